@@ -2,12 +2,17 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +39,8 @@ func ActionCommand(cfg *config.Config) *Command {
 		actionUpdateCommand(cfg),
 		actionApproveCommand(cfg),
 		actionRejectCommand(cfg),
+		actionRetryCommand(cfg),
+		actionPurgeCommand(cfg),
 	}
 
 	return cmd
@@ -63,6 +70,60 @@ func (f *fieldFlag) Set(val string) error {
 	return nil
 }
 
+// readInput reads a file, or stdin when path is "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// readFieldsFile loads an action fields map from a JSON object file, as
+// written by `action show --fields-json`. A path of "-" reads stdin.
+func readFieldsFile(path string) (map[string]string, error) {
+	data, err := readInput(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fields file: %w", err)
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("fields file must be a JSON object of string values: %w", err)
+	}
+	// null unmarshals without error into a nil map
+	if fields == nil {
+		return nil, fmt.Errorf("fields file must be a JSON object of string values, not null")
+	}
+	return fields, nil
+}
+
+// actionPayload is a whole proposed action as JSON, the input of
+// `action new --from-json`.
+type actionPayload struct {
+	Title      string            `json:"title"`
+	ActionType string            `json:"action_type"`
+	ProposedBy string            `json:"proposed_by"`
+	Body       string            `json:"body"`
+	Fields     map[string]string `json:"fields"`
+}
+
+// readActionPayload loads an actionPayload from a JSON file, or stdin when
+// path is "-". Unknown keys are rejected so typos don't drop data.
+func readActionPayload(path string) (*actionPayload, error) {
+	data, err := readInput(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read action JSON: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var payload actionPayload
+	if err := dec.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid action JSON: %w", err)
+	}
+	return &payload, nil
+}
+
 func actionNewCommand(cfg *config.Config) *Command {
 	fs := flag.NewFlagSet("new", flag.ContinueOnError)
 	actionType := fs.String("action-type", "", "Action type (e.g. task_create, calendar_reschedule, or any plugin type)")
@@ -70,29 +131,90 @@ func actionNewCommand(cfg *config.Config) *Command {
 	body := fs.String("body", "", "Reasoning/context for the action")
 	fields := &fieldFlag{values: make(map[string]string)}
 	fs.Var(fields, "field", "key=value field (repeatable)")
+	fieldsFile := fs.String("fields-file", "", "JSON object of fields (e.g. from action show --fields-json; - for stdin)")
+	fromJSON := fs.String("from-json", "", "JSON action {title, action_type, proposed_by, body, fields} (- for stdin)")
+	execute := fs.Bool("execute", false, "Approve and execute immediately (skips review)")
 
 	return &Command{
 		Name:        "new",
-		Usage:       "atask action new <title> [options]",
+		Usage:       "atask action new <title> [options] [--fields-file path] [--from-json path] [--execute]",
 		Description: "Create a proposed action",
 		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
-			if len(args) == 0 {
+			if len(args) == 0 && *fromJSON == "" {
 				return fmt.Errorf("usage: atask action new <title> --action-type <type> [--field key=value ...]")
 			}
+			if *fromJSON == "-" && *fieldsFile == "-" {
+				return fmt.Errorf("--from-json and --fields-file cannot both read stdin")
+			}
 
-			title := args[0]
-			if *actionType == "" {
+			// Start from the JSON payload, if any; explicit arguments and
+			// flags override its values.
+			payload := &actionPayload{ProposedBy: *proposedBy}
+			if *fromJSON != "" {
+				var err error
+				payload, err = readActionPayload(*fromJSON)
+				if err != nil {
+					return err
+				}
+				if payload.ProposedBy == "" {
+					payload.ProposedBy = *proposedBy
+				}
+			}
+			fs.Visit(func(f *flag.Flag) {
+				switch f.Name {
+				case "action-type":
+					payload.ActionType = *actionType
+				case "proposed-by":
+					payload.ProposedBy = *proposedBy
+				case "body":
+					payload.Body = *body
+				}
+			})
+			if len(args) > 0 {
+				payload.Title = args[0]
+			}
+
+			if payload.Title == "" {
+				return fmt.Errorf("action JSON is missing required key: title")
+			}
+			if payload.ActionType == "" {
+				if *fromJSON != "" {
+					return fmt.Errorf("action JSON is missing required key: action_type")
+				}
 				return fmt.Errorf("--action-type is required")
 			}
 
-			bodyText := *body
+			// --field values override the same keys from --fields-file,
+			// which override the payload's fields
+			actionFields := payload.Fields
+			if actionFields == nil {
+				actionFields = make(map[string]string)
+			}
+			if *fieldsFile != "" {
+				fileFields, err := readFieldsFile(*fieldsFile)
+				if err != nil {
+					return err
+				}
+				for k, v := range fileFields {
+					actionFields[k] = v
+				}
+			}
+			for k, v := range fields.values {
+				actionFields[k] = v
+			}
 
-			action, err := task.CreateAction(cfg.NotesDirectory, title, *actionType, *proposedBy, bodyText, fields.values)
+			action, err := task.CreateAction(cfg.NotesDirectory, payload.Title, payload.ActionType, payload.ProposedBy, payload.Body, actionFields)
 			if err != nil {
 				return err
 			}
 
+			// Trusted use only: run the approve flow right away. A failed
+			// action is left pending in the queue like a failed approve.
+			if *execute {
+				return approveAction(cfg, action, 0)
+			}
+
 			if globalFlags.JSON {
 				return printActionJSON(action)
 			}
@@ -128,6 +250,7 @@ func actionListCommand(cfg *config.Config) *Command {
 				}
 				actions = append(actions, archived...)
 			}
+			warnParseErrors(scanner)
 
 			// Filter to pending only unless --all
 			if !*showAll {
@@ -140,6 +263,10 @@ func actionListCommand(cfg *config.Config) *Command {
 				actions = pending
 			}
 
+			sort.Slice(actions, func(i, j int) bool {
+				return actions[i].IndexID < actions[j].IndexID
+			})
+
 			if globalFlags.JSON {
 				return printActionsJSON(actions)
 			}
@@ -181,57 +308,197 @@ func actionListCommand(cfg *config.Config) *Command {
 }
 
 func actionShowCommand(cfg *config.Config) *Command {
-	return &Command{
+	var (
+		diff       bool
+		fieldsJSON bool
+	)
+
+	cmd := &Command{
 		Name:        "show",
-		Usage:       "atask action show <id>",
+		Usage:       "atask action show <id> [--diff | --fields-json]",
 		Description: "Show action details",
-		Run: func(cmd *Command, args []string) error {
-			if len(args) == 0 {
-				return fmt.Errorf("usage: atask action show <id>")
+		Flags:       flag.NewFlagSet("action-show", flag.ExitOnError),
+	}
+
+	cmd.Flags.BoolVar(&diff, "diff", false, "Preview field-by-field changes against the target entity")
+	cmd.Flags.BoolVar(&fieldsJSON, "fields-json", false, "Print only the fields map as JSON (for action new --fields-file)")
+
+	cmd.Run = func(c *Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("usage: atask action show <id>")
+		}
+
+		action, err := lookupAction(cfg.NotesDirectory, args[0])
+		if err != nil {
+			return err
+		}
+
+		if fieldsJSON {
+			fields := action.Fields
+			if fields == nil {
+				fields = map[string]string{}
 			}
+			data, err := json.MarshalIndent(fields, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
 
-			action, err := lookupAction(cfg.NotesDirectory, args[0])
+		var preview *actionDiff
+		if diff {
+			preview = diffAction(cfg.NotesDirectory, action)
+		}
+
+		if globalFlags.JSON {
+			type jsonAction struct {
+				*denote.Action
+				Content string      `json:"content,omitempty"`
+				Diff    *actionDiff `json:"diff,omitempty"`
+			}
+			ja := jsonAction{Action: action, Content: action.Content, Diff: preview}
+			data, err := json.MarshalIndent(ja, "", "  ")
 			if err != nil {
-				return err
+				return fmt.Errorf("failed to marshal JSON: %w", err)
 			}
+			fmt.Println(string(data))
+			return nil
+		}
 
-			if globalFlags.JSON {
-				type jsonAction struct {
-					*denote.Action
-					Content string `json:"content,omitempty"`
-				}
-				ja := jsonAction{Action: action, Content: action.Content}
-				data, err := json.MarshalIndent(ja, "", "  ")
-				if err != nil {
-					return fmt.Errorf("failed to marshal JSON: %w", err)
+		fmt.Printf("# %s (#%d)\n\n", action.Title, action.IndexID)
+		fmt.Printf("  Action Type: %s\n", action.ActionType)
+		fmt.Printf("  Status:      %s\n", action.Status)
+		fmt.Printf("  Proposed By: %s\n", action.ProposedBy)
+		fmt.Printf("  Proposed At: %s\n", action.ProposedAt)
+		fmt.Println()
+
+		if preview != nil {
+			if preview.Resolved {
+				fmt.Printf("  Changes to %s:\n", preview.Target)
+			} else {
+				fmt.Printf("  Proposed fields (target %s could not be resolved):\n", preview.Target)
+			}
+			for _, ch := range preview.Changes {
+				switch {
+				case !preview.Resolved:
+					fmt.Printf("    %s: %s\n", ch.Field, ch.After)
+				case ch.Changed:
+					fmt.Printf("    %s: %s %s %s\n", ch.Field, displayDiffValue(ch.Before), icons(cfg).arrow, displayDiffValue(ch.After))
+				default:
+					fmt.Printf("    %s: %s (unchanged)\n", ch.Field, displayDiffValue(ch.After))
 				}
-				fmt.Println(string(data))
-				return nil
 			}
-
-			fmt.Printf("# %s (#%d)\n\n", action.Title, action.IndexID)
-			fmt.Printf("  Action Type: %s\n", action.ActionType)
-			fmt.Printf("  Status:      %s\n", action.Status)
-			fmt.Printf("  Proposed By: %s\n", action.ProposedBy)
-			fmt.Printf("  Proposed At: %s\n", action.ProposedAt)
 			fmt.Println()
-
-			if len(action.Fields) > 0 {
-				fmt.Println("  Fields:")
-				for k, v := range action.Fields {
-					fmt.Printf("    %s: %s\n", k, v)
-				}
-				fmt.Println()
+		} else if len(action.Fields) > 0 {
+			fmt.Println("  Fields:")
+			for _, k := range sortedFieldKeys(action.Fields) {
+				fmt.Printf("    %s: %s\n", k, action.Fields[k])
 			}
+			fmt.Println()
+		}
+
+		if action.Content != "" {
+			fmt.Println("  Reasoning:")
+			fmt.Printf("  %s\n", action.Content)
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+// actionDiff previews what approving an action would change on its target
+type actionDiff struct {
+	Target   string        `json:"target"`
+	Resolved bool          `json:"resolved"`
+	Changes  []fieldChange `json:"changes"`
+}
+
+// fieldChange is one field's before/after value in an actionDiff
+type fieldChange struct {
+	Field   string `json:"field"`
+	Before  string `json:"before,omitempty"`
+	After   string `json:"after"`
+	Changed bool   `json:"changed"`
+}
 
-			if action.Content != "" {
-				fmt.Println("  Reasoning:")
-				fmt.Printf("  %s\n", action.Content)
+// diffAction compares an action's fields with the current state of its
+// target. Only task_update targets can be resolved locally; anything else
+// (including tasks that no longer exist) lists the proposed fields only.
+func diffAction(dir string, action *denote.Action) *actionDiff {
+	targetID := action.Fields["target_id"]
+	d := &actionDiff{Target: targetID}
+
+	var t *denote.Task
+	if action.ActionType == denote.ActionTypeTaskUpdate && targetID != "" {
+		if found, err := lookupTask(dir, targetID); err == nil {
+			t = found
+			d.Resolved = true
+			d.Target = fmt.Sprintf("task #%d (%s)", t.IndexID, t.Title)
+		}
+	}
+
+	for _, k := range sortedFieldKeys(action.Fields) {
+		if k == "target_id" {
+			continue
+		}
+		ch := fieldChange{Field: k, After: action.Fields[k]}
+		if t != nil {
+			ch.Before, ch.After = taskFieldChange(t, k, ch.After)
+			ch.Changed = ch.Before != ch.After
+		}
+		d.Changes = append(d.Changes, ch)
+	}
+
+	return d
+}
+
+// taskFieldChange returns a task's current value for a task_update field and
+// the value it would have after the update, normalizing dates and people.
+func taskFieldChange(t *denote.Task, field, proposed string) (before, after string) {
+	normalizeDate := func(v string) string {
+		if parsed, err := denote.ParseNaturalDate(v); err == nil {
+			return parsed
+		}
+		return v
+	}
+
+	switch field {
+	case "title":
+		return t.Title, proposed
+	case "status":
+		return t.TaskMetadata.Status, proposed
+	case "priority":
+		return t.TaskMetadata.Priority, proposed
+	case "due":
+		return t.TaskMetadata.DueDate, normalizeDate(proposed)
+	case "area":
+		return t.TaskMetadata.Area, proposed
+	case "project":
+		return t.TaskMetadata.ProjectID, proposed
+	case "plan_for":
+		return t.PlannedFor, normalizeDate(proposed)
+	case "add_person":
+		people := append([]string{}, t.RelatedPeople...)
+		for _, p := range strings.Split(proposed, ",") {
+			if p = strings.TrimSpace(p); p != "" && !slices.Contains(people, p) {
+				people = append(people, p)
 			}
+		}
+		return strings.Join(t.RelatedPeople, ", "), strings.Join(people, ", ")
+	default:
+		return "", proposed
+	}
+}
 
-			return nil
-		},
+// displayDiffValue renders an empty diff value as (none)
+func displayDiffValue(v string) string {
+	if v == "" {
+		return "(none)"
 	}
+	return v
 }
 
 func actionUpdateCommand(cfg *config.Config) *Command {
@@ -305,13 +572,18 @@ func actionUpdateCommand(cfg *config.Config) *Command {
 }
 
 func actionApproveCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("approve", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "Print the command that would run without executing it")
+	timeout := fs.Duration("timeout", 0, "Kill a plugin that runs longer than this (default: actions.plugin_timeout, 30s)")
+
 	return &Command{
 		Name:        "approve",
-		Usage:       "atask action approve <id>",
+		Usage:       "atask action approve <id> [--dry-run] [--timeout 30s]",
 		Description: "Approve and execute the action",
+		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
 			if len(args) == 0 {
-				return fmt.Errorf("usage: atask action approve <id>")
+				return fmt.Errorf("usage: atask action approve <id> [--dry-run]")
 			}
 
 			action, err := lookupAction(cfg.NotesDirectory, args[0])
@@ -323,25 +595,206 @@ func actionApproveCommand(cfg *config.Config) *Command {
 				return fmt.Errorf("cannot approve action with status: %s", action.Status)
 			}
 
-			// Execute the action directly — stay pending on failure so user can fix and retry
-			result, execErr := executeAction(action)
+			if *dryRun {
+				return printActionPlan(cfg, action)
+			}
 
-			if execErr != nil {
-				if globalFlags.JSON {
-					errResult := map[string]interface{}{
-						"status": "failed",
-						"error":  execErr.Error(),
-					}
-					data, _ := json.MarshalIndent(errResult, "", "  ")
-					fmt.Println(string(data))
-				} else if !globalFlags.Quiet {
-					fmt.Fprintf(os.Stderr, "Action failed: %s\n", execErr.Error())
-				}
-				return execErr
+			return approveAction(cfg, action, *timeout)
+		},
+	}
+}
+
+// printActionPlan prints the command approving the action would run,
+// leaving the action untouched.
+func printActionPlan(cfg *config.Config, action *denote.Action) error {
+	c, plugin, err := buildActionCommand(context.Background(), cfg, action)
+	if err != nil {
+		return err
+	}
+
+	var stdin string
+	if plugin {
+		data, err := io.ReadAll(c.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read plugin input: %w", err)
+		}
+		stdin = string(data)
+	}
+
+	if globalFlags.JSON {
+		plan := struct {
+			Status string   `json:"status"`
+			Binary string   `json:"binary"`
+			Argv   []string `json:"argv"`
+			Plugin bool     `json:"plugin"`
+			Stdin  string   `json:"stdin,omitempty"`
+		}{
+			Status: "dry_run",
+			Binary: c.Path,
+			Argv:   c.Args,
+			Plugin: plugin,
+			Stdin:  stdin,
+		}
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	quoted := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		quoted[i] = shellQuote(arg)
+	}
+	fmt.Printf("Would run: %s\n", strings.Join(quoted, " "))
+	fmt.Printf("Binary:    %s\n", c.Path)
+	if plugin {
+		fmt.Printf("Stdin:     %s\n", stdin)
+	}
+	return nil
+}
+
+// shellQuote single-quotes arg for display when it contains anything
+// beyond plain word characters.
+func shellQuote(arg string) string {
+	if arg != "" && strings.IndexFunc(arg, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./,:=@", r))
+	}) < 0 {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// approveAction executes a pending action, then marks it executed and
+// archives it. On failure the action stays pending so it can be fixed and
+// retried. A plugin's structured result (see pluginResult) is recorded on
+// the action before it is archived. A plugin still running after timeout
+// (0 for the configured default) is killed and the action fails.
+func approveAction(cfg *config.Config, action *denote.Action, timeout time.Duration) error {
+	timeout = pluginTimeout(cfg, timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, plugin, execErr := executeAction(ctx, cfg, action)
+	if execErr != nil && plugin && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		execErr = fmt.Errorf("plugin %s timed out after %s and was killed", action.ActionType, timeout)
+	}
+
+	var pr *pluginResult
+	if execErr == nil && plugin {
+		pr = parsePluginResult(result)
+		if pr != nil && pr.failed() {
+			execErr = fmt.Errorf("plugin reported %s: %s", pr.Status, pr.Message)
+		}
+	}
+
+	if execErr != nil {
+		if globalFlags.JSON {
+			errResult := map[string]interface{}{
+				"status": "failed",
+				"error":  execErr.Error(),
+			}
+			data, _ := json.MarshalIndent(errResult, "", "  ")
+			fmt.Println(string(data))
+		} else if !globalFlags.Quiet {
+			fmt.Fprintf(os.Stderr, "Action failed: %s\n", execErr.Error())
+		}
+		return execErr
+	}
+
+	// Mark as executed and archive
+	action.Status = denote.ActionExecuted
+	action.Modified = acore.Now()
+	if pr != nil && pr.CreatedID != "" {
+		if action.Fields == nil {
+			action.Fields = make(map[string]string)
+		}
+		action.Fields["created_id"] = pr.CreatedID
+	}
+	if err := acore.UpdateFrontmatter(acore.NewLocalStore(filepath.Dir(action.FilePath)), filepath.Base(action.FilePath), action); err != nil {
+		return fmt.Errorf("failed to update action status: %w", err)
+	}
+
+	if err := task.ArchiveAction(cfg.NotesDirectory, action); err != nil {
+		return fmt.Errorf("failed to archive action: %w", err)
+	}
+
+	if globalFlags.JSON {
+		resultMap := map[string]interface{}{
+			"status": "executed",
+			"result": string(result),
+		}
+		if pr != nil {
+			if pr.Message != "" {
+				resultMap["message"] = pr.Message
+			}
+			if pr.CreatedID != "" {
+				resultMap["created_id"] = pr.CreatedID
+			}
+		}
+		data, _ := json.MarshalIndent(resultMap, "", "  ")
+		fmt.Println(string(data))
+	} else if !globalFlags.Quiet {
+		fmt.Printf("Action #%d executed successfully\n", action.IndexID)
+		if pr != nil && pr.Message != "" {
+			fmt.Printf("  %s\n", pr.Message)
+		}
+		if pr != nil && pr.CreatedID != "" {
+			fmt.Printf("  Created: %s\n", pr.CreatedID)
+		}
+	}
+
+	return nil
+}
+
+// pluginResult is the optional structured result a plugin may print on
+// stdout. A plugin that prints anything else is treated as plain output.
+type pluginResult struct {
+	Status    string `json:"status"`
+	Message   string `json:"message"`
+	CreatedID string `json:"created_id"`
+}
+
+// failed reports whether the plugin said the action did not succeed.
+func (r *pluginResult) failed() bool {
+	return r.Status == "failed" || r.Status == "error"
+}
+
+// parsePluginResult decodes plugin output as a pluginResult. It returns
+// nil when the output is not a JSON object or carries none of the
+// contract's keys.
+func parsePluginResult(output []byte) *pluginResult {
+	var r pluginResult
+	if err := json.Unmarshal(bytes.TrimSpace(output), &r); err != nil {
+		return nil
+	}
+	if r.Status == "" && r.Message == "" && r.CreatedID == "" {
+		return nil
+	}
+	return &r
+}
+
+func actionRejectCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "reject",
+		Usage:       "atask action reject <id>",
+		Description: "Reject and archive the action",
+		Run: func(cmd *Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("usage: atask action reject <id>")
 			}
 
-			// Mark as executed and archive
-			action.Status = denote.ActionExecuted
+			action, err := lookupAction(cfg.NotesDirectory, args[0])
+			if err != nil {
+				return err
+			}
+
+			if action.Status != denote.ActionPending {
+				return fmt.Errorf("cannot reject action with status: %s", action.Status)
+			}
+
+			action.Status = denote.ActionRejected
 			action.Modified = acore.Now()
 			if err := acore.UpdateFrontmatter(acore.NewLocalStore(filepath.Dir(action.FilePath)), filepath.Base(action.FilePath), action); err != nil {
 				return fmt.Errorf("failed to update action status: %w", err)
@@ -353,65 +806,214 @@ func actionApproveCommand(cfg *config.Config) *Command {
 
 			if globalFlags.JSON {
 				resultMap := map[string]interface{}{
-					"status": "executed",
-					"result": string(result),
+					"status": "rejected",
 				}
 				data, _ := json.MarshalIndent(resultMap, "", "  ")
 				fmt.Println(string(data))
 			} else if !globalFlags.Quiet {
-				fmt.Printf("Action #%d executed successfully\n", action.IndexID)
+				fmt.Printf("Action #%d rejected\n", action.IndexID)
 			}
-
 			return nil
 		},
 	}
 }
 
-func actionRejectCommand(cfg *config.Config) *Command {
+func actionRetryCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("retry", flag.ExitOnError)
+	approve := fs.Bool("approve", false, "Approve and execute the action immediately")
+
 	return &Command{
-		Name:        "reject",
-		Usage:       "atask action reject <id>",
-		Description: "Reject and archive the action",
+		Name:        "retry",
+		Usage:       "atask action retry <id> [--approve]",
+		Description: "Return a failed archived action to the queue",
+		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
 			if len(args) == 0 {
-				return fmt.Errorf("usage: atask action reject <id>")
+				return fmt.Errorf("usage: atask action retry <id> [--approve]")
 			}
 
-			action, err := lookupAction(cfg.NotesDirectory, args[0])
+			action, err := task.FindArchivedAction(cfg.NotesDirectory, args[0])
 			if err != nil {
 				return err
 			}
 
-			if action.Status != denote.ActionPending {
-				return fmt.Errorf("cannot reject action with status: %s", action.Status)
+			if action.Status != denote.ActionFailed {
+				return fmt.Errorf("cannot retry action with status: %s", action.Status)
 			}
 
-			action.Status = denote.ActionRejected
+			if err := task.UnarchiveAction(cfg.NotesDirectory, action); err != nil {
+				return err
+			}
+
+			action.Status = denote.ActionPending
 			action.Modified = acore.Now()
 			if err := acore.UpdateFrontmatter(acore.NewLocalStore(filepath.Dir(action.FilePath)), filepath.Base(action.FilePath), action); err != nil {
 				return fmt.Errorf("failed to update action status: %w", err)
 			}
 
-			if err := task.ArchiveAction(cfg.NotesDirectory, action); err != nil {
-				return fmt.Errorf("failed to archive action: %w", err)
+			if *approve {
+				return approveAction(cfg, action, 0)
 			}
 
 			if globalFlags.JSON {
 				resultMap := map[string]interface{}{
-					"status": "rejected",
+					"status":   "pending",
+					"index_id": action.IndexID,
 				}
 				data, _ := json.MarshalIndent(resultMap, "", "  ")
 				fmt.Println(string(data))
 			} else if !globalFlags.Quiet {
-				fmt.Printf("Action #%d rejected\n", action.IndexID)
+				fmt.Printf("Action #%d returned to the queue\n", action.IndexID)
+			}
+			return nil
+		},
+	}
+}
+
+func actionPurgeCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("purge", flag.ExitOnError)
+	olderThan := fs.String("older-than", "90d", "Delete archived actions proposed more than this many days ago (Nd)")
+	status := fs.String("status", "", "Only purge actions with this status (executed, rejected, failed)")
+	dryRun := fs.Bool("dry-run", false, "Show which actions would be deleted without deleting them")
+
+	return &Command{
+		Name:        "purge",
+		Usage:       "atask action purge [--older-than 90d] [--status rejected] [--dry-run]",
+		Description: "Delete old archived actions",
+		Flags:       fs,
+		Run: func(cmd *Command, args []string) error {
+			days, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(*olderThan), "d"))
+			if err != nil || days < 0 {
+				return fmt.Errorf("invalid --older-than: %s (expected Nd, e.g. 90d)", *olderThan)
+			}
+			switch *status {
+			case "", denote.ActionExecuted, denote.ActionRejected, denote.ActionFailed:
+			default:
+				return fmt.Errorf("invalid --status: %s (valid: executed, rejected, failed)", *status)
+			}
+
+			scanner := denote.NewScanner(cfg.NotesDirectory)
+			archived, err := scanner.FindArchivedActions()
+			if err != nil {
+				return err
+			}
+			warnParseErrors(scanner)
+
+			sort.Slice(archived, func(i, j int) bool {
+				return archived[i].IndexID < archived[j].IndexID
+			})
+
+			now := time.Now()
+			purged := []*denote.Action{}
+			failed := 0
+			for _, a := range archived {
+				if !isPurgeable(a, days, *status, now) {
+					continue
+				}
+				if *dryRun {
+					purged = append(purged, a)
+					if !globalFlags.JSON {
+						fmt.Printf("Would purge action #%d: %s (%s)\n", a.IndexID, a.Title, a.Status)
+					}
+					continue
+				}
+				if err := os.Remove(a.FilePath); err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to purge action #%d: %v\n", a.IndexID, err)
+					failed++
+					continue
+				}
+				purged = append(purged, a)
+				if !globalFlags.JSON && !globalFlags.Quiet {
+					fmt.Printf("Purged action #%d: %s (%s)\n", a.IndexID, a.Title, a.Status)
+				}
+			}
+
+			if globalFlags.JSON {
+				type purgedAction struct {
+					ID         string `json:"id"`
+					IndexID    int    `json:"index_id"`
+					Title      string `json:"title"`
+					Status     string `json:"status"`
+					ProposedAt string `json:"proposed_at"`
+				}
+				result := struct {
+					DryRun  bool           `json:"dry_run"`
+					Count   int            `json:"count"`
+					Actions []purgedAction `json:"actions"`
+				}{DryRun: *dryRun, Count: len(purged), Actions: []purgedAction{}}
+				for _, a := range purged {
+					result.Actions = append(result.Actions, purgedAction{a.ID, a.IndexID, a.Title, a.Status, a.ProposedAt})
+				}
+				data, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Println(string(data))
+			} else if !globalFlags.Quiet {
+				verb := "Purged"
+				if *dryRun {
+					verb = "Would purge"
+				}
+				fmt.Printf("%s %d archived action(s)\n", verb, len(purged))
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d action(s) could not be purged", failed)
 			}
 			return nil
 		},
 	}
 }
 
-// executePlugin runs an external plugin script with JSON on stdin.
-func executePlugin(pluginPath string, action *denote.Action) ([]byte, error) {
+// isPurgeable reports whether an archived action was proposed more than
+// days*24h before now and, if status is set, has that status. Actions
+// whose proposed_at is missing or not RFC3339 are never purged.
+func isPurgeable(a *denote.Action, days int, status string, now time.Time) bool {
+	if status != "" && a.Status != status {
+		return false
+	}
+	proposed, err := time.Parse(time.RFC3339, a.ProposedAt)
+	if err != nil {
+		return false
+	}
+	return proposed.Before(now.Add(-time.Duration(days) * 24 * time.Hour))
+}
+
+// pluginTimeout resolves how long a plugin may run: the explicit timeout
+// if set, else actions.plugin_timeout, else 30 seconds.
+func pluginTimeout(cfg *config.Config, timeout time.Duration) time.Duration {
+	if timeout > 0 {
+		return timeout
+	}
+	if cfg.Actions.PluginTimeout > 0 {
+		return time.Duration(cfg.Actions.PluginTimeout) * time.Second
+	}
+	return 30 * time.Second
+}
+
+// pluginEnvVars are passed through from atask's environment to plugins;
+// everything else is dropped.
+var pluginEnvVars = []string{"PATH", "HOME", "USER", "LOGNAME", "LANG", "LC_ALL", "TZ", "TMPDIR", "XDG_CONFIG_HOME", "XDG_DATA_HOME"}
+
+// pluginEnv builds the curated environment for a plugin run: the
+// pass-through variables plus ATASK_* variables describing the action.
+func pluginEnv(cfg *config.Config, action *denote.Action) []string {
+	var env []string
+	for _, name := range pluginEnvVars {
+		if v, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+v)
+		}
+	}
+	return append(env,
+		"ATASK_NOTES_DIR="+cfg.NotesDirectory,
+		"ATASK_ACTION_ID="+action.ID,
+		"ATASK_ACTION_TYPE="+action.ActionType,
+	)
+}
+
+// pluginCommand builds the command for an external plugin script, with
+// the action as JSON on stdin. The plugin is killed when ctx is done.
+func pluginCommand(ctx context.Context, cfg *config.Config, pluginPath string, action *denote.Action) (*exec.Cmd, error) {
 	input := map[string]interface{}{
 		"action_type": action.ActionType,
 		"title":       action.Title,
@@ -422,8 +1024,17 @@ func executePlugin(pluginPath string, action *denote.Action) ([]byte, error) {
 		return nil, fmt.Errorf("failed to marshal plugin input: %w", err)
 	}
 
-	cmd := exec.Command(pluginPath)
+	cmd := exec.CommandContext(ctx, pluginPath)
 	cmd.Stdin = bytes.NewReader(inputJSON)
+	cmd.Env = pluginEnv(cfg, action)
+	// Don't wait on output pipes held open by the plugin's own children
+	// once it has been killed
+	cmd.WaitDelay = time.Second
+	return cmd, nil
+}
+
+// executePlugin runs a plugin command built by pluginCommand.
+func executePlugin(cmd *exec.Cmd) ([]byte, error) {
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -445,15 +1056,51 @@ func pluginDir() string {
 }
 
 // executeAction maps action_type + fields to a CLI command and runs it.
-func executeAction(action *denote.Action) ([]byte, error) {
+// plugin reports whether the action ran as a plugin.
+func executeAction(ctx context.Context, cfg *config.Config, action *denote.Action) (output []byte, plugin bool, err error) {
+	c, plugin, err := buildActionCommand(ctx, cfg, action)
+	if err != nil {
+		return nil, false, err
+	}
+	if plugin {
+		output, err := executePlugin(c)
+		return output, true, err
+	}
+
+	output, err = c.CombinedOutput()
+	if err != nil {
+		return nil, false, fmt.Errorf("command failed: %s\nOutput: %s", err, string(output))
+	}
+
+	return output, false, nil
+}
+
+// buildActionCommand resolves the command that carries out an action
+// without running it: a plugin from pluginDir when one exists for the
+// action type, otherwise the built-in app command from actionCommandArgs.
+// plugin reports which of the two it returned.
+func buildActionCommand(ctx context.Context, cfg *config.Config, action *denote.Action) (c *exec.Cmd, plugin bool, err error) {
 	// Try plugin first
 	if dir := pluginDir(); dir != "" {
 		pluginPath := filepath.Join(dir, action.ActionType)
 		if info, err := os.Stat(pluginPath); err == nil && !info.IsDir() {
-			return executePlugin(pluginPath, action)
+			c, err := pluginCommand(ctx, cfg, pluginPath, action)
+			return c, true, err
 		}
 	}
 
+	bin, args, err := actionCommandArgs(action)
+	if err != nil {
+		return nil, false, err
+	}
+
+	args = append(args, "--json", "--quiet")
+	return exec.Command(bin, args...), false, nil
+}
+
+// actionCommandArgs maps a built-in action type to the app binary and
+// arguments that carry it out. Each action runs as a single command.
+func actionCommandArgs(action *denote.Action) (string, []string, error) {
 	var bin string
 	var args []string
 
@@ -472,13 +1119,15 @@ func executeAction(action *denote.Action) ([]byte, error) {
 		addFieldFlag(action.Fields, &args, "tags", "--tags")
 		addFieldFlag(action.Fields, &args, "estimate", "--estimate")
 		addFieldFlag(action.Fields, &args, "recur", "--recur")
-		// add_person handled as post-creation step below
+		addFieldFlag(action.Fields, &args, "add_person", "--add-person")
+		addFieldFlag(action.Fields, &args, "add_task", "--add-task")
+		addFieldFlag(action.Fields, &args, "add_idea", "--add-idea")
 
 	case denote.ActionTypeTaskUpdate:
 		bin = "atask"
 		targetID := action.Fields["target_id"]
 		if targetID == "" {
-			return nil, fmt.Errorf("task_update requires target_id field")
+			return "", nil, fmt.Errorf("task_update requires target_id field")
 		}
 		args = []string{"update"}
 		addFieldFlag(action.Fields, &args, "title", "--title")
@@ -491,6 +1140,43 @@ func executeAction(action *denote.Action) ([]byte, error) {
 		addFieldFlag(action.Fields, &args, "add_person", "--add-person")
 		args = append(args, targetID)
 
+	case denote.ActionTypeTaskDone:
+		bin = "atask"
+		targetID := action.Fields["target_id"]
+		if targetID == "" {
+			return "", nil, fmt.Errorf("task_done requires target_id field")
+		}
+		args = []string{"done", targetID}
+
+	case denote.ActionTypeProjectCreate:
+		bin = "atask"
+		title := action.Fields["title"]
+		if title == "" {
+			title = action.Title
+		}
+		args = []string{"project", "new", title}
+		addFieldFlag(action.Fields, &args, "priority", "--priority")
+		addFieldFlag(action.Fields, &args, "due", "--due")
+		addFieldFlag(action.Fields, &args, "area", "--area")
+		addFieldFlag(action.Fields, &args, "start", "--start")
+		addFieldFlag(action.Fields, &args, "tags", "--tags")
+
+	case denote.ActionTypeProjectUpdate:
+		bin = "atask"
+		targetID := action.Fields["target_id"]
+		if targetID == "" {
+			return "", nil, fmt.Errorf("project_update requires target_id field")
+		}
+		args = []string{"project", "update"}
+		addFieldFlag(action.Fields, &args, "title", "--title")
+		addFieldFlag(action.Fields, &args, "status", "--status")
+		addFieldFlag(action.Fields, &args, "priority", "--priority")
+		addFieldFlag(action.Fields, &args, "due", "--due")
+		addFieldFlag(action.Fields, &args, "area", "--area")
+		addFieldFlag(action.Fields, &args, "start", "--start")
+		addFieldFlag(action.Fields, &args, "add_person", "--add-person")
+		args = append(args, targetID)
+
 	case denote.ActionTypeIdeaCreate:
 		bin = "anote"
 		title := action.Fields["title"]
@@ -505,7 +1191,7 @@ func executeAction(action *denote.Action) ([]byte, error) {
 		bin = "anote"
 		targetID := action.Fields["target_id"]
 		if targetID == "" {
-			return nil, fmt.Errorf("idea_update requires target_id field")
+			return "", nil, fmt.Errorf("idea_update requires target_id field")
 		}
 		args = []string{"update", targetID}
 		addFieldFlag(action.Fields, &args, "title", "--title")
@@ -517,7 +1203,7 @@ func executeAction(action *denote.Action) ([]byte, error) {
 		bin = "apeople"
 		targetID := action.Fields["target_id"]
 		if targetID == "" {
-			return nil, fmt.Errorf("people_update requires target_id field")
+			return "", nil, fmt.Errorf("people_update requires target_id field")
 		}
 		args = []string{"update", targetID}
 		addFieldFlag(action.Fields, &args, "state", "-state")
@@ -527,48 +1213,20 @@ func executeAction(action *denote.Action) ([]byte, error) {
 		bin = "apeople"
 		targetID := action.Fields["target_id"]
 		if targetID == "" {
-			return nil, fmt.Errorf("people_log requires target_id field")
+			return "", nil, fmt.Errorf("people_log requires target_id field")
 		}
 		note := action.Fields["note"]
 		if note == "" {
-			return nil, fmt.Errorf("people_log requires note field")
+			return "", nil, fmt.Errorf("people_log requires note field")
 		}
 		args = []string{"log", targetID, note}
 		addFieldFlag(action.Fields, &args, "interaction", "-interaction")
 
 	default:
-		return nil, fmt.Errorf("unknown action type: %s (no plugin found at %s)", action.ActionType, filepath.Join(pluginDir(), action.ActionType))
+		return "", nil, fmt.Errorf("unknown action type: %s (no plugin found at %s)", action.ActionType, filepath.Join(pluginDir(), action.ActionType))
 	}
 
-	args = append(args, "--json", "--quiet")
-	c := exec.Command(bin, args...)
-	output, err := c.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("command failed: %s\nOutput: %s", err, string(output))
-	}
-
-	// For task_create: if add_person is set, run a follow-up update to link people
-	// (atask new doesn't support --add-person, only atask update does)
-	if action.ActionType == denote.ActionTypeTaskCreate {
-		if addPerson, ok := action.Fields["add_person"]; ok && addPerson != "" {
-			// Parse index_id from the created task's JSON output
-			var created struct {
-				IndexID int `json:"index_id"`
-			}
-			if err := json.Unmarshal(output, &created); err == nil && created.IndexID > 0 {
-				updateArgs := []string{"update"}
-				addFieldFlag(action.Fields, &updateArgs, "add_person", "--add-person")
-				updateArgs = append(updateArgs, fmt.Sprintf("%d", created.IndexID), "--json", "--quiet")
-				uc := exec.Command("atask", updateArgs...)
-				if updateOut, updateErr := uc.CombinedOutput(); updateErr != nil {
-					// Non-fatal: task was created but linking failed
-					return output, fmt.Errorf("task created but linking people failed: %s\nOutput: %s", updateErr, string(updateOut))
-				}
-			}
-		}
-	}
-
-	return output, nil
+	return bin, args, nil
 }
 
 func addFieldFlag(fields map[string]string, args *[]string, fieldName, flagName string) {
@@ -622,6 +1280,19 @@ func formatAge(proposedAt string) string {
 	return fmt.Sprintf("%dd ago", days)
 }
 
+// sortedFieldKeys returns the keys of an action's fields in sorted order.
+func sortedFieldKeys(fields map[string]string) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// printActionJSON prints a single action as JSON. Output is byte-stable:
+// struct fields are emitted in declaration order and encoding/json writes
+// map keys (the action's Fields) in sorted order.
 func printActionJSON(action *denote.Action) error {
 	type jsonAction struct {
 		ID         string            `json:"id"`
@@ -661,6 +1332,8 @@ func printActionJSON(action *denote.Action) error {
 	return nil
 }
 
+// printActionsJSON prints actions as a JSON array, in the order given.
+// Like printActionJSON, each element's Fields are emitted with sorted keys.
 func printActionsJSON(actions []*denote.Action) error {
 	type jsonAction struct {
 		ID         string            `json:"id"`
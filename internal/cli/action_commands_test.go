@@ -0,0 +1,717 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"github.com/mph-llm-experiments/atask/internal/task"
+)
+
+func TestTaskCreateActionLinksPeopleInOneStep(t *testing.T) {
+	action := &denote.Action{
+		ActionMetadata: denote.ActionMetadata{
+			ActionType: denote.ActionTypeTaskCreate,
+			Fields: map[string]string{
+				"title":      "Follow up on proposal",
+				"add_person": "01KJ1KHY4NFGESK9DDS4YEGH2J, 01KJ1KHY4NFGESK9DDS4YEGH2K",
+			},
+		},
+	}
+
+	bin, args, err := actionCommandArgs(action)
+	if err != nil {
+		t.Fatalf("actionCommandArgs error = %v", err)
+	}
+	if bin != "atask" || len(args) < 2 || args[0] != "new" {
+		t.Fatalf("actionCommandArgs = %s %v, want a single atask new invocation", bin, args)
+	}
+
+	// The same invocation must carry every person for `new` to link
+	cmd := taskNewCommand(&config.Config{})
+	if err := cmd.Flags.Parse(args[2:]); err != nil {
+		t.Fatalf("new rejected action args %v: %v", args, err)
+	}
+	got := cmd.Flags.Lookup("add-person").Value.String()
+	want := "01KJ1KHY4NFGESK9DDS4YEGH2J,01KJ1KHY4NFGESK9DDS4YEGH2K"
+	if got != want {
+		t.Errorf("new --add-person = %q, want %q", got, want)
+	}
+}
+
+func TestActionRetryRequeuesFailedAction(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	// A failed action sitting in queue/archive/
+	action, err := task.CreateAction(dir, "Create follow-up", denote.ActionTypeTaskCreate, "agent", "",
+		map[string]string{"title": "Follow up"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	action.Status = denote.ActionFailed
+	if err := acore.UpdateFrontmatter(acore.NewLocalStore(filepath.Dir(action.FilePath)), filepath.Base(action.FilePath), action); err != nil {
+		t.Fatal(err)
+	}
+	if err := task.ArchiveAction(dir, action); err != nil {
+		t.Fatal(err)
+	}
+	id := strconv.Itoa(action.IndexID)
+
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = actionRetryCommand(cfg).Execute([]string{id})
+	})
+	if runErr != nil {
+		t.Fatalf("retry error = %v", runErr)
+	}
+
+	var result struct {
+		Status  string `json:"status"`
+		IndexID int    `json:"index_id"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("retry output is not JSON: %v\n%s", err, out)
+	}
+	if result.Status != denote.ActionPending || result.IndexID != action.IndexID {
+		t.Errorf("retry output = %+v, want pending #%d", result, action.IndexID)
+	}
+
+	requeued, err := lookupAction(dir, id)
+	if err != nil {
+		t.Fatalf("action not back in queue: %v", err)
+	}
+	if requeued.Status != denote.ActionPending {
+		t.Errorf("requeued status = %q, want pending", requeued.Status)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "queue", "archive", filepath.Base(requeued.FilePath))); !os.IsNotExist(err) {
+		t.Errorf("archived copy still present (stat err = %v)", err)
+	}
+
+	// Only failed archived actions can be retried
+	runErr = nil
+	captureStdout(t, func() {
+		runErr = actionRetryCommand(cfg).Execute([]string{id})
+	})
+	if runErr == nil {
+		t.Error("retry of an action no longer in the archive succeeded, want error")
+	}
+}
+
+func TestBuildActionCommandArgv(t *testing.T) {
+	// No plugins: every built-in type resolves to its app command
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		actionType string
+		title      string
+		fields     map[string]string
+		want       []string
+	}{
+		{
+			actionType: denote.ActionTypeTaskCreate,
+			title:      "Proposal title",
+			fields:     map[string]string{"title": "Write report", "priority": "p1", "due": "2026-11-01", "area": "work"},
+			want:       []string{"atask", "new", "Write report", "--priority", "p1", "--due", "2026-11-01", "--area", "work", "--json", "--quiet"},
+		},
+		{
+			actionType: denote.ActionTypeTaskUpdate,
+			fields:     map[string]string{"target_id": "42", "status": "done", "plan_for": "today"},
+			want:       []string{"atask", "update", "--status", "done", "--plan-for", "today", "42", "--json", "--quiet"},
+		},
+		{
+			actionType: denote.ActionTypeTaskDone,
+			fields:     map[string]string{"target_id": "42"},
+			want:       []string{"atask", "done", "42", "--json", "--quiet"},
+		},
+		{
+			actionType: denote.ActionTypeProjectCreate,
+			title:      "Launch site",
+			fields:     map[string]string{"priority": "p2", "due": "2026-12-01", "area": "work", "start": "2026-11-01"},
+			want:       []string{"atask", "project", "new", "Launch site", "--priority", "p2", "--due", "2026-12-01", "--area", "work", "--start", "2026-11-01", "--json", "--quiet"},
+		},
+		{
+			actionType: denote.ActionTypeProjectUpdate,
+			fields:     map[string]string{"target_id": "12", "status": "paused", "start": "2026-11-15"},
+			want:       []string{"atask", "project", "update", "--status", "paused", "--start", "2026-11-15", "12", "--json", "--quiet"},
+		},
+		{
+			actionType: denote.ActionTypeIdeaCreate,
+			title:      "Idea from title",
+			fields:     map[string]string{"kind": "question", "tags": "a,b"},
+			want:       []string{"anote", "new", "Idea from title", "--kind", "question", "--tags", "a,b", "--json", "--quiet"},
+		},
+		{
+			actionType: denote.ActionTypeIdeaUpdate,
+			fields:     map[string]string{"target_id": "7", "state": "active", "maturity": "seed"},
+			want:       []string{"anote", "update", "7", "--state", "active", "--maturity", "seed", "--json", "--quiet"},
+		},
+		{
+			actionType: denote.ActionTypePeopleUpdate,
+			fields:     map[string]string{"target_id": "3", "state": "ok", "plan_for": "2026-11-02"},
+			want:       []string{"apeople", "update", "3", "-state", "ok", "-plan-for", "2026-11-02", "--json", "--quiet"},
+		},
+		{
+			actionType: denote.ActionTypePeopleLog,
+			fields:     map[string]string{"target_id": "3", "note": "Caught up over lunch", "interaction": "meeting"},
+			want:       []string{"apeople", "log", "3", "Caught up over lunch", "-interaction", "meeting", "--json", "--quiet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.actionType, func(t *testing.T) {
+			action := &denote.Action{ActionMetadata: denote.ActionMetadata{ActionType: tt.actionType, Fields: tt.fields}}
+			action.Title = tt.title
+
+			c, plugin, err := buildActionCommand(context.Background(), &config.Config{}, action)
+			if err != nil {
+				t.Fatalf("buildActionCommand error = %v", err)
+			}
+			if plugin {
+				t.Error("buildActionCommand chose a plugin, want the built-in command")
+			}
+			if !reflect.DeepEqual(c.Args, tt.want) {
+				t.Errorf("argv = %q, want %q", c.Args, tt.want)
+			}
+		})
+	}
+
+	for _, actionType := range []string{denote.ActionTypeTaskUpdate, denote.ActionTypeTaskDone, denote.ActionTypeProjectUpdate} {
+		missing := &denote.Action{ActionMetadata: denote.ActionMetadata{ActionType: actionType, Fields: map[string]string{}}}
+		if _, _, err := buildActionCommand(context.Background(), &config.Config{}, missing); err == nil || !strings.Contains(err.Error(), "requires target_id") {
+			t.Errorf("%s without target_id: err = %v, want target_id error", actionType, err)
+		}
+	}
+}
+
+func TestActionApproveDryRunLeavesActionPending(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	action, err := task.CreateAction(dir, "Create follow-up", denote.ActionTypeTaskCreate, "agent", "",
+		map[string]string{"title": "Follow up with Sam"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := strconv.Itoa(action.IndexID)
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = actionApproveCommand(cfg).Execute([]string{id, "--dry-run"})
+	})
+	if runErr != nil {
+		t.Fatalf("approve --dry-run error = %v", runErr)
+	}
+	if want := "Would run: atask new 'Follow up with Sam' --json --quiet\n"; !strings.HasPrefix(out, want) {
+		t.Errorf("dry-run output = %q, want prefix %q", out, want)
+	}
+
+	after, err := lookupAction(dir, id)
+	if err != nil {
+		t.Fatalf("action left the queue: %v", err)
+	}
+	if after.Status != denote.ActionPending {
+		t.Errorf("status after dry run = %q, want pending", after.Status)
+	}
+}
+
+func TestActionNewFromJSON(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	payload := `{"title": "Create follow-up", "action_type": "task_create", "proposed_by": "planner",
+		"body": "Mentioned in standup", "fields": {"title": "Follow up", "priority": "p2"}}`
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "action.json")
+		if err := os.WriteFile(path, []byte(payload), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		var runErr error
+		captureStdout(t, func() {
+			runErr = actionNewCommand(cfg).Execute([]string{"--from-json", path, "--field", "priority=p1"})
+		})
+		if runErr != nil {
+			t.Fatalf("new --from-json error = %v", runErr)
+		}
+
+		actions, err := denote.NewScanner(dir).FindActions()
+		if err != nil || len(actions) != 1 {
+			t.Fatalf("FindActions = %d actions, %v; want 1", len(actions), err)
+		}
+		a := actions[0]
+		if a.Title != "Create follow-up" || a.ActionType != "task_create" || a.ProposedBy != "planner" {
+			t.Errorf("action = %q/%q/%q, want payload title, type, and proposer", a.Title, a.ActionType, a.ProposedBy)
+		}
+		if a.Fields["title"] != "Follow up" || a.Fields["priority"] != "p1" {
+			t.Errorf("fields = %v, want payload fields with --field override", a.Fields)
+		}
+		if !strings.Contains(a.Content, "Mentioned in standup") {
+			t.Errorf("content = %q, want payload body", a.Content)
+		}
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.WriteString(`{"title": "Log call", "action_type": "people_log", "fields": {"target_id": "3", "note": "Called"}}`); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+		stdin := os.Stdin
+		os.Stdin = r
+		t.Cleanup(func() { os.Stdin = stdin })
+
+		globalFlags.JSON = true
+		t.Cleanup(func() { globalFlags.JSON = false })
+
+		var runErr error
+		out := captureStdout(t, func() {
+			runErr = actionNewCommand(cfg).Execute([]string{"--from-json", "-"})
+		})
+		if runErr != nil {
+			t.Fatalf("new --from-json - error = %v", runErr)
+		}
+
+		var created struct {
+			Title      string            `json:"title"`
+			ActionType string            `json:"action_type"`
+			ProposedBy string            `json:"proposed_by"`
+			Fields     map[string]string `json:"fields"`
+		}
+		if err := json.Unmarshal([]byte(out), &created); err != nil {
+			t.Fatalf("output is not JSON: %v\n%s", err, out)
+		}
+		if created.Title != "Log call" || created.ActionType != "people_log" || created.Fields["note"] != "Called" {
+			t.Errorf("created = %+v, want the stdin payload", created)
+		}
+		if created.ProposedBy != "cli" {
+			t.Errorf("proposed_by = %q, want default cli", created.ProposedBy)
+		}
+	})
+
+	t.Run("missing keys", func(t *testing.T) {
+		for _, body := range []string{`{"action_type": "task_create"}`, `{"title": "No type"}`, `{"title": "x", "action_type": "task_create", "feilds": {}}`} {
+			path := filepath.Join(t.TempDir(), "action.json")
+			if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := actionNewCommand(cfg).Execute([]string{"--from-json", path}); err == nil {
+				t.Errorf("new --from-json with %s succeeded, want error", body)
+			}
+		}
+	})
+}
+
+// writeFakePlugin installs an executable plugin script for actionType
+// under a temporary HOME.
+func writeFakePlugin(t *testing.T, actionType, script string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", "acore", "plugins")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, actionType), []byte("#!/bin/sh\ncat >/dev/null\n"+script+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadFieldsFileRejectsNonObjects(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range []struct {
+		name, content string
+		wantErr       bool
+	}{
+		{"object", `{"title": "Follow up"}`, false},
+		{"empty object", `{}`, false},
+		{"null", `null`, true},
+		{"array", `["title"]`, true},
+		{"string", `"title"`, true},
+	} {
+		path := filepath.Join(dir, strings.ReplaceAll(tc.name, " ", "-")+".json")
+		if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fields, err := readFieldsFile(path)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("readFieldsFile(%s) error = %v, want error %v", tc.name, err, tc.wantErr)
+		}
+		if err == nil && fields == nil {
+			t.Errorf("readFieldsFile(%s) returned a nil map", tc.name)
+		}
+	}
+
+	// A null fields file alongside --field fails cleanly
+	path := filepath.Join(dir, "null.json")
+	cfg := &config.Config{NotesDirectory: dir}
+	args := []string{"Follow up", "--action-type", "task_create", "--fields-file", path, "--field", "priority=p1"}
+	captureStdout(t, func() {
+		if err := actionNewCommand(cfg).Execute(args); err == nil {
+			t.Error("action new with a null fields file succeeded, want error")
+		}
+	})
+}
+
+func TestApproveRecordsPluginResult(t *testing.T) {
+	tests := []struct {
+		name        string
+		script      string
+		wantErr     bool
+		wantCreated string
+		wantMessage string
+	}{
+		{
+			name:        "structured",
+			script:      `echo '{"status": "ok", "message": "Moved to Friday", "created_id": "evt-123"}'`,
+			wantCreated: "evt-123",
+			wantMessage: "Moved to Friday",
+		},
+		{
+			name:   "plain output",
+			script: `echo 'rescheduled'`,
+		},
+		{
+			name:    "reported failure",
+			script:  `echo '{"status": "failed", "message": "calendar unavailable"}'`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeFakePlugin(t, "calendar_reschedule", tt.script)
+			dir := t.TempDir()
+			cfg := &config.Config{NotesDirectory: dir}
+
+			action, err := task.CreateAction(dir, "Move review", "calendar_reschedule", "agent", "",
+				map[string]string{"event": "review"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			globalFlags.JSON = true
+			t.Cleanup(func() { globalFlags.JSON = false })
+
+			var runErr error
+			out := captureStdout(t, func() {
+				runErr = actionApproveCommand(cfg).Execute([]string{strconv.Itoa(action.IndexID)})
+			})
+
+			if tt.wantErr {
+				if runErr == nil {
+					t.Fatal("approve succeeded, want plugin-reported failure")
+				}
+				if pending, err := lookupAction(dir, strconv.Itoa(action.IndexID)); err != nil || pending.Status != denote.ActionPending {
+					t.Errorf("failed action not left pending: %v", err)
+				}
+				return
+			}
+			if runErr != nil {
+				t.Fatalf("approve error = %v", runErr)
+			}
+
+			var result map[string]string
+			if err := json.Unmarshal([]byte(out), &result); err != nil {
+				t.Fatalf("approve output is not JSON: %v\n%s", err, out)
+			}
+			if result["status"] != "executed" || result["message"] != tt.wantMessage || result["created_id"] != tt.wantCreated {
+				t.Errorf("approve output = %v, want message %q and created_id %q", result, tt.wantMessage, tt.wantCreated)
+			}
+
+			archived, err := task.FindArchivedAction(dir, strconv.Itoa(action.IndexID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := archived.Fields["created_id"]; got != tt.wantCreated {
+				t.Errorf("archived created_id = %q, want %q", got, tt.wantCreated)
+			}
+			if archived.Fields["event"] != "review" {
+				t.Errorf("archived fields = %v, want original fields kept", archived.Fields)
+			}
+		})
+	}
+}
+
+func TestApproveKillsPluginAfterTimeout(t *testing.T) {
+	writeFakePlugin(t, "slow_sync", "sleep 10")
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	action, err := task.CreateAction(dir, "Sync calendar", "slow_sync", "agent", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	var runErr error
+	captureStdout(t, func() {
+		runErr = actionApproveCommand(cfg).Execute([]string{strconv.Itoa(action.IndexID), "--timeout", "200ms"})
+	})
+	if runErr == nil || !strings.Contains(runErr.Error(), "timed out after 200ms") {
+		t.Fatalf("approve error = %v, want timeout error", runErr)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("approve took %s, want the plugin killed at the timeout", elapsed)
+	}
+	if pending, err := lookupAction(dir, strconv.Itoa(action.IndexID)); err != nil || pending.Status != denote.ActionPending {
+		t.Errorf("timed-out action not left pending: %v", err)
+	}
+}
+
+func TestPluginReceivesCuratedEnvironment(t *testing.T) {
+	writeFakePlugin(t, "env_probe", `printf '{"status": "ok", "message": "%s|%s", "created_id": "%s"}' "$ATASK_ACTION_TYPE" "$PLUGIN_SECRET" "$ATASK_NOTES_DIR"`)
+	t.Setenv("PLUGIN_SECRET", "leaked")
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	action, err := task.CreateAction(dir, "Probe env", "env_probe", "agent", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = actionApproveCommand(cfg).Execute([]string{strconv.Itoa(action.IndexID)})
+	})
+	if runErr != nil {
+		t.Fatalf("approve error = %v", runErr)
+	}
+
+	var result map[string]string
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("approve output is not JSON: %v\n%s", err, out)
+	}
+	if result["created_id"] != dir {
+		t.Errorf("plugin saw ATASK_NOTES_DIR = %q, want %q", result["created_id"], dir)
+	}
+	if result["message"] != "env_probe|" {
+		t.Errorf("plugin saw ATASK_ACTION_TYPE|PLUGIN_SECRET = %q, want env_probe and no pass-through", result["message"])
+	}
+}
+
+func TestIsPurgeableBoundaryAndStatus(t *testing.T) {
+	now := time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+	action := func(status string, age time.Duration) *denote.Action {
+		a := &denote.Action{}
+		a.Status = status
+		a.ProposedAt = now.Add(-age).Format(time.RFC3339)
+		return a
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name   string
+		action *denote.Action
+		status string
+		want   bool
+	}{
+		{"older than threshold", action(denote.ActionExecuted, 90*day+time.Second), "", true},
+		{"exactly at threshold", action(denote.ActionExecuted, 90*day), "", false},
+		{"newer than threshold", action(denote.ActionRejected, 89*day), "", false},
+		{"status filter matches", action(denote.ActionRejected, 120*day), denote.ActionRejected, true},
+		{"status filter excludes", action(denote.ActionExecuted, 120*day), denote.ActionRejected, false},
+		{"unparseable proposed_at", &denote.Action{ActionMetadata: denote.ActionMetadata{Status: denote.ActionRejected, ProposedAt: "last spring"}}, "", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPurgeable(tt.action, 90, tt.status, now); got != tt.want {
+			t.Errorf("%s: isPurgeable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestActionPurgeDeletesOnlyMatchingArchivedActions(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	archive := func(title, status string, age time.Duration) *denote.Action {
+		t.Helper()
+		a, err := task.CreateAction(dir, title, denote.ActionTypeTaskCreate, "agent", "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.Status = status
+		a.ProposedAt = time.Now().Add(-age).Format(time.RFC3339)
+		if err := acore.UpdateFrontmatter(acore.NewLocalStore(filepath.Dir(a.FilePath)), filepath.Base(a.FilePath), a); err != nil {
+			t.Fatal(err)
+		}
+		if err := task.ArchiveAction(dir, a); err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	oldRejected := archive("Old rejected", denote.ActionRejected, 100*24*time.Hour)
+	oldExecuted := archive("Old executed", denote.ActionExecuted, 100*24*time.Hour)
+	newRejected := archive("New rejected", denote.ActionRejected, 10*24*time.Hour)
+
+	archivedIDs := func() map[int]bool {
+		t.Helper()
+		actions, err := denote.NewScanner(dir).FindArchivedActions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make(map[int]bool)
+		for _, a := range actions {
+			ids[a.IndexID] = true
+		}
+		return ids
+	}
+
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+
+	type purgeResult struct {
+		DryRun  bool `json:"dry_run"`
+		Count   int  `json:"count"`
+		Actions []struct {
+			IndexID int `json:"index_id"`
+		} `json:"actions"`
+	}
+	run := func(args ...string) purgeResult {
+		t.Helper()
+		var runErr error
+		out := captureStdout(t, func() {
+			runErr = actionPurgeCommand(cfg).Execute(args)
+		})
+		if runErr != nil {
+			t.Fatalf("purge %v error = %v", args, runErr)
+		}
+		var result purgeResult
+		if err := json.Unmarshal([]byte(out), &result); err != nil {
+			t.Fatalf("purge output is not JSON: %v\n%s", err, out)
+		}
+		return result
+	}
+
+	// Dry run reports the old rejected action and deletes nothing
+	result := run("--status", "rejected", "--dry-run")
+	if !result.DryRun || result.Count != 1 || result.Actions[0].IndexID != oldRejected.IndexID {
+		t.Errorf("dry run = %+v, want only #%d", result, oldRejected.IndexID)
+	}
+	if len(archivedIDs()) != 3 {
+		t.Error("dry run deleted archived actions")
+	}
+
+	result = run("--status", "rejected")
+	if result.Count != 1 {
+		t.Errorf("purge --status rejected count = %d, want 1", result.Count)
+	}
+	ids := archivedIDs()
+	if ids[oldRejected.IndexID] || !ids[oldExecuted.IndexID] || !ids[newRejected.IndexID] {
+		t.Errorf("archived after purge = %v, want only #%d removed", ids, oldRejected.IndexID)
+	}
+
+	if err := actionPurgeCommand(cfg).Execute([]string{"--status", "pending"}); err == nil {
+		t.Error("purge --status pending succeeded, want error")
+	}
+}
+
+func TestTaskFieldChange(t *testing.T) {
+	tk := &denote.Task{
+		TaskMetadata: denote.TaskMetadata{
+			Status:    denote.TaskStatusOpen,
+			Priority:  denote.PriorityP2,
+			DueDate:   "2026-03-01",
+			Area:      "home",
+			ProjectID: "01KJ1KHY4NFGESK9DDS4YEGH2P",
+		},
+	}
+	tk.Title = "Fix bike"
+	tk.RelatedPeople = []string{"01KJ1KHY4NFGESK9DDS4YEGH2J"}
+
+	for _, tc := range []struct {
+		name, field, proposed string
+		before, after         string
+	}{
+		{"unchanged title", "title", "Fix bike", "Fix bike", "Fix bike"},
+		{"unchanged due", "due", "2026-03-01", "2026-03-01", "2026-03-01"},
+		{"unchanged person", "add_person", "01KJ1KHY4NFGESK9DDS4YEGH2J",
+			"01KJ1KHY4NFGESK9DDS4YEGH2J", "01KJ1KHY4NFGESK9DDS4YEGH2J"},
+		{"changed priority", "priority", "p1", "p2", "p1"},
+		{"changed status", "status", "done", "open", "done"},
+		{"added person", "add_person", "01KJ1KHY4NFGESK9DDS4YEGH2K, 01KJ1KHY4NFGESK9DDS4YEGH2J",
+			"01KJ1KHY4NFGESK9DDS4YEGH2J", "01KJ1KHY4NFGESK9DDS4YEGH2J, 01KJ1KHY4NFGESK9DDS4YEGH2K"},
+		{"cleared area", "area", "", "home", ""},
+		{"cleared project", "project", "", "01KJ1KHY4NFGESK9DDS4YEGH2P", ""},
+		{"unknown field", "notes", "call first", "", "call first"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			before, after := taskFieldChange(tk, tc.field, tc.proposed)
+			if before != tc.before || after != tc.after {
+				t.Errorf("taskFieldChange(%q, %q) = (%q, %q), want (%q, %q)",
+					tc.field, tc.proposed, before, after, tc.before, tc.after)
+			}
+		})
+	}
+}
+
+func TestDiffAction(t *testing.T) {
+	dir := t.TempDir()
+	target := newTestTask(t, dir, "Fix bike", func(tk *denote.Task) {
+		tk.TaskMetadata.Priority = denote.PriorityP2
+		tk.TaskMetadata.Area = "home"
+	})
+	id := strconv.Itoa(target.IndexID)
+
+	update := func(fields map[string]string) *denote.Action {
+		return &denote.Action{
+			ActionMetadata: denote.ActionMetadata{ActionType: denote.ActionTypeTaskUpdate, Fields: fields},
+		}
+	}
+
+	d := diffAction(dir, update(map[string]string{
+		"target_id": id,
+		"title":     "Fix bike",
+		"priority":  "p1",
+		"area":      "",
+	}))
+	if !d.Resolved || !strings.Contains(d.Target, "#"+id) {
+		t.Errorf("diffAction target = %q (resolved %v), want task #%s resolved", d.Target, d.Resolved, id)
+	}
+	want := []fieldChange{
+		{Field: "area", Before: "home", After: "", Changed: true},
+		{Field: "priority", Before: "p2", After: "p1", Changed: true},
+		{Field: "title", Before: "Fix bike", After: "Fix bike", Changed: false},
+	}
+	if !reflect.DeepEqual(d.Changes, want) {
+		t.Errorf("diffAction changes = %+v, want %+v", d.Changes, want)
+	}
+
+	// Targets that can't be resolved list the proposed values only
+	for name, action := range map[string]*denote.Action{
+		"missing task": update(map[string]string{"target_id": "999", "priority": "p1"}),
+		"other type": {
+			ActionMetadata: denote.ActionMetadata{
+				ActionType: denote.ActionTypeProjectUpdate,
+				Fields:     map[string]string{"target_id": id, "priority": "p1"},
+			},
+		},
+	} {
+		d := diffAction(dir, action)
+		want := []fieldChange{{Field: "priority", After: "p1"}}
+		if d.Resolved || !reflect.DeepEqual(d.Changes, want) {
+			t.Errorf("%s: diffAction = %+v, want unresolved with %+v", name, d, want)
+		}
+	}
+}
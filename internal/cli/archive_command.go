@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"github.com/mph-llm-experiments/atask/internal/task"
+)
+
+// taskArchiveCommand moves long-finished tasks into archive/ so they stop
+// slowing down scans and cluttering listings
+func taskArchiveCommand(cfg *config.Config) *Command {
+	var (
+		olderThan string
+		dryRun    bool
+	)
+
+	cmd := &Command{
+		Name:        "archive",
+		Usage:       "atask task archive [--older-than 30d] [--dry-run]",
+		Description: "Move done and dropped tasks into archive/",
+		Flags:       flag.NewFlagSet("task-archive", flag.ExitOnError),
+	}
+
+	cmd.Flags.StringVar(&olderThan, "older-than", "30d", "Archive tasks finished more than this many days ago (Nd)")
+	cmd.Flags.BoolVar(&dryRun, "dry-run", false, "Show which tasks would be archived without moving them")
+
+	cmd.Run = func(c *Command, args []string) error {
+		days, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(olderThan), "d"))
+		if err != nil || days < 0 {
+			return fmt.Errorf("invalid --older-than: %s (expected Nd, e.g. 30d)", olderThan)
+		}
+
+		scanner := denote.NewScanner(cfg.NotesDirectory)
+		tasks, err := scanner.FindTasks()
+		if err != nil {
+			return fmt.Errorf("failed to scan directory: %v", err)
+		}
+		warnParseErrors(scanner)
+
+		now := time.Now()
+		var archived []*denote.Task
+		failed := 0
+		for _, t := range tasks {
+			if !isArchivable(t, days, now) {
+				continue
+			}
+			if dryRun {
+				archived = append(archived, t)
+				if !globalFlags.JSON {
+					fmt.Printf("Would archive task ID %d: %s\n", t.IndexID, t.Title)
+				}
+				continue
+			}
+			if err := task.ArchiveTask(cfg.NotesDirectory, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to archive task %d: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+			archived = append(archived, t)
+			if !globalFlags.JSON && !globalFlags.Quiet {
+				fmt.Printf("Archived task ID %d: %s\n", t.IndexID, t.Title)
+			}
+		}
+
+		if globalFlags.JSON {
+			if archived == nil {
+				archived = []*denote.Task{}
+			}
+			data, err := json.MarshalIndent(archived, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else if len(archived) == 0 && !globalFlags.Quiet {
+			fmt.Println("No tasks to archive")
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d task(s) could not be archived", failed)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// isArchivable reports whether t is done or dropped and was finished more
+// than days calendar days before now. The finish date is completed_date,
+// falling back to the modified timestamp for tasks finished before
+// completed_date was recorded.
+func isArchivable(t *denote.Task, days int, now time.Time) bool {
+	if t.TaskMetadata.Status != denote.TaskStatusDone && t.TaskMetadata.Status != denote.TaskStatusDropped {
+		return false
+	}
+
+	finished := t.TaskMetadata.CompletedDate
+	if finished == "" {
+		modified, err := time.Parse(time.RFC3339, t.Modified)
+		if err != nil {
+			return false
+		}
+		finished = modified.In(now.Location()).Format("2006-01-02")
+	}
+	finishedDay, err := time.ParseInLocation("2006-01-02", finished, now.Location())
+	if err != nil {
+		return false
+	}
+
+	cutoff := time.Date(now.Year(), now.Month(), now.Day()-days, 0, 0, 0, 0, now.Location())
+	return finishedDay.Before(cutoff)
+}
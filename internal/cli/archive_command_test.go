@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+func TestIsArchivableThreshold(t *testing.T) {
+	now := time.Date(2026, 3, 31, 15, 0, 0, 0, time.Local)
+	daysAgo := func(n int) string {
+		return now.AddDate(0, 0, -n).Format("2006-01-02")
+	}
+
+	tests := []struct {
+		name      string
+		status    string
+		completed string
+		want      bool
+	}{
+		{"done 31 days ago", denote.TaskStatusDone, daysAgo(31), true},
+		{"done exactly 30 days ago", denote.TaskStatusDone, daysAgo(30), false},
+		{"done 29 days ago", denote.TaskStatusDone, daysAgo(29), false},
+		{"dropped 31 days ago", denote.TaskStatusDropped, daysAgo(31), true},
+		{"open with old completed_date", denote.TaskStatusOpen, daysAgo(90), false},
+	}
+
+	for _, tt := range tests {
+		tk := &denote.Task{}
+		tk.TaskMetadata.Status = tt.status
+		tk.TaskMetadata.CompletedDate = tt.completed
+		if got := isArchivable(tk, 30, now); got != tt.want {
+			t.Errorf("%s: isArchivable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Without completed_date the modified timestamp stands in
+	legacy := &denote.Task{}
+	legacy.TaskMetadata.Status = denote.TaskStatusDone
+	legacy.Modified = now.AddDate(0, 0, -45).Format(time.RFC3339)
+	if !isArchivable(legacy, 30, now) {
+		t.Error("done task modified 45 days ago without completed_date is not archivable")
+	}
+}
+
+func TestTaskArchiveMovesFiles(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	old := newTestTask(t, dir, "Old chore", func(tk *denote.Task) {
+		tk.TaskMetadata.Status = denote.TaskStatusDone
+		tk.TaskMetadata.CompletedDate = time.Now().AddDate(0, 0, -40).Format("2006-01-02")
+	})
+	newTestTask(t, dir, "Still open", nil)
+
+	// A dry run leaves everything in place
+	captureStdout(t, func() {
+		if err := taskArchiveCommand(cfg).Execute([]string{"--dry-run"}); err != nil {
+			t.Errorf("archive --dry-run error = %v", err)
+		}
+	})
+	if _, err := os.Stat(old.FilePath); err != nil {
+		t.Fatalf("dry run moved the task: %v", err)
+	}
+
+	captureStdout(t, func() {
+		if err := taskArchiveCommand(cfg).Execute(nil); err != nil {
+			t.Errorf("archive error = %v", err)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(dir, "archive", filepath.Base(old.FilePath))); err != nil {
+		t.Fatalf("archived file missing: %v", err)
+	}
+
+	scanner := denote.NewScanner(dir)
+	tasks, err := scanner.FindTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Still open" {
+		t.Errorf("FindTasks after archive returned %d tasks, want only the open one", len(tasks))
+	}
+	archived, err := scanner.FindArchivedTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 1 || archived[0].Title != "Old chore" {
+		t.Errorf("FindArchivedTasks returned %d tasks, want the archived one", len(archived))
+	}
+}
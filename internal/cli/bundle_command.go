@@ -0,0 +1,474 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"github.com/mph-llm-experiments/atask/internal/task"
+)
+
+// bundleSchemaVersion is the schema_version written into export bundles.
+// Import refuses bundles with a higher version.
+const bundleSchemaVersion = 1
+
+// bundle is a single-file backup of a vault: every task and project plus
+// the pending actions, each with its body. Tasks moved to archive/ have
+// their own section so they are restored there.
+type bundle struct {
+	SchemaVersion int             `json:"schema_version"`
+	ExportedAt    string          `json:"exported_at"`
+	Tasks         []bundleTask    `json:"tasks"`
+	ArchivedTasks []bundleTask    `json:"archived_tasks,omitempty"`
+	Projects      []bundleProject `json:"projects"`
+	Actions       []bundleAction  `json:"actions"`
+}
+
+type bundleTask struct {
+	*denote.Task
+	Content string `json:"content,omitempty"`
+}
+
+type bundleProject struct {
+	*denote.Project
+	Content string `json:"content,omitempty"`
+}
+
+type bundleAction struct {
+	*denote.Action
+	Content string `json:"content,omitempty"`
+}
+
+// ExportCommand creates the export command
+func ExportCommand(cfg *config.Config) *Command {
+	cmd := &Command{
+		Name:        "export",
+		Usage:       "atask export <format> <file>",
+		Description: "Export the vault",
+	}
+
+	cmd.Subcommands = []*Command{
+		exportBundleCommand(cfg),
+	}
+
+	return cmd
+}
+
+// ImportCommand creates the import command
+func ImportCommand(cfg *config.Config) *Command {
+	cmd := &Command{
+		Name:        "import",
+		Usage:       "atask import <format> <file>",
+		Description: "Import into the vault",
+	}
+
+	cmd.Subcommands = []*Command{
+		importBundleCommand(cfg),
+	}
+
+	return cmd
+}
+
+// exportBundleCommand writes all tasks, projects, and pending actions to
+// one JSON file
+func exportBundleCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "bundle",
+		Usage:       "atask export bundle <file.json>",
+		Description: "Write all tasks, projects, and pending actions to one JSON file",
+		Run: func(c *Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("usage: %s", c.Usage)
+			}
+
+			scanner := denote.NewScanner(cfg.NotesDirectory)
+			tasks, err := scanner.FindTasks()
+			if err != nil {
+				return fmt.Errorf("failed to scan tasks: %v", err)
+			}
+			archivedTasks, err := scanner.FindArchivedTasks()
+			if err != nil {
+				return fmt.Errorf("failed to scan archived tasks: %v", err)
+			}
+			projects, err := scanner.FindProjects()
+			if err != nil {
+				return fmt.Errorf("failed to scan projects: %v", err)
+			}
+			actions, err := scanner.FindActions()
+			if err != nil {
+				return fmt.Errorf("failed to scan actions: %v", err)
+			}
+			warnParseErrors(scanner)
+
+			b := bundle{
+				SchemaVersion: bundleSchemaVersion,
+				ExportedAt:    time.Now().Format(time.RFC3339),
+				Tasks:         []bundleTask{},
+				Projects:      []bundleProject{},
+				Actions:       []bundleAction{},
+			}
+			// Paths are machine-specific, so they stay out of the bundle
+			for _, t := range tasks {
+				t.FilePath = ""
+				b.Tasks = append(b.Tasks, bundleTask{Task: t, Content: t.Content})
+			}
+			for _, t := range archivedTasks {
+				t.FilePath = ""
+				b.ArchivedTasks = append(b.ArchivedTasks, bundleTask{Task: t, Content: t.Content})
+			}
+			for _, p := range projects {
+				p.FilePath = ""
+				b.Projects = append(b.Projects, bundleProject{Project: p, Content: p.Content})
+			}
+			for _, a := range actions {
+				if a.Status != denote.ActionPending {
+					continue
+				}
+				a.FilePath = ""
+				b.Actions = append(b.Actions, bundleAction{Action: a, Content: a.Content})
+			}
+			sort.Slice(b.Tasks, func(i, j int) bool { return b.Tasks[i].IndexID < b.Tasks[j].IndexID })
+			sort.Slice(b.ArchivedTasks, func(i, j int) bool { return b.ArchivedTasks[i].IndexID < b.ArchivedTasks[j].IndexID })
+			sort.Slice(b.Projects, func(i, j int) bool { return b.Projects[i].IndexID < b.Projects[j].IndexID })
+			sort.Slice(b.Actions, func(i, j int) bool { return b.Actions[i].IndexID < b.Actions[j].IndexID })
+
+			data, err := json.MarshalIndent(b, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal bundle: %w", err)
+			}
+			if err := os.WriteFile(args[0], append(data, '\n'), 0644); err != nil {
+				return fmt.Errorf("failed to write bundle: %w", err)
+			}
+
+			if globalFlags.JSON {
+				out, _ := json.MarshalIndent(map[string]interface{}{
+					"file":           args[0],
+					"tasks":          len(b.Tasks),
+					"archived_tasks": len(b.ArchivedTasks),
+					"projects":       len(b.Projects),
+					"actions":        len(b.Actions),
+				}, "", "  ")
+				fmt.Println(string(out))
+				return nil
+			}
+			if !globalFlags.Quiet {
+				fmt.Printf("Exported %d tasks (%d archived), %d projects, %d actions to %s\n",
+					len(b.Tasks)+len(b.ArchivedTasks), len(b.ArchivedTasks), len(b.Projects), len(b.Actions), args[0])
+			}
+			return nil
+		},
+	}
+}
+
+// importBundleCommand recreates the files of an export bundle
+func importBundleCommand(cfg *config.Config) *Command {
+	fs := flag.NewFlagSet("import-bundle", flag.ContinueOnError)
+	preserveIDs := fs.Bool("preserve-ids", false, "Keep the bundle's ULIDs and index_ids instead of assigning fresh ones")
+	dryRun := fs.Bool("dry-run", false, "Report what would be imported without writing files")
+
+	return &Command{
+		Name:        "bundle",
+		Usage:       "atask import bundle <file.json> [--preserve-ids] [--dry-run]",
+		Description: "Recreate tasks, projects, and actions from an export bundle",
+		Flags:       fs,
+		Run: func(c *Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("usage: %s", c.Usage)
+			}
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read bundle: %w", err)
+			}
+			var b bundle
+			if err := json.Unmarshal(data, &b); err != nil {
+				return fmt.Errorf("invalid bundle: %w", err)
+			}
+			if b.SchemaVersion > bundleSchemaVersion {
+				return fmt.Errorf("bundle schema_version %d is newer than this atask supports (%d)", b.SchemaVersion, bundleSchemaVersion)
+			}
+
+			imp, err := newBundleImporter(cfg.NotesDirectory, *preserveIDs, *dryRun)
+			if err != nil {
+				return err
+			}
+			imp.run(&b)
+
+			if *preserveIDs && !*dryRun && imp.total() > 0 {
+				if err := task.ReseedCounters(cfg.NotesDirectory); err != nil {
+					return err
+				}
+			}
+
+			if globalFlags.JSON {
+				out, _ := json.MarshalIndent(map[string]interface{}{
+					"dry_run":        *dryRun,
+					"tasks":          imp.tasks,
+					"archived_tasks": imp.archived,
+					"projects":       imp.projects,
+					"actions":        imp.actions,
+					"skipped":        imp.skipped,
+					"failed":         imp.failed,
+				}, "", "  ")
+				fmt.Println(string(out))
+			} else if !globalFlags.Quiet {
+				verb := "Imported"
+				if *dryRun {
+					verb = "Would import"
+				}
+				fmt.Printf("%s %d tasks (%d archived), %d projects, %d actions\n",
+					verb, imp.tasks+imp.archived, imp.archived, imp.projects, imp.actions)
+				if imp.skipped > 0 {
+					fmt.Printf("%d already present, skipped\n", imp.skipped)
+				}
+				if *dryRun {
+					fmt.Println("Dry run: no files were written")
+				}
+			}
+
+			if imp.failed > 0 {
+				return fmt.Errorf("%d bundle entries could not be imported", imp.failed)
+			}
+			return nil
+		},
+	}
+}
+
+// bundleImporter writes bundle entries into a notes directory. With
+// preserveIDs, entries keep their ULID and index_id: ones whose ULID is
+// already on disk are skipped, and ones whose index_id is taken fail. Without
+// it every entry gets fresh IDs and project_id, related_tasks, and blocked_by
+// links inside the bundle are rewritten to match.
+type bundleImporter struct {
+	dir         string
+	preserveIDs bool
+	dryRun      bool
+
+	existingIDs    map[string]bool
+	usedIndexIDs   map[int]bool // tasks and projects share a counter
+	usedActionIDs  map[int]bool
+	projectIndexes map[string]string // old project index_id -> new
+	entityIDs      map[string]string // old ULID -> new
+
+	tasks, archived, projects, actions, skipped, failed int
+}
+
+func newBundleImporter(dir string, preserveIDs, dryRun bool) (*bundleImporter, error) {
+	imp := &bundleImporter{
+		dir:            dir,
+		preserveIDs:    preserveIDs,
+		dryRun:         dryRun,
+		existingIDs:    make(map[string]bool),
+		usedIndexIDs:   make(map[int]bool),
+		usedActionIDs:  make(map[int]bool),
+		projectIndexes: make(map[string]string),
+		entityIDs:      make(map[string]string),
+	}
+
+	scanner := denote.NewScanner(dir)
+	tasks, err := scanner.FindTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan tasks: %v", err)
+	}
+	projects, err := scanner.FindProjects()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan projects: %v", err)
+	}
+	archivedTasks, err := scanner.FindArchivedTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan archived tasks: %v", err)
+	}
+	actions, err := scanner.FindActions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan actions: %v", err)
+	}
+	archived, _ := scanner.FindArchivedActions()
+
+	// Archived tasks keep their index_ids, so they count as taken
+	for _, t := range append(tasks, archivedTasks...) {
+		imp.existingIDs[t.ID] = true
+		imp.usedIndexIDs[t.IndexID] = true
+	}
+	for _, p := range projects {
+		imp.existingIDs[p.ID] = true
+		imp.usedIndexIDs[p.IndexID] = true
+	}
+	for _, a := range append(actions, archived...) {
+		imp.existingIDs[a.ID] = true
+		imp.usedActionIDs[a.IndexID] = true
+	}
+	return imp, nil
+}
+
+func (imp *bundleImporter) total() int {
+	return imp.tasks + imp.archived + imp.projects + imp.actions
+}
+
+// run imports projects before tasks so task links can be remapped, and
+// reserves every fresh ID before writing anything that refers to one.
+func (imp *bundleImporter) run(b *bundle) {
+	var projects []bundleProject
+	for _, p := range b.Projects {
+		if imp.admit("project", p.ID, p.IndexID, imp.usedIndexIDs) {
+			projects = append(projects, p)
+		}
+	}
+	var tasks []bundleTask
+	for _, t := range b.Tasks {
+		if imp.admit("task", t.ID, t.IndexID, imp.usedIndexIDs) {
+			tasks = append(tasks, t)
+		}
+	}
+	var archivedTasks []bundleTask
+	for _, t := range b.ArchivedTasks {
+		if imp.admit("task", t.ID, t.IndexID, imp.usedIndexIDs) {
+			archivedTasks = append(archivedTasks, t)
+		}
+	}
+	var actions []bundleAction
+	for _, a := range b.Actions {
+		if imp.admit("action", a.ID, a.IndexID, imp.usedActionIDs) {
+			actions = append(actions, a)
+		}
+	}
+
+	if imp.dryRun {
+		imp.projects, imp.tasks, imp.archived, imp.actions = len(projects), len(tasks), len(archivedTasks), len(actions)
+		return
+	}
+
+	if !imp.preserveIDs {
+		for _, p := range projects {
+			oldIndex := strconv.Itoa(p.IndexID)
+			if !imp.assignFresh(&p.Entity, task.NextIndexID) {
+				continue
+			}
+			imp.projectIndexes[oldIndex] = strconv.Itoa(p.IndexID)
+		}
+		for _, t := range append(tasks, archivedTasks...) {
+			imp.assignFresh(&t.Entity, task.NextIndexID)
+		}
+		for _, a := range actions {
+			imp.assignFresh(&a.Entity, task.NextActionIndexID)
+		}
+	}
+
+	for _, p := range projects {
+		if p.ID == "" {
+			continue
+		}
+		imp.write("project", p.IndexID, task.RestoreProject(imp.dir, p.Project, p.Content), &imp.projects)
+	}
+	for _, t := range tasks {
+		if t.ID == "" {
+			continue
+		}
+		imp.remapTask(t.Task)
+		imp.write("task", t.IndexID, task.RestoreTask(imp.dir, t.Task, t.Content), &imp.tasks)
+	}
+	if len(archivedTasks) > 0 {
+		archiveDir := filepath.Join(imp.dir, "archive")
+		if err := os.MkdirAll(archiveDir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot import archived tasks: %v\n", err)
+			imp.failed += len(archivedTasks)
+			archivedTasks = nil
+		}
+		for _, t := range archivedTasks {
+			if t.ID == "" {
+				continue
+			}
+			imp.remapTask(t.Task)
+			imp.write("task", t.IndexID, task.RestoreTask(archiveDir, t.Task, t.Content), &imp.archived)
+		}
+	}
+	for _, a := range actions {
+		if a.ID == "" {
+			continue
+		}
+		imp.write("action", a.IndexID, task.RestoreAction(imp.dir, a.Action, a.Content), &imp.actions)
+	}
+}
+
+// remapTask points a freshly numbered task's project_id, related_tasks and
+// blocked_by at the new IDs of the entities imported with it. A project_id
+// naming a project outside the bundle is cleared, since its old number
+// could belong to an unrelated project here.
+func (imp *bundleImporter) remapTask(t *denote.Task) {
+	if imp.preserveIDs {
+		return
+	}
+	if pid := t.TaskMetadata.ProjectID; pid != "" {
+		if newIndex, ok := imp.projectIndexes[pid]; ok {
+			t.TaskMetadata.ProjectID = newIndex
+		} else if newID, ok := imp.entityIDs[pid]; ok {
+			t.TaskMetadata.ProjectID = newID
+		} else {
+			fmt.Fprintf(os.Stderr, "Task %q: project %s is not in the bundle, clearing project_id\n", t.Title, pid)
+			t.TaskMetadata.ProjectID = ""
+		}
+	}
+	for i, id := range t.RelatedTasks {
+		if newID, ok := imp.entityIDs[id]; ok {
+			t.RelatedTasks[i] = newID
+		}
+	}
+	for i, id := range t.TaskMetadata.BlockedBy {
+		if newID, ok := imp.entityIDs[id]; ok {
+			t.TaskMetadata.BlockedBy[i] = newID
+		}
+	}
+}
+
+// admit reports whether a bundle entry should be imported, counting the
+// ones skipped or rejected under --preserve-ids.
+func (imp *bundleImporter) admit(kind, id string, indexID int, used map[int]bool) bool {
+	if !imp.preserveIDs {
+		return true
+	}
+	if imp.existingIDs[id] {
+		imp.skipped++
+		return false
+	}
+	if used[indexID] {
+		fmt.Fprintf(os.Stderr, "Cannot import %s #%d (%s): index_id already in use\n", kind, indexID, id)
+		imp.failed++
+		return false
+	}
+	imp.existingIDs[id] = true
+	used[indexID] = true
+	return true
+}
+
+// assignFresh gives an entity a new ULID and index_id, recording the ULID
+// mapping. On failure the entity's ID is cleared so it is not written.
+func (imp *bundleImporter) assignFresh(e *acore.Entity, next func(string) (int, error)) bool {
+	indexID, err := next(imp.dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot import %s: %v\n", e.Title, err)
+		imp.failed++
+		e.ID = ""
+		return false
+	}
+	newID := acore.NewID()
+	imp.entityIDs[e.ID] = newID
+	e.ID = newID
+	e.IndexID = indexID
+	return true
+}
+
+func (imp *bundleImporter) write(kind string, indexID int, err error, count *int) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot import %s #%d: %v\n", kind, indexID, err)
+		imp.failed++
+		return
+	}
+	*count++
+}
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"github.com/mph-llm-experiments/atask/internal/task"
+)
+
+// bundleFixture is a vault with a project, a task in it, a task blocked by
+// and related to that one, a task whose project is not in the vault, and an
+// archived task.
+type bundleFixture struct {
+	project                     *denote.Project
+	linked, waiting, stray, old *denote.Task
+}
+
+func newBundleFixture(t *testing.T, dir string) bundleFixture {
+	t.Helper()
+	var f bundleFixture
+	var err error
+
+	if f.project, err = task.CreateProject(dir, "Garden", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if f.linked, err = task.CreateTask(dir, "Buy seeds", "Tomatoes and basil", nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	f.linked.TaskMetadata.ProjectID = strconv.Itoa(f.project.IndexID)
+	if err := task.UpdateTaskFile(f.linked.FilePath, f.linked); err != nil {
+		t.Fatal(err)
+	}
+	f.waiting = newTestTask(t, dir, "Plant seeds", func(tk *denote.Task) {
+		tk.TaskMetadata.BlockedBy = []string{f.linked.ID}
+		tk.RelatedTasks = []string{f.linked.ID}
+	})
+	f.stray = newTestTask(t, dir, "Fix fence", func(tk *denote.Task) {
+		tk.TaskMetadata.ProjectID = "999"
+	})
+	f.old = newTestTask(t, dir, "Rake leaves", nil)
+	if err := task.ArchiveTask(dir, f.old); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// exportTestBundle writes dir's bundle to a temp file and returns its path.
+func exportTestBundle(t *testing.T, dir string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "backup.json")
+	if err := exportBundleCommand(&config.Config{NotesDirectory: dir}).Execute([]string{file}); err != nil {
+		t.Fatalf("export bundle: %v", err)
+	}
+	return file
+}
+
+// tasksByTitle scans dir, archive included, and indexes the tasks by title.
+func tasksByTitle(t *testing.T, dir string) map[string]*denote.Task {
+	t.Helper()
+	scanner := denote.NewScanner(dir)
+	tasks, err := scanner.FindTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived, err := scanner.FindArchivedTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTitle := make(map[string]*denote.Task)
+	for _, tk := range append(tasks, archived...) {
+		byTitle[tk.Title] = tk
+	}
+	return byTitle
+}
+
+func TestBundleRoundTripFreshIDs(t *testing.T) {
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+
+	src := t.TempDir()
+	f := newBundleFixture(t, src)
+	file := exportTestBundle(t, src)
+
+	// Existing entities in the target push the fresh index_ids past the
+	// bundle's, so an unmapped link would point at the wrong entity
+	dst := t.TempDir()
+	for range 5 {
+		if _, err := task.CreateProject(dst, "Existing", "", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := importBundleCommand(&config.Config{NotesDirectory: dst}).Execute([]string{file}); err != nil {
+		t.Fatalf("import bundle: %v", err)
+	}
+
+	projects, err := denote.NewScanner(dst).FindProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var garden *denote.Project
+	for _, p := range projects {
+		if p.Title == "Garden" {
+			garden = p
+		}
+	}
+	if garden == nil {
+		t.Fatal("imported project not found")
+	}
+	if garden.ID == f.project.ID || garden.IndexID == f.project.IndexID {
+		t.Errorf("project kept its IDs (%s, #%d), want fresh ones", garden.ID, garden.IndexID)
+	}
+
+	got := tasksByTitle(t, dst)
+	linked, waiting, stray, old := got["Buy seeds"], got["Plant seeds"], got["Fix fence"], got["Rake leaves"]
+	if linked == nil || waiting == nil || stray == nil || old == nil {
+		t.Fatalf("imported tasks = %v, want all four", got)
+	}
+	if linked.ID == f.linked.ID {
+		t.Errorf("task kept its ULID %s, want a fresh one", linked.ID)
+	}
+	if want := strconv.Itoa(garden.IndexID); linked.TaskMetadata.ProjectID != want {
+		t.Errorf("project_id = %q, want remapped to %s", linked.TaskMetadata.ProjectID, want)
+	}
+	if len(waiting.TaskMetadata.BlockedBy) != 1 || waiting.TaskMetadata.BlockedBy[0] != linked.ID {
+		t.Errorf("blocked_by = %v, want [%s]", waiting.TaskMetadata.BlockedBy, linked.ID)
+	}
+	if len(waiting.RelatedTasks) != 1 || waiting.RelatedTasks[0] != linked.ID {
+		t.Errorf("related_tasks = %v, want [%s]", waiting.RelatedTasks, linked.ID)
+	}
+	if stray.TaskMetadata.ProjectID != "" {
+		t.Errorf("project_id outside the bundle = %q, want cleared", stray.TaskMetadata.ProjectID)
+	}
+	if filepath.Base(filepath.Dir(old.FilePath)) != "archive" {
+		t.Errorf("archived task restored to %s, want archive/", old.FilePath)
+	}
+	if linked.Content == "" {
+		t.Error("task body was not restored")
+	}
+}
+
+func TestBundleRoundTripPreserveIDs(t *testing.T) {
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+
+	src := t.TempDir()
+	f := newBundleFixture(t, src)
+	file := exportTestBundle(t, src)
+
+	dst := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dst}
+	if err := importBundleCommand(cfg).Execute([]string{file, "--preserve-ids"}); err != nil {
+		t.Fatalf("import bundle --preserve-ids: %v", err)
+	}
+
+	got := tasksByTitle(t, dst)
+	for _, want := range []*denote.Task{f.linked, f.waiting, f.stray, f.old} {
+		tk := got[want.Title]
+		if tk == nil {
+			t.Errorf("task %q not imported", want.Title)
+			continue
+		}
+		if tk.ID != want.ID || tk.IndexID != want.IndexID {
+			t.Errorf("task %q = (%s, #%d), want (%s, #%d)", want.Title, tk.ID, tk.IndexID, want.ID, want.IndexID)
+		}
+	}
+	if got["Buy seeds"].TaskMetadata.ProjectID != strconv.Itoa(f.project.IndexID) {
+		t.Errorf("project_id = %q, want it kept", got["Buy seeds"].TaskMetadata.ProjectID)
+	}
+	if bb := got["Plant seeds"].TaskMetadata.BlockedBy; len(bb) != 1 || bb[0] != f.linked.ID {
+		t.Errorf("blocked_by = %v, want [%s]", bb, f.linked.ID)
+	}
+
+	// The counter is reseeded past every imported index_id, archived included
+	next, err := task.NextIndexID(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next <= f.old.IndexID {
+		t.Errorf("next index_id = %d, want past the archived task's %d", next, f.old.IndexID)
+	}
+
+	// Re-importing skips everything already present
+	if err := importBundleCommand(cfg).Execute([]string{file, "--preserve-ids"}); err != nil {
+		t.Errorf("re-import --preserve-ids: %v", err)
+	}
+	if n := len(tasksByTitle(t, dst)); n != 4 {
+		t.Errorf("after re-import %d tasks, want 4", n)
+	}
+}
+
+func TestBundleImportPreserveIDsRefusesArchivedIndexID(t *testing.T) {
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+
+	src := t.TempDir()
+	newTestTask(t, src, "Water plants", nil)
+	file := exportTestBundle(t, src)
+
+	// The target's only task is archived and holds the same index_id
+	dst := t.TempDir()
+	held := newTestTask(t, dst, "Old chore", nil)
+	if err := task.ArchiveTask(dst, held); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := importBundleCommand(&config.Config{NotesDirectory: dst}).Execute([]string{file, "--preserve-ids"}); err == nil {
+		t.Error("import over an archived task's index_id succeeded, want error")
+	}
+	if _, ok := tasksByTitle(t, dst)["Water plants"]; ok {
+		t.Error("task with a colliding index_id was imported")
+	}
+}
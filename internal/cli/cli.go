@@ -1,10 +1,14 @@
 package cli
 
 import (
+	"flag"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"github.com/mph-llm-experiments/atask/internal/task"
 	"github.com/mph-llm-experiments/atask/internal/tui"
 )
 
@@ -26,12 +30,22 @@ func Run(cfg *config.Config, args []string) error {
 		cfg = newCfg
 	}
 
+	// Notes directory precedence: --dir/--notes-dir > ATASK_NOTES_DIR > config
 	if globalFlags.Dir != "" {
-		cfg.NotesDirectory = globalFlags.Dir
+		cfg.NotesDirectory = config.ExpandHome(globalFlags.Dir)
+	} else if envDir := os.Getenv("ATASK_NOTES_DIR"); envDir != "" {
+		cfg.NotesDirectory = config.ExpandHome(envDir)
 	}
 
-	// Sync on startup/shutdown — skip for --json (programmatic/aweb use)
-	if !globalFlags.JSON {
+	task.SetSlugOptions(task.SlugOptions{
+		MaxLength:     cfg.Filenames.SlugMaxLength,
+		Transliterate: cfg.Filenames.Transliterate,
+	})
+
+	// Sync on startup/shutdown — skip for --json (programmatic/aweb use),
+	// for sync dry-runs, which must not transfer anything, and for summary
+	// and completion, which status bars and shells poll frequently.
+	if !globalFlags.JSON && !isSyncDryRun(remaining) && !isPollingCommand(remaining) {
 		SyncOnStartup(cfg)
 		defer SyncOnShutdown(cfg)
 	}
@@ -46,7 +60,16 @@ func Run(cfg *config.Config, args []string) error {
 		}
 	}
 
-	// Create root command
+	// Scan progress would garble the TUI, so only enable it for commands
+	if globalFlags.Verbose {
+		denote.SetVerbose(os.Stderr)
+	}
+
+	return rootCommand(cfg).Execute(remaining)
+}
+
+// rootCommand builds the atask command tree.
+func rootCommand(cfg *config.Config) *Command {
 	root := &Command{
 		Name:  "atask",
 		Usage: "atask <command> [options]",
@@ -55,10 +78,20 @@ func Run(cfg *config.Config, args []string) error {
 Task Commands (implicit):
   new        Create a new task
   list       List tasks
+  summary    One-line overdue/soon/open counts
   show       Show task details
   update     Update task metadata
   done       Mark tasks as done
+  start      Mark tasks as in progress
+  reopen     Set done tasks back to open
+  promote    Raise task priority one step
+  demote     Lower task priority one step
+  bump       Shift due dates by an offset (+3d, -1w)
+  plan       Plan tasks for a day (default today); --clear to unset
   log        Add log entry to task
+  merge      Merge a duplicate task into another
+  move       Move tasks to another area and/or project
+  archive    Move long-finished tasks into archive/
 
 Project Commands:
   project new      Create a new project
@@ -66,6 +99,8 @@ Project Commands:
   project show     Show project details
   project update   Update project metadata
   project tasks    Show tasks for a project
+  project move-tasks  Move all tasks of one project to another
+  project stats    Summarize projects by status
 
 Action Queue Commands:
   action new       Create a proposed action
@@ -74,19 +109,29 @@ Action Queue Commands:
   action update    Modify action fields
   action approve   Approve and execute an action
   action reject    Reject an action
+  action retry     Requeue a failed archived action
+  action purge     Delete old archived actions
 
 Other Commands:
+  context     Show effective settings and counts
+  today       Overdue, due-today, and planned tasks in one view
+  stats       Dashboard of open, done-this-week, and overdue counts
+  doctor      Report integrity problems (--fix repairs the safe ones)
   sync        Sync files with Cloudflare R2
-  completion  Generate shell completions
+  export      Write a backup bundle (export bundle file.json)
+  import      Restore a backup bundle (import bundle file.json)
+  completion  Completion data, or a shell script (completion script zsh)
 
 Global Options:
   --tui, -t      Launch TUI interface
   --area AREA    Filter by area (for TUI or commands)
   --config PATH  Use specific config file
-  --dir PATH     Override task directory
+  --dir PATH     Override task directory (alias --notes-dir; env ATASK_NOTES_DIR)
   --json         Output in JSON format
   --no-color     Disable color output
-  --quiet, -q    Minimal output`,
+  --ascii        Use plain ASCII status icons
+  --quiet, -q    Minimal output
+  --verbose      Report scan progress and parse errors on stderr`,
 	}
 
 	// Get task commands and add them directly to root
@@ -95,15 +140,41 @@ Global Options:
 		root.Subcommands = append(root.Subcommands, cmd)
 	}
 	
-	// Add project, action, sync, completion, and migrate commands
+	// Add project, action, context, today, stats, doctor, sync, export/import, completion, and migrate commands
 	root.Subcommands = append(root.Subcommands,
 		ProjectCommand(cfg),
 		ActionCommand(cfg),
+		ContextCommand(cfg),
+		TodayCommand(cfg),
+		StatsCommand(cfg),
+		DoctorCommand(cfg),
 		SyncCommand(cfg),
-		CompletionCommand(cfg),
+		ExportCommand(cfg),
+		ImportCommand(cfg),
+		CompletionCommand(cfg, root),
 		MigrateCommand(cfg),
 	)
 
-	// Execute command
-	return root.Execute(remaining)
-}
\ No newline at end of file
+	return root
+}
+
+// isSyncDryRun reports whether the arguments invoke `sync --dry-run`. They
+// are parsed with sync's own flags, so --dry-run=true counts too.
+func isSyncDryRun(args []string) bool {
+	if len(args) == 0 || args[0] != "sync" {
+		return false
+	}
+	fs := SyncCommand(&config.Config{}).Flags
+	fs.SetOutput(io.Discard)
+	if err := fs.Parse(reorderFlagsFirst(args[1:], fs)); err != nil {
+		return false
+	}
+	dryRun, _ := fs.Lookup("dry-run").Value.(flag.Getter).Get().(bool)
+	return dryRun
+}
+
+// isPollingCommand reports whether the arguments invoke a command that is
+// run repeatedly in the background and must stay fast.
+func isPollingCommand(args []string) bool {
+	return len(args) > 0 && (args[0] == "summary" || args[0] == "completion")
+}
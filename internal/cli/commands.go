@@ -90,7 +90,7 @@ func reorderFlagsFirst(args []string, fs *flag.FlagSet) []string {
 			positional = append(positional, args[i+1:]...)
 			break
 		}
-		if strings.HasPrefix(arg, "-") {
+		if strings.HasPrefix(arg, "-") && !isNegativeOffset(arg) {
 			// It's a flag. Check if the flag takes a value.
 			flags = append(flags, arg)
 			name := strings.TrimLeft(arg, "-")
@@ -118,6 +118,12 @@ func reorderFlagsFirst(args []string, fs *flag.FlagSet) []string {
 	return append(flags, positional...)
 }
 
+// isNegativeOffset reports whether arg is a negative number or relative
+// offset such as -1w, which is a positional value rather than a flag.
+func isNegativeOffset(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-' && arg[1] >= '0' && arg[1] <= '9'
+}
+
 // isBoolFlag checks if a flag is a boolean flag (doesn't take a value argument)
 func isBoolFlag(f *flag.Flag) bool {
 	// Check if the flag implements the boolFlag interface
@@ -130,6 +136,12 @@ func isBoolFlag(f *flag.Flag) bool {
 	return false
 }
 
+// jsonSchemaVersion is reported as schema_version in the list-style JSON
+// envelopes (list, query, project list, project tasks) and in stats. Bump
+// it whenever the shape of those envelopes or their items changes
+// incompatibly.
+const jsonSchemaVersion = 1
+
 // Global flags
 type GlobalFlags struct {
 	Config   string
@@ -138,6 +150,8 @@ type GlobalFlags struct {
 	NoColor  bool
 	JSON     bool
 	Quiet    bool
+	Verbose  bool
+	ASCII    bool
 	Area     string
 }
 
@@ -152,11 +166,11 @@ func ParseGlobalFlags(args []string) ([]string, error) {
 		arg := args[i]
 		
 		// Check if this is a global flag with value
-		if (arg == "--config" || arg == "--dir" || arg == "--area") && i+1 < len(args) {
+		if (arg == "--config" || arg == "--dir" || arg == "--notes-dir" || arg == "--area") && i+1 < len(args) {
 			switch arg {
 			case "--config":
 				globalFlags.Config = args[i+1]
-			case "--dir":
+			case "--dir", "--notes-dir":
 				globalFlags.Dir = args[i+1]
 			case "--area":
 				globalFlags.Area = args[i+1]
@@ -183,6 +197,14 @@ func ParseGlobalFlags(args []string) ([]string, error) {
 			globalFlags.Quiet = true
 			i++
 			continue
+		case "--verbose":
+			globalFlags.Verbose = true
+			i++
+			continue
+		case "--ascii":
+			globalFlags.ASCII = true
+			i++
+			continue
 		}
 		
 		// Check for = style flags (e.g., --config=value)
@@ -196,6 +218,11 @@ func ParseGlobalFlags(args []string) ([]string, error) {
 			i++
 			continue
 		}
+		if strings.HasPrefix(arg, "--notes-dir=") {
+			globalFlags.Dir = strings.TrimPrefix(arg, "--notes-dir=")
+			i++
+			continue
+		}
 		if strings.HasPrefix(arg, "--area=") {
 			globalFlags.Area = strings.TrimPrefix(arg, "--area=")
 			i++
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestIsNegativeOffset(t *testing.T) {
+	for _, tc := range []struct {
+		arg  string
+		want bool
+	}{
+		{"-1w", true},
+		{"-3d", true},
+		{"-12", true},
+		{"-", false},
+		{"--keep-weekday", false},
+		{"-json", false},
+		{"+1w", false},
+	} {
+		if got := isNegativeOffset(tc.arg); got != tc.want {
+			t.Errorf("isNegativeOffset(%q) = %v, want %v", tc.arg, got, tc.want)
+		}
+	}
+}
+
+func TestReorderFlagsFirstKeepsNegativeOffsetsPositional(t *testing.T) {
+	fs := flag.NewFlagSet("bump", flag.ContinueOnError)
+	fs.Bool("keep-weekday", false, "")
+	fs.String("area", "", "")
+
+	got := reorderFlagsFirst([]string{"12", "-1w", "--keep-weekday", "--area", "-2d"}, fs)
+	// The value of a non-bool flag is taken as is, even if it looks negative
+	want := []string{"--keep-weekday", "--area", "-2d", "12", "-1w"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reorderFlagsFirst = %q, want %q", got, want)
+	}
+}
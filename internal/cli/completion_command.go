@@ -5,21 +5,23 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/mph-llm-experiments/atask/internal/config"
 	"github.com/mph-llm-experiments/atask/internal/denote"
 )
 
-// CompletionCommand returns the completion command
-func CompletionCommand(cfg *config.Config) *Command {
+// CompletionCommand returns the completion command. root is walked by
+// `completion script` to generate the shell integration.
+func CompletionCommand(cfg *config.Config, root *Command) *Command {
 	cmd := &Command{
 		Name:        "completion",
-		Usage:       "completion <type>",
-		Description: "Output completion data for shell scripts",
+		Usage:       "atask completion <type> | atask completion script <bash|zsh|fish>",
+		Description: "Output completion data or a shell completion script",
 		Flags:       flag.NewFlagSet("completion", flag.ContinueOnError),
 		Run: func(c *Command, args []string) error {
 			if len(args) == 0 {
-				return fmt.Errorf("completion type required: task-ids, project-ids, areas, tags")
+				return fmt.Errorf("completion type required: %s, or script <shell>", strings.Join(completionTypes, ", "))
 			}
 
 			scanner := denote.NewScanner(cfg.NotesDirectory)
@@ -43,6 +45,10 @@ func CompletionCommand(cfg *config.Config) *Command {
 		},
 	}
 
+	cmd.Subcommands = []*Command{
+		completionScriptCommand(root),
+	}
+
 	return cmd
 }
 
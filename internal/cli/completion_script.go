@@ -0,0 +1,412 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// completionTypes are the data sets `atask completion <type>` can output.
+var completionTypes = []string{"task-ids", "project-ids", "areas", "tags"}
+
+// completionShells are the shells `atask completion script` can target.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// globalCompletionFlags mirrors the flags handled by ParseGlobalFlags.
+var globalCompletionFlags = []completionFlag{
+	{name: "--tui", kind: ""},
+	{name: "-t", kind: ""},
+	{name: "--area", kind: "areas"},
+	{name: "--config", kind: "value"},
+	{name: "--dir", kind: "value"},
+	{name: "--notes-dir", kind: "value"},
+	{name: "--json", kind: ""},
+	{name: "--no-color", kind: ""},
+	{name: "--ascii", kind: ""},
+	{name: "--quiet", kind: ""},
+	{name: "-q", kind: ""},
+	{name: "--verbose", kind: ""},
+}
+
+// completionFlag is a flag as offered by the generated scripts.
+type completionFlag struct {
+	name string // with dashes: -p, --priority
+	kind string // "" for booleans, "value", or a value source (areas, tags, project-ids, priority)
+}
+
+// completionNode is one command path in the generated scripts.
+type completionNode struct {
+	key   string   // command path below atask, "" for atask itself
+	words []string // subcommands or fixed values for the next word
+	flags []completionFlag
+	args  string // dynamic positional data: task-ids, project-ids, or ""
+}
+
+// completionScriptCommand emits a shell completion script for the command tree
+func completionScriptCommand(root *Command) *Command {
+	return &Command{
+		Name:        "script",
+		Usage:       "atask completion script <bash|zsh|fish>",
+		Description: "Print a completion script to source from your shell",
+		Flags:       flag.NewFlagSet("completion-script", flag.ContinueOnError),
+		Run: func(c *Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("shell required: %s", strings.Join(completionShells, ", "))
+			}
+
+			nodes := collectCompletionNodes(root)
+			switch args[0] {
+			case "bash":
+				fmt.Print(bashCompletionScript(nodes))
+			case "zsh":
+				fmt.Print(zshCompletionScript(nodes))
+			case "fish":
+				fmt.Print(fishCompletionScript(nodes))
+			default:
+				return fmt.Errorf("unsupported shell: %s (use %s)", args[0], strings.Join(completionShells, ", "))
+			}
+			return nil
+		},
+	}
+}
+
+// collectCompletionNodes walks the command tree, reading flags from each
+// command's FlagSet so the scripts never drift from the real commands.
+func collectCompletionNodes(root *Command) []completionNode {
+	var nodes []completionNode
+
+	var walk func(key string, c *Command)
+	walk = func(key string, c *Command) {
+		node := completionNode{key: key, args: completionArgKind(key, c.Usage)}
+		for _, sub := range c.Subcommands {
+			node.words = append(node.words, sub.Name)
+		}
+		switch key {
+		case "":
+			node.flags = globalCompletionFlags
+		case "completion":
+			node.words = append(node.words, completionTypes...)
+		case "completion script":
+			node.words = completionShells
+		}
+		if c.Flags != nil {
+			c.Flags.VisitAll(func(f *flag.Flag) {
+				node.flags = append(node.flags, newCompletionFlag(f))
+			})
+		}
+		nodes = append(nodes, node)
+
+		for _, sub := range c.Subcommands {
+			walk(strings.TrimSpace(key+" "+sub.Name), sub)
+		}
+	}
+	walk("", root)
+
+	return nodes
+}
+
+// completionArgKind infers what a command's positional arguments are from
+// its usage line.
+func completionArgKind(key, usage string) string {
+	switch {
+	case strings.HasPrefix(key, "action "):
+		// Action IDs have no completion data
+		return ""
+	case strings.Contains(usage, "<project-id"):
+		return "project-ids"
+	case strings.HasPrefix(key, "project ") && strings.Contains(usage, "<id>"):
+		return "project-ids"
+	case strings.Contains(usage, "<task-id"), strings.Contains(usage, "-id>"), strings.Contains(usage, "<id>"):
+		return "task-ids"
+	}
+	return ""
+}
+
+func newCompletionFlag(f *flag.Flag) completionFlag {
+	cf := completionFlag{name: "--" + f.Name}
+	if len(f.Name) == 1 {
+		cf.name = "-" + f.Name
+	}
+
+	switch {
+	case isBoolFlag(f):
+		cf.kind = ""
+	case f.Name == "area":
+		cf.kind = "areas"
+	case f.Name == "tag" || f.Name == "tags":
+		cf.kind = "tags"
+	case f.Name == "project":
+		cf.kind = "project-ids"
+	case f.Name == "p" || f.Name == "priority":
+		cf.kind = "priority"
+	default:
+		cf.kind = "value"
+	}
+	return cf
+}
+
+// commandKeys returns every command path below atask.
+func commandKeys(nodes []completionNode) []string {
+	var keys []string
+	for _, n := range nodes {
+		if n.key != "" {
+			keys = append(keys, n.key)
+		}
+	}
+	return keys
+}
+
+// valueFlagNames returns the flag names per value source, across all commands.
+func valueFlagNames(nodes []completionNode) map[string][]string {
+	seen := make(map[string]bool)
+	names := make(map[string][]string)
+	for _, n := range nodes {
+		for _, f := range n.flags {
+			if f.kind == "" || f.kind == "value" || seen[f.name] {
+				continue
+			}
+			seen[f.name] = true
+			names[f.kind] = append(names[f.kind], f.name)
+		}
+	}
+	for kind := range names {
+		sort.Strings(names[kind])
+	}
+	return names
+}
+
+// writeValueCases writes one case arm per value source that has flags.
+func writeValueCases(b *strings.Builder, values map[string][]string, actions map[string]string) {
+	for _, kind := range []string{"areas", "tags", "project-ids", "priority"} {
+		if len(values[kind]) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "        %s) %s ;;\n", strings.Join(values[kind], "|"), actions[kind])
+	}
+}
+
+func flagNames(flags []completionFlag) []string {
+	names := make([]string, len(flags))
+	for i, f := range flags {
+		names[i] = f.name
+	}
+	return names
+}
+
+// shellPattern quotes command paths for a case pattern list.
+func shellPattern(keys []string) string {
+	quoted := make([]string, len(keys))
+	for i, k := range keys {
+		quoted[i] = "'" + k + "'"
+	}
+	return strings.Join(quoted, "|")
+}
+
+func bashCompletionScript(nodes []completionNode) string {
+	var b strings.Builder
+	values := valueFlagNames(nodes)
+
+	b.WriteString(`# bash completion for atask
+# Load with: source <(atask completion script bash)
+
+_atask() {
+    local cur prev key word i
+    local candidates="" flags="" args=""
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    case "$prev" in
+`)
+	writeValueCases(&b, values, map[string]string{
+		"areas":       `COMPREPLY=($(compgen -W "$(atask completion areas 2>/dev/null)" -- "$cur")); return`,
+		"tags":        `COMPREPLY=($(compgen -W "$(atask completion tags 2>/dev/null)" -- "$cur")); return`,
+		"project-ids": `COMPREPLY=($(compgen -W "$(atask completion project-ids 2>/dev/null | cut -d: -f1)" -- "$cur")); return`,
+		"priority":    `COMPREPLY=($(compgen -W "p1 p2 p3" -- "$cur")); return`,
+	})
+	b.WriteString(`    esac
+
+    # Resolve the deepest command from the words typed so far
+    key=""
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        word="${COMP_WORDS[i]}"
+        case "$word" in
+            --config|--dir|--notes-dir|--area) ((i++)); continue ;;
+            -*) continue ;;
+        esac
+        case "${key:+$key }$word" in
+`)
+	fmt.Fprintf(&b, "            %s) key=\"${key:+$key }$word\" ;;\n", shellPattern(commandKeys(nodes)))
+	b.WriteString(`            *) break ;;
+        esac
+    done
+
+    case "$key" in
+`)
+	for _, n := range nodes {
+		fmt.Fprintf(&b, "        '%s') candidates=%q; flags=%q; args=%q ;;\n",
+			n.key, strings.Join(n.words, " "), strings.Join(flagNames(n.flags), " "), n.args)
+	}
+	b.WriteString(`    esac
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+        return
+    fi
+    case "$args" in
+        task-ids) candidates="$candidates $(atask completion task-ids 2>/dev/null)" ;;
+        project-ids) candidates="$candidates $(atask completion project-ids 2>/dev/null | cut -d: -f1)" ;;
+    esac
+    COMPREPLY=($(compgen -W "$candidates" -- "$cur"))
+}
+
+complete -F _atask atask
+`)
+	return b.String()
+}
+
+func zshCompletionScript(nodes []completionNode) string {
+	var b strings.Builder
+	values := valueFlagNames(nodes)
+
+	b.WriteString(`#compdef atask
+# zsh completion for atask
+# Load with: source <(atask completion script zsh)
+
+_atask() {
+    local key="" word i args=""
+    local prev=${words[CURRENT-1]}
+    local -a candidates flags projects
+
+    case $prev in
+`)
+	writeValueCases(&b, values, map[string]string{
+		"areas":       `compadd -- ${(f)"$(atask completion areas 2>/dev/null)"}; return`,
+		"tags":        `compadd -- ${(f)"$(atask completion tags 2>/dev/null)"}; return`,
+		"project-ids": `projects=(${(f)"$(atask completion project-ids 2>/dev/null)"}); _describe -t projects project projects; return`,
+		"priority":    `compadd p1 p2 p3; return`,
+	})
+	b.WriteString(`    esac
+
+    # Resolve the deepest command from the words typed so far
+    for ((i = 2; i < CURRENT; i++)); do
+        word=${words[i]}
+        case $word in
+            --config|--dir|--notes-dir|--area) ((i++)); continue ;;
+            -*) continue ;;
+        esac
+        case "${key:+$key }$word" in
+`)
+	fmt.Fprintf(&b, "            %s) key=\"${key:+$key }$word\" ;;\n", shellPattern(commandKeys(nodes)))
+	b.WriteString(`            *) break ;;
+        esac
+    done
+
+    case $key in
+`)
+	for _, n := range nodes {
+		fmt.Fprintf(&b, "        '%s') candidates=(%s); flags=(%s); args=%q ;;\n",
+			n.key, strings.Join(n.words, " "), strings.Join(flagNames(n.flags), " "), n.args)
+	}
+	b.WriteString(`    esac
+
+    if [[ $PREFIX == -* ]]; then
+        compadd -- $flags
+        return
+    fi
+    case $args in
+        task-ids) candidates+=(${(f)"$(atask completion task-ids 2>/dev/null)"}) ;;
+        project-ids)
+            projects=(${(f)"$(atask completion project-ids 2>/dev/null)"})
+            _describe -t projects project projects
+            ;;
+    esac
+    compadd -- $candidates
+}
+
+compdef _atask atask
+`)
+	return b.String()
+}
+
+func fishCompletionScript(nodes []completionNode) string {
+	var b strings.Builder
+
+	b.WriteString(`# fish completion for atask
+# Load with: atask completion script fish | source
+
+# Print the deepest command resolved from the words typed so far
+function __atask_key
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    set -l key ''
+    set -l skip 0
+    for word in $tokens
+        if test $skip = 1
+            set skip 0
+            continue
+        end
+        switch $word
+            case --config --dir --notes-dir --area
+                set skip 1
+                continue
+            case '-*'
+                continue
+        end
+        set -l next (string trim -- "$key $word")
+        switch $next
+`)
+	quoted := make([]string, 0, len(nodes))
+	for _, k := range commandKeys(nodes) {
+		quoted = append(quoted, "'"+k+"'")
+	}
+	fmt.Fprintf(&b, "            case %s\n", strings.Join(quoted, " "))
+	b.WriteString(`                set key $next
+            case '*'
+                break
+        end
+    end
+    echo $key
+end
+
+function __atask_is
+    set -l key (__atask_key)
+    test "$key" = "$argv"
+end
+
+complete -c atask -f
+`)
+
+	for _, n := range nodes {
+		cond := strings.TrimSpace("__atask_is " + n.key)
+		if len(n.words) > 0 {
+			fmt.Fprintf(&b, "complete -c atask -n '%s' -a '%s'\n", cond, strings.Join(n.words, " "))
+		}
+		switch n.args {
+		case "task-ids":
+			fmt.Fprintf(&b, "complete -c atask -n '%s' -a '(atask completion task-ids 2>/dev/null)'\n", cond)
+		case "project-ids":
+			fmt.Fprintf(&b, "complete -c atask -n '%s' -a '(atask completion project-ids 2>/dev/null | string replace : \\t)'\n", cond)
+		}
+		for _, f := range n.flags {
+			opt := "-l " + strings.TrimPrefix(f.name, "--")
+			if !strings.HasPrefix(f.name, "--") {
+				opt = "-o " + strings.TrimPrefix(f.name, "-")
+			}
+			switch f.kind {
+			case "":
+				fmt.Fprintf(&b, "complete -c atask -n '%s' %s\n", cond, opt)
+			case "value":
+				fmt.Fprintf(&b, "complete -c atask -n '%s' %s -r\n", cond, opt)
+			case "priority":
+				fmt.Fprintf(&b, "complete -c atask -n '%s' %s -x -a 'p1 p2 p3'\n", cond, opt)
+			case "project-ids":
+				fmt.Fprintf(&b, "complete -c atask -n '%s' %s -x -a '(atask completion project-ids 2>/dev/null | string replace : \\t)'\n", cond, opt)
+			default:
+				fmt.Fprintf(&b, "complete -c atask -n '%s' %s -x -a '(atask completion %s 2>/dev/null)'\n", cond, opt, f.kind)
+			}
+		}
+	}
+
+	return b.String()
+}
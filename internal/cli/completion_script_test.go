@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+)
+
+// commandPaths returns every command path below c, e.g. "project show".
+func commandPaths(prefix string, c *Command) []string {
+	var paths []string
+	for _, sub := range c.Subcommands {
+		path := strings.TrimSpace(prefix + " " + sub.Name)
+		paths = append(paths, path)
+		paths = append(paths, commandPaths(path, sub)...)
+	}
+	return paths
+}
+
+func TestCompletionScriptListsEverySubcommand(t *testing.T) {
+	root := rootCommand(&config.Config{NotesDirectory: t.TempDir()})
+	paths := commandPaths("", root)
+	if len(paths) == 0 {
+		t.Fatal("root command has no subcommands")
+	}
+
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var runErr error
+			script := captureStdout(t, func() {
+				runErr = root.Execute([]string{"completion", "script", shell})
+			})
+			if runErr != nil {
+				t.Fatalf("completion script %s error = %v", shell, runErr)
+			}
+			if !strings.Contains(script, "# "+shell+" completion for atask") {
+				t.Errorf("%s script is missing its header:\n%s", shell, script)
+			}
+			for _, path := range paths {
+				if !strings.Contains(script, "'"+path+"'") {
+					t.Errorf("%s script does not list %q", shell, path)
+				}
+			}
+		})
+	}
+}
+
+func TestCompletionScriptRejectsUnknownShell(t *testing.T) {
+	root := rootCommand(&config.Config{NotesDirectory: t.TempDir()})
+	if err := root.Execute([]string{"completion", "script", "powershell"}); err == nil {
+		t.Error("completion script powershell succeeded, want error")
+	}
+	if err := root.Execute([]string{"completion", "script"}); err == nil {
+		t.Error("completion script without a shell succeeded, want error")
+	}
+}
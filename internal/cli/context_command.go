@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+// ContextCommand returns the context command, which shows effective settings
+func ContextCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "context",
+		Usage:       "atask context",
+		Description: "Show the effective notes directory, config, and entity counts",
+		Run: func(c *Command, args []string) error {
+			configFile := globalFlags.Config
+			if configFile == "" {
+				configFile = config.ActivePath()
+			}
+
+			r2Configured := false
+			if acoreCfg, err := acore.LoadConfig(); err == nil {
+				r2Configured = acoreCfg.R2.Enabled()
+			}
+
+			scanner := denote.NewScanner(cfg.NotesDirectory)
+			tasks, err := scanner.FindTasks()
+			if err != nil {
+				return fmt.Errorf("failed to scan directory: %v", err)
+			}
+			projects, err := scanner.FindProjects()
+			if err != nil {
+				return fmt.Errorf("failed to scan directory: %v", err)
+			}
+			actions, err := scanner.FindActions()
+			if err != nil {
+				return fmt.Errorf("failed to scan queue: %v", err)
+			}
+			pending := 0
+			for _, a := range actions {
+				if a.Status == denote.ActionPending {
+					pending++
+				}
+			}
+
+			if globalFlags.JSON {
+				output := struct {
+					NotesDirectory string `json:"notes_directory"`
+					ConfigFile     string `json:"config_file"`
+					Area           string `json:"area"`
+					SoonHorizon    int    `json:"soon_horizon"`
+					R2Sync         bool   `json:"r2_sync"`
+					Tasks          int    `json:"tasks"`
+					Projects       int    `json:"projects"`
+					PendingActions int    `json:"pending_actions"`
+				}{
+					NotesDirectory: cfg.NotesDirectory,
+					ConfigFile:     configFile,
+					Area:           globalFlags.Area,
+					SoonHorizon:    cfg.SoonHorizon,
+					R2Sync:         r2Configured,
+					Tasks:          len(tasks),
+					Projects:       len(projects),
+					PendingActions: pending,
+				}
+				data, err := json.MarshalIndent(output, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			orNone := func(s string) string {
+				if s == "" {
+					return "(none)"
+				}
+				return s
+			}
+			syncStr := "not configured"
+			if r2Configured {
+				syncStr = "configured"
+			}
+
+			fmt.Printf("  Notes dir:    %s\n", cfg.NotesDirectory)
+			fmt.Printf("  Config file:  %s\n", orNone(configFile))
+			fmt.Printf("  Area:         %s\n", orNone(globalFlags.Area))
+			fmt.Printf("  Soon horizon: %d days\n", cfg.SoonHorizon)
+			fmt.Printf("  R2 sync:      %s\n", syncStr)
+			fmt.Println()
+			fmt.Printf("  Tasks:           %d\n", len(tasks))
+			fmt.Printf("  Projects:        %d\n", len(projects))
+			fmt.Printf("  Pending actions: %d\n", pending)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+// Output formats accepted by --format on list and query.
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+	formatJSONL = "jsonl"
+)
+
+// outputFormat resolves --format against the global --json flag. An empty
+// format falls back to json when --json is set and text otherwise.
+func outputFormat(format string) (string, error) {
+	switch format {
+	case "":
+		if globalFlags.JSON {
+			return formatJSON, nil
+		}
+		return formatText, nil
+	case formatText, formatJSON, formatCSV, formatJSONL:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid --format: %s (valid: text, json, csv, jsonl)", format)
+}
+
+// csvHeader is the column order written by writeTasksCSV.
+var csvHeader = []string{"index_id", "status", "priority", "due_date", "area", "project", "title"}
+
+// writeTasksCSV writes tasks as CSV with a header row. Projects are written
+// by name when projectNames knows them, otherwise by index_id. Output is
+// always plain: no colors or icons.
+func writeTasksCSV(w io.Writer, tasks []denote.Task, projectNames map[string]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		project := t.TaskMetadata.ProjectID
+		if name := projectNames[project]; name != "" {
+			project = name
+		}
+		record := []string{
+			strconv.Itoa(t.IndexID),
+			t.TaskMetadata.Status,
+			t.TaskMetadata.Priority,
+			t.TaskMetadata.DueDate,
+			t.TaskMetadata.Area,
+			project,
+			t.Title,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// jsonlTask is one line of jsonl output: the task as in --json, plus its
+// project name and, with --json-computed, its readiness.
+type jsonlTask struct {
+	denote.Task
+	ProjectName  string   `json:"project_name,omitempty"`
+	Blocked      *bool    `json:"blocked,omitempty"`
+	OpenBlockers []string `json:"open_blockers,omitempty"`
+}
+
+// writeTasksJSONL writes one compact JSON object per task, encoding each
+// as it goes rather than building the whole document. No envelope, counts,
+// or totals are written, so every line is a task.
+func writeTasksJSONL(w io.Writer, tasks []denote.Task, projectNames map[string]string, computed bool) error {
+	enc := json.NewEncoder(w)
+	for _, t := range tasks {
+		line := jsonlTask{Task: t, ProjectName: projectNames[t.ProjectID]}
+		if computed {
+			blocked := t.IsBlocked()
+			line.Blocked = &blocked
+			line.OpenBlockers = t.OpenBlockers
+		}
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"github.com/mph-llm-experiments/atask/internal/task"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// doctorIssue is one integrity problem found by doctor. fix, when set,
+// repairs the problem in memory on the issue's task; the caller writes it.
+type doctorIssue struct {
+	Severity string `json:"severity"`
+	Kind     string `json:"kind"`
+	File     string `json:"file"`
+	IndexID  int    `json:"index_id,omitempty"`
+	Message  string `json:"message"`
+	Fixable  bool   `json:"fixable"`
+	Fixed    bool   `json:"fixed,omitempty"`
+
+	task *denote.Task
+	fix  func(t *denote.Task)
+}
+
+// DoctorCommand returns the doctor command, which checks tasks and projects
+// for integrity problems and optionally repairs the safe ones
+func DoctorCommand(cfg *config.Config) *Command {
+	var fix bool
+
+	cmd := &Command{
+		Name:        "doctor",
+		Usage:       "atask doctor [--fix]",
+		Description: "Report data integrity problems in tasks and projects",
+		Flags:       flag.NewFlagSet("doctor", flag.ExitOnError),
+	}
+
+	cmd.Flags.BoolVar(&fix, "fix", false, "Repair safe problems: clear dangling project_id, drop dangling related_tasks and blocked_by IDs")
+
+	cmd.Run = func(c *Command, args []string) error {
+		scanner := denote.NewScanner(cfg.NotesDirectory)
+		tasks, err := scanner.FindTasks()
+		if err != nil {
+			return fmt.Errorf("failed to scan directory: %v", err)
+		}
+		archived, err := scanner.FindArchivedTasks()
+		if err != nil {
+			return fmt.Errorf("failed to scan archive: %v", err)
+		}
+		projects, err := scanner.FindProjects()
+		if err != nil {
+			return fmt.Errorf("failed to scan directory: %v", err)
+		}
+
+		issues := diagnose(tasks, archived, projects, scanner.ParseErrors())
+		if fix {
+			applyDoctorFixes(issues)
+		}
+
+		var errs, warnings, fixed int
+		for _, is := range issues {
+			switch {
+			case is.Fixed:
+				fixed++
+			case is.Severity == severityError:
+				errs++
+			default:
+				warnings++
+			}
+		}
+
+		if globalFlags.JSON {
+			out := struct {
+				Issues   []doctorIssue `json:"issues"`
+				Errors   int           `json:"errors"`
+				Warnings int           `json:"warnings"`
+				Fixed    int           `json:"fixed"`
+			}{Issues: issues, Errors: errs, Warnings: warnings, Fixed: fixed}
+			if out.Issues == nil {
+				out.Issues = []doctorIssue{}
+			}
+			data, err := json.MarshalIndent(out, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else {
+			printDoctorIssues(issues)
+		}
+
+		if remaining := errs + warnings; remaining > 0 {
+			return fmt.Errorf("%d problem(s) found", remaining)
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// diagnose checks parsed tasks and projects for problems: files that failed
+// to parse, duplicate index_ids, invalid statuses and priorities, malformed
+// dates, and references to projects or tasks that do not exist. Archived
+// tasks are only checked as reference targets and for index_ids shared with
+// live tasks, so a link to an archived task is never reported or removed.
+// Issues are ordered errors first, then by file.
+func diagnose(tasks, archived []*denote.Task, projects []*denote.Project, parseErrors []denote.ParseError) []doctorIssue {
+	var issues []doctorIssue
+	add := func(is doctorIssue) {
+		is.Fixable = is.fix != nil
+		issues = append(issues, is)
+	}
+
+	for _, e := range parseErrors {
+		add(doctorIssue{Severity: severityError, Kind: "parse_error", File: filepath.Base(e.Path),
+			Message: fmt.Sprintf("file could not be parsed: %v", e.Err)})
+	}
+
+	projectRefs := make(map[string]bool)
+	projectFiles := make(map[int][]string)
+	for _, p := range projects {
+		projectRefs[strconv.Itoa(p.IndexID)] = true
+		projectRefs[p.ID] = true
+		projectFiles[p.IndexID] = append(projectFiles[p.IndexID], filepath.Base(p.FilePath))
+
+		file := filepath.Base(p.FilePath)
+		if p.ProjectMetadata.Status != "" && !denote.IsValidProjectStatus(p.ProjectMetadata.Status) {
+			add(doctorIssue{Severity: severityError, Kind: "invalid_status", File: file, IndexID: p.IndexID,
+				Message: fmt.Sprintf("invalid project status %q", p.ProjectMetadata.Status)})
+		}
+		if p.ProjectMetadata.Priority != "" && !denote.IsValidPriority(p.ProjectMetadata.Priority) {
+			add(doctorIssue{Severity: severityWarning, Kind: "invalid_priority", File: file, IndexID: p.IndexID,
+				Message: fmt.Sprintf("invalid priority %q", p.ProjectMetadata.Priority)})
+		}
+		for _, d := range []struct{ field, value string }{
+			{"due_date", p.ProjectMetadata.DueDate},
+			{"start_date", p.ProjectMetadata.StartDate},
+		} {
+			if !isDoctorDate(d.value) {
+				add(doctorIssue{Severity: severityError, Kind: "malformed_date", File: file, IndexID: p.IndexID,
+					Message: fmt.Sprintf("%s %q is not YYYY-MM-DD", d.field, d.value)})
+			}
+		}
+	}
+
+	taskIDs := make(map[string]bool)
+	taskFiles := make(map[int][]string)
+	for _, t := range tasks {
+		taskIDs[t.ID] = true
+		taskFiles[t.IndexID] = append(taskFiles[t.IndexID], filepath.Base(t.FilePath))
+	}
+	for _, t := range archived {
+		taskIDs[t.ID] = true
+		taskFiles[t.IndexID] = append(taskFiles[t.IndexID], filepath.Join("archive", filepath.Base(t.FilePath)))
+	}
+
+	for _, t := range tasks {
+		file := filepath.Base(t.FilePath)
+		if t.TaskMetadata.Status != "" && !denote.IsValidTaskStatus(t.TaskMetadata.Status) {
+			add(doctorIssue{Severity: severityError, Kind: "invalid_status", File: file, IndexID: t.IndexID,
+				Message: fmt.Sprintf("invalid task status %q", t.TaskMetadata.Status)})
+		}
+		if t.TaskMetadata.Priority != "" && !denote.IsValidPriority(t.TaskMetadata.Priority) {
+			add(doctorIssue{Severity: severityWarning, Kind: "invalid_priority", File: file, IndexID: t.IndexID,
+				Message: fmt.Sprintf("invalid priority %q", t.TaskMetadata.Priority)})
+		}
+		for _, d := range []struct{ field, value string }{
+			{"due_date", t.TaskMetadata.DueDate},
+			{"start_date", t.TaskMetadata.StartDate},
+			{"completed_date", t.TaskMetadata.CompletedDate},
+			{"planned_for", t.PlannedFor},
+		} {
+			if !isDoctorDate(d.value) {
+				add(doctorIssue{Severity: severityError, Kind: "malformed_date", File: file, IndexID: t.IndexID,
+					Message: fmt.Sprintf("%s %q is not YYYY-MM-DD", d.field, d.value)})
+			}
+		}
+
+		if pid := t.TaskMetadata.ProjectID; pid != "" && !projectRefs[pid] {
+			add(doctorIssue{Severity: severityWarning, Kind: "dangling_project", File: file, IndexID: t.IndexID,
+				Message: fmt.Sprintf("project_id %s does not match any project", pid),
+				task:    t, fix: func(t *denote.Task) { t.TaskMetadata.ProjectID = "" }})
+		}
+		for _, id := range t.RelatedTasks {
+			if !taskIDs[id] {
+				add(doctorIssue{Severity: severityWarning, Kind: "dangling_related_task", File: file, IndexID: t.IndexID,
+					Message: fmt.Sprintf("related task %s does not exist", id),
+					task:    t, fix: func(t *denote.Task) { t.RelatedTasks = removeString(t.RelatedTasks, id) }})
+			}
+		}
+		for _, id := range t.TaskMetadata.BlockedBy {
+			if !taskIDs[id] {
+				add(doctorIssue{Severity: severityWarning, Kind: "dangling_blocker", File: file, IndexID: t.IndexID,
+					Message: fmt.Sprintf("blocker %s does not exist", id),
+					task:    t, fix: func(t *denote.Task) { t.TaskMetadata.BlockedBy = removeString(t.TaskMetadata.BlockedBy, id) }})
+			}
+		}
+	}
+
+	for _, dup := range []struct {
+		kind  string
+		files map[int][]string
+	}{{"task", taskFiles}, {"project", projectFiles}} {
+		for indexID, files := range dup.files {
+			if len(files) < 2 {
+				continue
+			}
+			sort.Strings(files)
+			for _, f := range files {
+				add(doctorIssue{Severity: severityError, Kind: "duplicate_index_id", File: f, IndexID: indexID,
+					Message: fmt.Sprintf("%s index_id %d is used by %d files", dup.kind, indexID, len(files))})
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Severity != issues[j].Severity {
+			return issues[i].Severity == severityError
+		}
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		return issues[i].Kind < issues[j].Kind
+	})
+	return issues
+}
+
+// isDoctorDate reports whether a date field is empty or YYYY-MM-DD.
+func isDoctorDate(value string) bool {
+	if value == "" {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", value)
+	return err == nil
+}
+
+// removeString returns list without any occurrence of s.
+func removeString(list []string, s string) []string {
+	var kept []string
+	for _, v := range list {
+		if v != s {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// applyDoctorFixes runs every fixable issue's repair and writes each touched
+// task once, marking its issues fixed only if the write succeeds.
+func applyDoctorFixes(issues []doctorIssue) {
+	var order []*denote.Task
+	byTask := make(map[*denote.Task][]int)
+	for i, is := range issues {
+		if is.fix == nil {
+			continue
+		}
+		if _, seen := byTask[is.task]; !seen {
+			order = append(order, is.task)
+		}
+		is.fix(is.task)
+		byTask[is.task] = append(byTask[is.task], i)
+	}
+	for _, t := range order {
+		if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to fix task ID %d: %v\n", t.IndexID, err)
+			continue
+		}
+		for _, i := range byTask[t] {
+			issues[i].Fixed = true
+		}
+	}
+}
+
+// printDoctorIssues prints issues grouped by severity.
+func printDoctorIssues(issues []doctorIssue) {
+	if len(issues) == 0 {
+		fmt.Println("No problems found.")
+		return
+	}
+	for _, group := range []struct{ severity, title string }{
+		{severityError, "Errors"},
+		{severityWarning, "Warnings"},
+	} {
+		var shown []doctorIssue
+		for _, is := range issues {
+			if is.Severity == group.severity {
+				shown = append(shown, is)
+			}
+		}
+		if len(shown) == 0 {
+			continue
+		}
+		fmt.Printf("%s (%d):\n", group.title, len(shown))
+		for _, is := range shown {
+			line := fmt.Sprintf("  %-22s %s", is.Kind, is.File)
+			if is.IndexID > 0 {
+				line += fmt.Sprintf(" (#%d)", is.IndexID)
+			}
+			line += ": " + is.Message
+			switch {
+			case is.Fixed:
+				line += " [fixed]"
+			case is.Fixable:
+				line += " [fixable with --fix]"
+			}
+			fmt.Println(line)
+		}
+	}
+}
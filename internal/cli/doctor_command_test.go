@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"github.com/mph-llm-experiments/atask/internal/task"
+)
+
+func TestDoctorDetectsDanglingProjectAndDuplicateIndex(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	project, err := task.CreateProject(dir, "Kitchen", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	linked := newTestTask(t, dir, "Pick tiles", func(tk *denote.Task) {
+		tk.TaskMetadata.ProjectID = strconv.Itoa(project.IndexID)
+	})
+	orphan := newTestTask(t, dir, "Order sink", func(tk *denote.Task) {
+		tk.TaskMetadata.ProjectID = "999"
+	})
+	newTestTask(t, dir, "Call plumber", func(tk *denote.Task) {
+		tk.IndexID = linked.IndexID
+	})
+
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+
+	type report struct {
+		Issues []struct {
+			Severity string `json:"severity"`
+			Kind     string `json:"kind"`
+			IndexID  int    `json:"index_id"`
+			Fixable  bool   `json:"fixable"`
+			Fixed    bool   `json:"fixed"`
+		} `json:"issues"`
+		Errors   int `json:"errors"`
+		Warnings int `json:"warnings"`
+		Fixed    int `json:"fixed"`
+	}
+	run := func(args ...string) (report, error) {
+		var runErr error
+		out := captureStdout(t, func() {
+			runErr = DoctorCommand(cfg).Execute(args)
+		})
+		var r report
+		if err := json.Unmarshal([]byte(out), &r); err != nil {
+			t.Fatalf("doctor --json output is not JSON: %v\n%s", err, out)
+		}
+		return r, runErr
+	}
+
+	r, err := run()
+	if err == nil {
+		t.Error("doctor with problems succeeded, want error")
+	}
+	kinds := make(map[string]int)
+	for _, is := range r.Issues {
+		kinds[is.Kind]++
+		switch is.Kind {
+		case "duplicate_index_id":
+			if is.Severity != severityError || is.IndexID != linked.IndexID || is.Fixable {
+				t.Errorf("duplicate issue = %+v, want unfixable error on #%d", is, linked.IndexID)
+			}
+		case "dangling_project":
+			if is.Severity != severityWarning || is.IndexID != orphan.IndexID || !is.Fixable {
+				t.Errorf("dangling project issue = %+v, want fixable warning on #%d", is, orphan.IndexID)
+			}
+		}
+	}
+	if kinds["duplicate_index_id"] != 2 || kinds["dangling_project"] != 1 || len(r.Issues) != 3 {
+		t.Errorf("issues by kind = %v, want 2 duplicate_index_id and 1 dangling_project", kinds)
+	}
+
+	// --fix clears the dangling project_id and leaves the duplicate alone
+	r, _ = run("--fix")
+	if r.Fixed != 1 || r.Errors != 2 || r.Warnings != 0 {
+		t.Errorf("--fix counts = fixed %d, errors %d, warnings %d; want 1, 2, 0", r.Fixed, r.Errors, r.Warnings)
+	}
+	got, err := denote.ParseTaskFile(orphan.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TaskMetadata.ProjectID != "" {
+		t.Errorf("after --fix project_id = %q, want empty", got.TaskMetadata.ProjectID)
+	}
+	got, err = denote.ParseTaskFile(linked.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TaskMetadata.ProjectID != strconv.Itoa(project.IndexID) {
+		t.Errorf("--fix changed a valid project_id to %q", got.TaskMetadata.ProjectID)
+	}
+}
+
+func TestDoctorKeepsReferencesToArchivedTasks(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	blocker := newTestTask(t, dir, "Sign lease", nil)
+	if err := task.ArchiveTask(dir, blocker); err != nil {
+		t.Fatal(err)
+	}
+	waiting := newTestTask(t, dir, "Move furniture", func(tk *denote.Task) {
+		tk.TaskMetadata.BlockedBy = []string{blocker.ID, "01JGONE0000000000000000000"}
+		tk.RelatedTasks = []string{blocker.ID}
+	})
+
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+	captureStdout(t, func() {
+		// Only the missing blocker is a problem, and --fix removes it
+		if err := DoctorCommand(cfg).Execute([]string{"--fix"}); err != nil {
+			t.Errorf("doctor --fix error = %v, want the dangling blocker fixed", err)
+		}
+	})
+
+	got, err := denote.ParseTaskFile(waiting.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.TaskMetadata.BlockedBy) != 1 || got.TaskMetadata.BlockedBy[0] != blocker.ID {
+		t.Errorf("after --fix blocked_by = %v, want only the archived blocker %s", got.TaskMetadata.BlockedBy, blocker.ID)
+	}
+	if len(got.RelatedTasks) != 1 || got.RelatedTasks[0] != blocker.ID {
+		t.Errorf("after --fix related_tasks = %v, want the archived task kept", got.RelatedTasks)
+	}
+}
+
+func TestDiagnoseFindsDuplicateBetweenLiveAndArchived(t *testing.T) {
+	live := &denote.Task{}
+	live.ID, live.IndexID, live.FilePath = "01JLIVE0000000000000000000", 7, "/notes/live__task.md"
+	old := &denote.Task{}
+	old.ID, old.IndexID, old.FilePath = "01JOLD00000000000000000000", 7, "/notes/archive/old__task.md"
+
+	var files []string
+	for _, is := range diagnose([]*denote.Task{live}, []*denote.Task{old}, nil, nil) {
+		if is.Kind == "duplicate_index_id" {
+			files = append(files, is.File)
+		}
+	}
+	if len(files) != 2 || files[0] != "archive/old__task.md" || files[1] != "live__task.md" {
+		t.Errorf("duplicate index_id files = %v, want the archived and live copies", files)
+	}
+}
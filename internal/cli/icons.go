@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+// iconSet holds the status icons and priority marker shared by every text
+// renderer (list, query, project list, project tasks, project show).
+type iconSet struct {
+	open, inProgress, done, paused, delegated, dropped string
+	active, completed, cancelled                       string
+	blocked                                            string // prefixed to titles of blocked tasks
+	recurring                                          string // prefixed to titles of recurring tasks
+	arrow                                              string // before/after changes and project names
+	bullet                                             string // list items in change summaries
+	approx                                             string // before estimated durations
+	priority                                           string // template with {priority}
+}
+
+var unicodeIcons = iconSet{
+	open: "○", inProgress: "◐", done: "✓", paused: "⏸", delegated: "→", dropped: "⨯",
+	active: "◆", completed: "✓", cancelled: "⨯",
+	blocked:   "⛔",
+	recurring: "↻",
+	arrow:     "→",
+	bullet:    "•",
+	approx:    "≈",
+	priority:  "[{priority}]",
+}
+
+var asciiIcons = iconSet{
+	open: "o", inProgress: "~", done: "x", paused: "=", delegated: ">", dropped: "-",
+	active: "*", completed: "x", cancelled: "-",
+	blocked:   "!",
+	recurring: "@",
+	arrow:     "->",
+	bullet:    "*",
+	approx:    "~",
+	priority:  "[{priority}]",
+}
+
+// icons resolves the icon set for this invocation. --ascii forces the
+// plain ASCII set; otherwise [icons] in the config picks the base set and
+// overrides individual icons.
+func icons(cfg *config.Config) iconSet {
+	if globalFlags.ASCII {
+		return asciiIcons
+	}
+
+	set := unicodeIcons
+	c := cfg.Icons
+	if c.ASCII {
+		set = asciiIcons
+	}
+	override := func(icon *string, value string) {
+		if value != "" {
+			*icon = value
+		}
+	}
+	override(&set.open, c.Open)
+	override(&set.inProgress, c.InProgress)
+	override(&set.done, c.Done)
+	override(&set.paused, c.Paused)
+	override(&set.delegated, c.Delegated)
+	override(&set.dropped, c.Dropped)
+	override(&set.active, c.Active)
+	override(&set.completed, c.Completed)
+	override(&set.cancelled, c.Cancelled)
+	override(&set.blocked, c.Blocked)
+	override(&set.priority, c.Priority)
+	return set
+}
+
+// taskStatus returns the icon for a task status.
+func (s iconSet) taskStatus(status string) string {
+	switch status {
+	case denote.TaskStatusInProgress:
+		return s.inProgress
+	case denote.TaskStatusDone:
+		return s.done
+	case denote.TaskStatusPaused:
+		return s.paused
+	case denote.TaskStatusDelegated:
+		return s.delegated
+	case denote.TaskStatusDropped:
+		return s.dropped
+	}
+	return s.open
+}
+
+// projectStatus returns the icon for a project status.
+func (s iconSet) projectStatus(status string) string {
+	switch status {
+	case denote.ProjectStatusCompleted:
+		return s.completed
+	case denote.ProjectStatusPaused:
+		return s.paused
+	case denote.ProjectStatusCancelled:
+		return s.cancelled
+	}
+	return s.active
+}
+
+// priorityMarker renders a priority such as "[p1]". An empty priority is
+// padded to the marker's width so columns stay aligned.
+func (s iconSet) priorityMarker(priority string) string {
+	if priority == "" {
+		return strings.Repeat(" ", runewidth.StringWidth(s.priorityMarker(denote.PriorityP1)))
+	}
+	return strings.ReplaceAll(s.priority, "{priority}", priority)
+}
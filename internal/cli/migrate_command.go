@@ -24,6 +24,7 @@ func MigrateCommand(cfg *config.Config) *Command {
 	cmd.Subcommands = []*Command{
 		migrateAcoreCommand(cfg),
 		migrateProjectIDCommand(cfg),
+		migrateStatusCommand(cfg),
 	}
 
 	return cmd
@@ -239,3 +240,52 @@ func migrateProjectIDCommand(cfg *config.Config) *Command {
 
 	return cmd
 }
+
+// migrateStatusCommand reports task and project files still in legacy Denote format
+func migrateStatusCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "status",
+		Usage:       "atask migrate status",
+		Description: "Count and list files still in legacy Denote format",
+		Run: func(cmd *Command, args []string) error {
+			scanner := denote.NewScanner(cfg.NotesDirectory)
+			legacy, err := scanner.FindLegacyFiles()
+			if err != nil {
+				return fmt.Errorf("failed to scan for legacy files: %w", err)
+			}
+
+			if globalFlags.JSON {
+				if legacy == nil {
+					legacy = []denote.LegacyFile{}
+				}
+				unparseable := []string{}
+				for _, e := range scanner.ParseErrors() {
+					unparseable = append(unparseable, e.Path)
+				}
+				result := map[string]interface{}{
+					"legacy_count": len(legacy),
+					"files":        legacy,
+					"unparseable":  unparseable,
+				}
+				data, _ := json.MarshalIndent(result, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+
+			warnParseErrors(scanner)
+			if len(legacy) == 0 {
+				fmt.Println("No legacy files found. Nothing to migrate.")
+				return nil
+			}
+
+			fmt.Printf("%d legacy file(s) still in Denote format:\n", len(legacy))
+			if !globalFlags.Quiet {
+				for _, f := range legacy {
+					fmt.Printf("  %s  %-7s  %s\n", f.DenoteID, f.Type, f.Title)
+				}
+			}
+			fmt.Println("Run 'atask migrate acore' to convert them.")
+			return nil
+		},
+	}
+}
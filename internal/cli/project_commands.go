@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/mph-llm-experiments/acore"
@@ -30,7 +31,9 @@ func ProjectCommand(cfg *config.Config) *Command {
 		projectShowCommand(cfg),
 		projectTasksCommand(cfg),
 		projectUpdateCommand(cfg),
+		projectMoveTasksCommand(cfg),
 		projectLogCommand(cfg),
+		projectStatsCommand(cfg),
 	}
 
 	return cmd
@@ -46,10 +49,16 @@ func lookupProject(dir string, identifier string) (*denote.Project, error) {
 
 // projectShowCommand shows details for a single project
 func projectShowCommand(cfg *config.Config) *Command {
-	return &Command{
+	var (
+		withTasks bool
+		all       bool
+	)
+
+	cmd := &Command{
 		Name:        "show",
-		Usage:       "atask project show <id>",
+		Usage:       "atask project show <id> [--with-tasks [--all]]",
 		Description: "Show project details by index_id or ULID",
+		Flags:       flag.NewFlagSet("project-show", flag.ExitOnError),
 		Run: func(cmd *Command, args []string) error {
 			if len(args) == 0 {
 				return fmt.Errorf("usage: atask project show <id>")
@@ -60,12 +69,49 @@ func projectShowCommand(cfg *config.Config) *Command {
 				return err
 			}
 
+			allTasks, err := denote.NewScanner(cfg.NotesDirectory).FindTasks()
+			if err != nil {
+				return fmt.Errorf("failed to find tasks: %v", err)
+			}
+
+			// Progress counts every task except dropped ones, whether or
+			// not --with-tasks lists them
+			var projectTasks []*denote.Task
+			taskCount, doneCount := 0, 0
+			projectIDStr := strconv.Itoa(p.IndexID)
+			for _, t := range allTasks {
+				if t.TaskMetadata.ProjectID != projectIDStr {
+					continue
+				}
+				if t.TaskMetadata.Status != denote.TaskStatusDropped {
+					taskCount++
+					if t.TaskMetadata.Status == denote.TaskStatusDone {
+						doneCount++
+					}
+				}
+				if withTasks && (all || denote.IsOpenTaskStatus(t.TaskMetadata.Status)) {
+					projectTasks = append(projectTasks, t)
+				}
+			}
+			if withTasks {
+				sortProjectTasks(projectTasks, "priority", false)
+				if projectTasks == nil {
+					projectTasks = []*denote.Task{}
+				}
+			}
+
 			if globalFlags.JSON {
 				type jsonProject struct {
 					*denote.Project
-					Content string `json:"content,omitempty"`
+					Content   string          `json:"content,omitempty"`
+					TaskCount int             `json:"task_count"`
+					DoneCount int             `json:"done_count"`
+					Tasks     *[]*denote.Task `json:"tasks,omitempty"`
+				}
+				jp := jsonProject{Project: p, Content: p.Content, TaskCount: taskCount, DoneCount: doneCount}
+				if withTasks {
+					jp.Tasks = &projectTasks
 				}
-				jp := jsonProject{Project: p, Content: p.Content}
 				data, err := json.MarshalIndent(jp, "", "  ")
 				if err != nil {
 					return fmt.Errorf("failed to marshal JSON: %w", err)
@@ -95,6 +141,7 @@ func projectShowCommand(cfg *config.Config) *Command {
 			if p.ProjectMetadata.Area != "" {
 				fmt.Printf("  Area:     %s\n", p.ProjectMetadata.Area)
 			}
+			fmt.Printf("  Progress: %s\n", formatProgress(doneCount, taskCount))
 			fmt.Println()
 
 			if p.Created != "" {
@@ -127,6 +174,14 @@ func projectShowCommand(cfg *config.Config) *Command {
 				}
 			}
 
+			if withTasks {
+				fmt.Printf("\n  Tasks (%d):\n", len(projectTasks))
+				ic := icons(cfg)
+				for _, t := range projectTasks {
+					fmt.Printf("  %3d %s %s %s\n", t.IndexID, ic.priorityMarker(t.TaskMetadata.Priority), t.TaskMetadata.Status, t.Title)
+				}
+			}
+
 			if strings.TrimSpace(p.Content) != "" {
 				fmt.Printf("\n---\n%s", p.Content)
 			}
@@ -134,6 +189,39 @@ func projectShowCommand(cfg *config.Config) *Command {
 			return nil
 		},
 	}
+
+	cmd.Flags.BoolVar(&withTasks, "with-tasks", false, "Include the project's tasks (open only unless --all)")
+	cmd.Flags.BoolVar(&all, "all", false, "With --with-tasks, include tasks of every status")
+
+	return cmd
+}
+
+// cascadeTaskStatus maps a closing project status to the status its
+// unfinished tasks should take, or "" if the status doesn't close a project.
+func cascadeTaskStatus(projectStatus string) string {
+	switch projectStatus {
+	case denote.ProjectStatusCompleted:
+		return denote.TaskStatusDone
+	case denote.ProjectStatusCancelled:
+		return denote.TaskStatusDropped
+	}
+	return ""
+}
+
+// unfinishedProjectTasks returns the project's tasks that are not yet done or dropped
+func unfinishedProjectTasks(tasks []*denote.Task, p *denote.Project) []*denote.Task {
+	projectIDStr := strconv.Itoa(p.IndexID)
+	var unfinished []*denote.Task
+	for _, t := range tasks {
+		if t.TaskMetadata.ProjectID != projectIDStr {
+			continue
+		}
+		if t.TaskMetadata.Status == denote.TaskStatusDone || t.TaskMetadata.Status == denote.TaskStatusDropped {
+			continue
+		}
+		unfinished = append(unfinished, t)
+	}
+	return unfinished
 }
 
 // projectNewCommand creates a new project
@@ -144,11 +232,12 @@ func projectNewCommand(cfg *config.Config) *Command {
 		area      string
 		startDate string
 		tags      string
+		template  string
 	)
 
 	cmd := &Command{
 		Name:        "new",
-		Usage:       "atask project new <title> [options]",
+		Usage:       "atask project new <title> [options] [--template name]",
 		Description: "Create a new project",
 		Flags:       flag.NewFlagSet("project-new", flag.ExitOnError),
 	}
@@ -159,6 +248,7 @@ func projectNewCommand(cfg *config.Config) *Command {
 	cmd.Flags.StringVar(&startDate, "start", "", "Start date (YYYY-MM-DD or natural language)")
 	cmd.Flags.StringVar(&area, "area", "", "Project area")
 	cmd.Flags.StringVar(&tags, "tags", "", "Comma-separated tags")
+	cmd.Flags.StringVar(&template, "template", "", "Create starter tasks from a template in ~/.config/atask/templates")
 
 	cmd.Run = func(c *Command, args []string) error {
 		if len(args) == 0 {
@@ -167,6 +257,16 @@ func projectNewCommand(cfg *config.Config) *Command {
 
 		title := strings.Join(args, " ")
 
+		// Load the template first so a missing or invalid one creates nothing
+		var tmpl *task.ProjectTemplate
+		if template != "" {
+			var err error
+			tmpl, err = task.LoadProjectTemplate(config.TemplatesDir(), template)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Parse tags
 		var tagList []string
 		if tags != "" {
@@ -220,6 +320,18 @@ func projectNewCommand(cfg *config.Config) *Command {
 			fmt.Printf("Created project: %s (ID: %d)\n", projectFile.FilePath, projectFile.IndexID)
 		}
 
+		if tmpl != nil {
+			created, err := task.CreateTemplateTasks(cfg.NotesDirectory, tmpl, projectFile, time.Now())
+			if err != nil {
+				return fmt.Errorf("template %s: %v", tmpl.Name, err)
+			}
+			if !globalFlags.Quiet {
+				for _, t := range created {
+					fmt.Printf("  Created task: %s (ID: %d)\n", t.Title, t.IndexID)
+				}
+			}
+		}
+
 		// Launch TUI if requested
 		if globalFlags.TUI {
 			// TODO: Launch TUI in project view for this project
@@ -278,6 +390,7 @@ func projectListCommand(cfg *config.Config) *Command {
 		if err != nil {
 			return fmt.Errorf("failed to scan directory: %v", err)
 		}
+		warnParseErrors(scanner)
 
 		// Apply filters
 		var filtered []*denote.Project
@@ -317,12 +430,18 @@ func projectListCommand(cfg *config.Config) *Command {
 		// Sort projects
 		sortProjects(filtered, sortBy, reverse)
 
-		// Count tasks per project (needed for both JSON and text output)
+		// Count tasks per project (needed for both JSON and text output).
+		// Dropped tasks count toward neither total.
 		tasks, _ := scanner.FindTasks()
 		taskCounts := make(map[string]int)
+		doneCounts := make(map[string]int)
 		for _, t := range tasks {
-			if t.TaskMetadata.ProjectID != "" {
-				taskCounts[t.TaskMetadata.ProjectID]++
+			if t.TaskMetadata.ProjectID == "" || t.TaskMetadata.Status == denote.TaskStatusDropped {
+				continue
+			}
+			taskCounts[t.TaskMetadata.ProjectID]++
+			if t.TaskMetadata.Status == denote.TaskStatusDone {
+				doneCounts[t.TaskMetadata.ProjectID]++
 			}
 		}
 
@@ -332,11 +451,13 @@ func projectListCommand(cfg *config.Config) *Command {
 			type ProjectJSON struct {
 				denote.Project
 				TaskCount int `json:"task_count"`
+				DoneCount int `json:"done_count"`
 			}
 
 			type Output struct {
-				Projects []ProjectJSON `json:"projects"`
-				Count    int           `json:"count"`
+				SchemaVersion int           `json:"schema_version"`
+				Projects      []ProjectJSON `json:"projects"`
+				Count         int           `json:"count"`
 			}
 
 			// Build JSON output with task counts
@@ -345,12 +466,14 @@ func projectListCommand(cfg *config.Config) *Command {
 				jsonProjects[i] = ProjectJSON{
 					Project:   *p,
 					TaskCount: taskCounts[strconv.Itoa(p.IndexID)],
+					DoneCount: doneCounts[strconv.Itoa(p.IndexID)],
 				}
 			}
 
 			output := Output{
-				Projects: jsonProjects,
-				Count:    len(filtered),
+				SchemaVersion: jsonSchemaVersion,
+				Projects:      jsonProjects,
+				Count:         len(filtered),
 			}
 
 			// Marshal and print
@@ -380,22 +503,14 @@ func projectListCommand(cfg *config.Config) *Command {
 		}
 
 		// Display projects
+		ic := icons(cfg)
 		for _, p := range filtered {
-			// Status icon
-			status := "◆"
-			switch p.ProjectMetadata.Status {
-			case denote.ProjectStatusCompleted:
-				status = "✓"
-			case denote.ProjectStatusPaused:
-				status = "⏸"
-			case denote.ProjectStatusCancelled:
-				status = "⨯"
-			}
+			status := ic.projectStatus(p.ProjectMetadata.Status)
 
-			// Priority with padding
-			priority := "    " // 4 spaces for alignment
+			// Priority, padded for alignment when unset
+			priority := ic.priorityMarker("")
 			if p.ProjectMetadata.Priority != "" {
-				pStr := fmt.Sprintf("[%s]", p.ProjectMetadata.Priority)
+				pStr := ic.priorityMarker(p.ProjectMetadata.Priority)
 				switch p.ProjectMetadata.Priority {
 				case "p1":
 					priority = priorityHighColor.Sprint(pStr)
@@ -417,27 +532,16 @@ func projectListCommand(cfg *config.Config) *Command {
 				}
 			}
 
-			// Title - truncate to 40 chars
-			title := p.Title
-			if len(title) > 40 {
-				title = title[:37] + "..."
-			}
-
-			// Area - truncate to 10 chars
-			area := ""
-			if p.ProjectMetadata.Area != "" {
-				area = p.ProjectMetadata.Area
-				if len(area) > 10 {
-					area = area[:7] + "..."
-				}
-			}
+			// Title and area, truncated and padded to their columns
+			title := padColumn(p.Title, 40)
+			area := padColumn(p.ProjectMetadata.Area, 10)
 
 			// Task count
-			taskCount := taskCounts[strconv.Itoa(p.IndexID)]
-			taskStr := fmt.Sprintf("(%d tasks)", taskCount)
+			idStr := strconv.Itoa(p.IndexID)
+			taskStr := fmt.Sprintf("(%d/%d done)", doneCounts[idStr], taskCounts[idStr])
 
 			// Build the line with fixed-width columns
-			line := fmt.Sprintf("%3d %s %s %s  %-40s %-10s %s",
+			line := fmt.Sprintf("%3d %s %s %s  %s %s %s",
 				p.IndexID,
 				status,
 				priority,
@@ -523,7 +627,7 @@ func projectTasksCommand(cfg *config.Config) *Command {
 		for _, t := range allTasks {
 			if t.TaskMetadata.ProjectID == projectIDStr {
 				// Apply status filter
-				if !all && status == "" && t.TaskMetadata.Status != denote.TaskStatusOpen {
+				if !all && status == "" && !denote.IsOpenTaskStatus(t.TaskMetadata.Status) {
 					continue
 				}
 				if status != "" && t.TaskMetadata.Status != status {
@@ -539,15 +643,17 @@ func projectTasksCommand(cfg *config.Config) *Command {
 		// JSON output
 		if globalFlags.JSON {
 			type Output struct {
-				Project *denote.Project `json:"project"`
-				Tasks   []*denote.Task  `json:"tasks"`
-				Count   int             `json:"task_count"`
+				SchemaVersion int             `json:"schema_version"`
+				Project       *denote.Project `json:"project"`
+				Tasks         []*denote.Task  `json:"tasks"`
+				Count         int             `json:"task_count"`
 			}
 
 			output := Output{
-				Project: targetProject,
-				Tasks:   projectTasks,
-				Count:   len(projectTasks),
+				SchemaVersion: jsonSchemaVersion,
+				Project:       targetProject,
+				Tasks:         projectTasks,
+				Count:         len(projectTasks),
 			}
 
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
@@ -570,6 +676,13 @@ func projectTasksCommand(cfg *config.Config) *Command {
 			}
 			fmt.Println()
 		}
+		totalEstimate := 0
+		for _, t := range projectTasks {
+			totalEstimate += t.TaskMetadata.Estimate
+		}
+		if totalEstimate > 0 {
+			fmt.Printf("Estimate: %s\n", formatEstimate(cfg, totalEstimate))
+		}
 		fmt.Printf("\nTasks (%d):\n\n", len(projectTasks))
 
 		// Display tasks
@@ -588,24 +701,14 @@ func projectTasksCommand(cfg *config.Config) *Command {
 		priorityHighColor := color.New(color.FgRed, color.Bold)
 		priorityMedColor := color.New(color.FgYellow)
 
+		ic := icons(cfg)
 		for _, t := range projectTasks {
-			// Status icon
-			statusIcon := "○"
-			switch t.TaskMetadata.Status {
-			case denote.TaskStatusDone:
-				statusIcon = "✓"
-			case denote.TaskStatusPaused:
-				statusIcon = "⏸"
-			case denote.TaskStatusDelegated:
-				statusIcon = "→"
-			case denote.TaskStatusDropped:
-				statusIcon = "⨯"
-			}
+			statusIcon := ic.taskStatus(t.TaskMetadata.Status)
 
 			// Priority
-			priority := "    "
+			priority := ic.priorityMarker("")
 			if t.TaskMetadata.Priority != "" {
-				pStr := fmt.Sprintf("[%s]", t.TaskMetadata.Priority)
+				pStr := ic.priorityMarker(t.TaskMetadata.Priority)
 				switch t.TaskMetadata.Priority {
 				case "p1":
 					priority = priorityHighColor.Sprint(pStr)
@@ -628,10 +731,7 @@ func projectTasksCommand(cfg *config.Config) *Command {
 			}
 
 			// Title
-			title := t.Title
-			if len(title) > 60 {
-				title = title[:57] + "..."
-			}
+			title := truncateColumn(t.Title, 60)
 
 			// Build line
 			line := fmt.Sprintf("%3d %s %s %s  %s",
@@ -671,6 +771,9 @@ func projectUpdateCommand(cfg *config.Config) *Command {
 		removeTask   string
 		addIdea      string
 		removeIdea   string
+		cascade      bool
+		dryRun       bool
+		ignoreErrors bool
 	)
 
 	cmd := &Command{
@@ -687,6 +790,9 @@ func projectUpdateCommand(cfg *config.Config) *Command {
 	cmd.Flags.StringVar(&startDate, "start", "", "Set start date")
 	cmd.Flags.StringVar(&area, "area", "", "Set area")
 	cmd.Flags.StringVar(&status, "status", "", "Set status (active, completed, paused, cancelled)")
+	cmd.Flags.BoolVar(&cascade, "cascade-status", false, "With --status completed/cancelled, mark the project's unfinished tasks done/dropped")
+	cmd.Flags.BoolVar(&dryRun, "dry-run", false, "Show what would change, including cascaded tasks, without writing")
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
 
 	// Cross-app relationship flags
 	cmd.Flags.StringVar(&addPerson, "add-person", "", "Add related contact (ULID)")
@@ -720,12 +826,26 @@ func projectUpdateCommand(cfg *config.Config) *Command {
 			projectsByID[p.IndexID] = p
 		}
 
+		// Closing a project may leave unfinished tasks behind; load tasks
+		// once so they can be reported or cascaded
+		cascadeTo := cascadeTaskStatus(status)
+		var allTasks []*denote.Task
+		if cascadeTo != "" {
+			allTasks, err = scanner.FindTasks()
+			if err != nil {
+				return fmt.Errorf("failed to find tasks: %v", err)
+			}
+		}
+
 		// Update each project
 		updated := 0
+		failed := 0
+		requested := len(numbers)
 		for _, id := range numbers {
 			p, ok := projectsByID[id]
 			if !ok {
 				fmt.Fprintf(os.Stderr, "Project with ID %d not found\n", id)
+				failed++
 				continue
 			}
 
@@ -743,6 +863,7 @@ func projectUpdateCommand(cfg *config.Config) *Command {
 				parsedDue, err := denote.ParseNaturalDate(due)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Invalid due date for project ID %d: %v\n", id, err)
+					failed++
 					continue
 				}
 				p.ProjectMetadata.DueDate = parsedDue
@@ -752,6 +873,7 @@ func projectUpdateCommand(cfg *config.Config) *Command {
 				parsedStart, err := denote.ParseNaturalDate(startDate)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Invalid start date for project ID %d: %v\n", id, err)
+					failed++
 					continue
 				}
 				p.ProjectMetadata.StartDate = parsedStart
@@ -764,47 +886,64 @@ func projectUpdateCommand(cfg *config.Config) *Command {
 			if status != "" {
 				if !denote.IsValidProjectStatus(status) {
 					fmt.Fprintf(os.Stderr, "Invalid status for project ID %d: %s\n", id, status)
+					failed++
 					continue
 				}
 				p.ProjectMetadata.Status = status
 				changed = true
 			}
 
-			// Apply cross-app relationship updates
+			// Apply cross-app relationship updates. Syncing writes the other
+			// app's file, so a dry run only changes the in-memory project.
 			if addPerson != "" {
 				acore.AddRelation(&p.RelatedPeople, addPerson)
-				acore.SyncRelation(p.Type, p.ID, addPerson)
+				if !dryRun {
+					acore.SyncRelation(p.Type, p.ID, addPerson)
+				}
 				changed = true
 			}
 			if removePerson != "" {
 				acore.RemoveRelation(&p.RelatedPeople, removePerson)
-				acore.UnsyncRelation(p.Type, p.ID, removePerson)
+				if !dryRun {
+					acore.UnsyncRelation(p.Type, p.ID, removePerson)
+				}
 				changed = true
 			}
 			if addTask != "" {
 				acore.AddRelation(&p.RelatedTasks, addTask)
-				acore.SyncRelation(p.Type, p.ID, addTask)
+				if !dryRun {
+					acore.SyncRelation(p.Type, p.ID, addTask)
+				}
 				changed = true
 			}
 			if removeTask != "" {
 				acore.RemoveRelation(&p.RelatedTasks, removeTask)
-				acore.UnsyncRelation(p.Type, p.ID, removeTask)
+				if !dryRun {
+					acore.UnsyncRelation(p.Type, p.ID, removeTask)
+				}
 				changed = true
 			}
 			if addIdea != "" {
 				acore.AddRelation(&p.RelatedIdeas, addIdea)
-				acore.SyncRelation(p.Type, p.ID, addIdea)
+				if !dryRun {
+					acore.SyncRelation(p.Type, p.ID, addIdea)
+				}
 				changed = true
 			}
 			if removeIdea != "" {
 				acore.RemoveRelation(&p.RelatedIdeas, removeIdea)
-				acore.UnsyncRelation(p.Type, p.ID, removeIdea)
+				if !dryRun {
+					acore.UnsyncRelation(p.Type, p.ID, removeIdea)
+				}
 				changed = true
 			}
 
-			if changed {
+			if changed && dryRun {
+				fmt.Printf("Would update project ID %d: %s\n", id, p.Title)
+			} else if changed {
 				if err := denote.UpdateProjectFile(p.FilePath, p); err != nil {
 					fmt.Fprintf(os.Stderr, "Failed to update project ID %d: %v\n", id, err)
+					failed++
 					continue
 				}
 				updated++
@@ -812,12 +951,272 @@ func projectUpdateCommand(cfg *config.Config) *Command {
 					fmt.Printf("Updated project ID %d: %s\n", id, p.Title)
 				}
 			}
+
+			if cascadeTo != "" {
+				unfinished := unfinishedProjectTasks(allTasks, p)
+				switch {
+				case len(unfinished) == 0:
+				case !cascade:
+					fmt.Fprintf(os.Stderr, "Warning: project ID %d still has %d unfinished task(s); use --cascade-status to mark them %s\n",
+						id, len(unfinished), cascadeTo)
+				default:
+					for _, t := range unfinished {
+						if dryRun {
+							fmt.Printf("  Would mark task ID %d %s: %s\n", t.IndexID, cascadeTo, t.Title)
+							continue
+						}
+						// Cascaded tasks count toward the batch like requested IDs
+						requested++
+						t.SetStatus(cascadeTo)
+						if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+							fmt.Fprintf(os.Stderr, "Failed to update task ID %d: %v\n", t.IndexID, err)
+							failed++
+							continue
+						}
+						if !globalFlags.Quiet {
+							fmt.Printf("  Marked task ID %d %s: %s\n", t.IndexID, cascadeTo, t.Title)
+						}
+					}
+				}
+			}
 		}
 
-		if updated == 0 && !globalFlags.Quiet {
+		if updated == 0 && !dryRun && !globalFlags.Quiet {
 			fmt.Println("No projects updated")
 		}
 
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+// projectMoveTasksCommand reassigns every task of one project to another
+func projectMoveTasksCommand(cfg *config.Config) *Command {
+	var (
+		from   int
+		to     int
+		status string
+		dryRun bool
+	)
+
+	cmd := &Command{
+		Name:        "move-tasks",
+		Usage:       "atask project move-tasks --from <id> --to <id> [--status status] [--dry-run]",
+		Description: "Move all tasks of one project to another",
+		Flags:       flag.NewFlagSet("project-move-tasks", flag.ExitOnError),
+	}
+
+	cmd.Flags.IntVar(&from, "from", 0, "Source project index_id")
+	cmd.Flags.IntVar(&to, "to", 0, "Destination project index_id")
+	cmd.Flags.StringVar(&status, "status", "", "Only move tasks with this status")
+	cmd.Flags.BoolVar(&dryRun, "dry-run", false, "Show which tasks would move without writing")
+
+	cmd.Run = func(c *Command, args []string) error {
+		if from == 0 || to == 0 {
+			return fmt.Errorf("usage: atask project move-tasks --from <id> --to <id>")
+		}
+		if from == to {
+			return fmt.Errorf("--from and --to are the same project")
+		}
+		if status != "" && !denote.IsValidTaskStatus(status) {
+			return fmt.Errorf("invalid status: %s", status)
+		}
+
+		source, err := task.FindProjectByID(cfg.NotesDirectory, from)
+		if err != nil {
+			return fmt.Errorf("project with ID %d not found", from)
+		}
+		dest, err := task.FindProjectByID(cfg.NotesDirectory, to)
+		if err != nil {
+			return fmt.Errorf("project with ID %d not found", to)
+		}
+
+		allTasks, err := denote.NewScanner(cfg.NotesDirectory).FindTasks()
+		if err != nil {
+			return fmt.Errorf("failed to find tasks: %v", err)
+		}
+
+		sourceID, destID := strconv.Itoa(source.IndexID), strconv.Itoa(dest.IndexID)
+		var matched []*denote.Task
+		for _, t := range allTasks {
+			if t.TaskMetadata.ProjectID != sourceID {
+				continue
+			}
+			if status != "" && t.TaskMetadata.Status != status {
+				continue
+			}
+			matched = append(matched, t)
+		}
+
+		var moved []*denote.Task
+		failed := 0
+		for _, t := range matched {
+			if dryRun {
+				if !globalFlags.JSON && !globalFlags.Quiet {
+					fmt.Printf("Would move task ID %d: %s\n", t.IndexID, t.Title)
+				}
+				moved = append(moved, t)
+				continue
+			}
+			t.TaskMetadata.ProjectID = destID
+			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to move task ID %d: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+			moved = append(moved, t)
+			if !globalFlags.JSON && !globalFlags.Quiet {
+				fmt.Printf("Moved task ID %d: %s\n", t.IndexID, t.Title)
+			}
+		}
+
+		if globalFlags.JSON {
+			if moved == nil {
+				moved = []*denote.Task{}
+			}
+			output := struct {
+				From   int            `json:"from"`
+				To     int            `json:"to"`
+				DryRun bool           `json:"dry_run"`
+				Count  int            `json:"count"`
+				Tasks  []*denote.Task `json:"tasks"`
+			}{From: source.IndexID, To: dest.IndexID, DryRun: dryRun, Count: len(moved), Tasks: moved}
+			data, err := json.MarshalIndent(output, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else if !globalFlags.Quiet {
+			verb := "Moved"
+			if dryRun {
+				verb = "Would move"
+			}
+			fmt.Printf("%s %d tasks from %s (#%d) to %s (#%d)\n", verb, len(moved), source.Title, source.IndexID, dest.Title, dest.IndexID)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d tasks failed to move", failed, len(matched))
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+// projectStatsCommand summarizes all projects for a portfolio overview
+func projectStatsCommand(cfg *config.Config) *Command {
+	var area string
+
+	cmd := &Command{
+		Name:        "stats",
+		Usage:       "atask project stats [--area area]",
+		Description: "Summarize projects by status, open tasks, and overdue projects",
+		Flags:       flag.NewFlagSet("project-stats", flag.ExitOnError),
+	}
+
+	cmd.Flags.StringVar(&area, "area", "", "Only count projects in this area")
+
+	cmd.Run = func(c *Command, args []string) error {
+		if area == "" {
+			area = globalFlags.Area
+		}
+
+		scanner := denote.NewScanner(cfg.NotesDirectory)
+		projects, err := scanner.FindProjects()
+		if err != nil {
+			return fmt.Errorf("failed to scan directory: %v", err)
+		}
+
+		type overdueProject struct {
+			IndexID int    `json:"index_id"`
+			Title   string `json:"title"`
+			DueDate string `json:"due_date"`
+		}
+		stats := struct {
+			SchemaVersion   int              `json:"schema_version"`
+			Total           int              `json:"total"`
+			ByStatus        map[string]int   `json:"by_status"`
+			OpenTasks       int              `json:"open_tasks"`
+			OverdueProjects []overdueProject `json:"overdue_projects"`
+		}{
+			SchemaVersion: jsonSchemaVersion,
+			ByStatus: map[string]int{
+				denote.ProjectStatusActive:    0,
+				denote.ProjectStatusCompleted: 0,
+				denote.ProjectStatusPaused:    0,
+				denote.ProjectStatusCancelled: 0,
+			},
+			OverdueProjects: []overdueProject{},
+		}
+
+		activeIDs := make(map[string]bool)
+		for _, p := range projects {
+			if area != "" && p.ProjectMetadata.Area != area {
+				continue
+			}
+			stats.Total++
+			stats.ByStatus[p.ProjectMetadata.Status]++
+
+			if p.ProjectMetadata.Status != denote.ProjectStatusActive {
+				continue
+			}
+			activeIDs[strconv.Itoa(p.IndexID)] = true
+			if denote.IsOverdue(p.ProjectMetadata.DueDate) {
+				stats.OverdueProjects = append(stats.OverdueProjects, overdueProject{
+					IndexID: p.IndexID,
+					Title:   p.Title,
+					DueDate: p.ProjectMetadata.DueDate,
+				})
+			}
+		}
+		sort.Slice(stats.OverdueProjects, func(i, j int) bool {
+			return stats.OverdueProjects[i].DueDate < stats.OverdueProjects[j].DueDate
+		})
+
+		// Open tasks across active projects, same scan as project list counts
+		tasks, err := scanner.FindTasks()
+		if err != nil {
+			return fmt.Errorf("failed to scan directory: %v", err)
+		}
+		warnParseErrors(scanner)
+		for _, t := range tasks {
+			if denote.IsOpenTaskStatus(t.TaskMetadata.Status) && activeIDs[t.TaskMetadata.ProjectID] {
+				stats.OpenTasks++
+			}
+		}
+
+		if globalFlags.JSON {
+			data, err := json.MarshalIndent(stats, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		fmt.Printf("Projects (%d):\n\n", stats.Total)
+		for _, status := range []string{
+			denote.ProjectStatusActive,
+			denote.ProjectStatusCompleted,
+			denote.ProjectStatusPaused,
+			denote.ProjectStatusCancelled,
+		} {
+			fmt.Printf("  %-10s %3d\n", status, stats.ByStatus[status])
+		}
+		fmt.Printf("\n  Open tasks in active projects: %d\n", stats.OpenTasks)
+
+		if len(stats.OverdueProjects) > 0 {
+			overdueColor := color.New(color.FgRed, color.Bold)
+			if globalFlags.NoColor || color.NoColor {
+				color.NoColor = true
+			}
+			fmt.Printf("\nOverdue projects (%d):\n", len(stats.OverdueProjects))
+			for _, p := range stats.OverdueProjects {
+				fmt.Printf("  %3d %s  %s\n", p.IndexID, overdueColor.Sprintf("[%s]", p.DueDate), p.Title)
+			}
+		}
+
 		return nil
 	}
 
@@ -827,28 +1226,41 @@ func projectUpdateCommand(cfg *config.Config) *Command {
 // projectLogCommand adds or deletes a timestamped log entry on a project
 func projectLogCommand(cfg *config.Config) *Command {
 	var deleteLine string
+	var deleteIndex int
+	var logDate string
 
 	cmd := &Command{
 		Name:        "log",
-		Usage:       "atask project log <project-id> [message] [--delete <line>]",
+		Usage:       "atask project log <project-id> [message] [--date <date>] [--delete <line>] [--delete-index <n>]",
 		Description: "Add or delete a timestamped log entry on a project",
 		Flags:       flag.NewFlagSet("project-log", flag.ExitOnError),
 	}
 
 	cmd.Flags.StringVar(&deleteLine, "delete", "", "Delete a log entry matching this exact line")
+	cmd.Flags.IntVar(&deleteIndex, "delete-index", 0, "Delete the Nth log entry (1-based, newest first)")
+	cmd.Flags.StringVar(&logDate, "date", "", "Date the entry (YYYY-MM-DD or natural language, e.g. yesterday; default today)")
 
 	cmd.Run = func(c *Command, args []string) error {
 		if len(args) < 1 {
 			return fmt.Errorf("project ID required")
 		}
+		if deleteLine != "" && deleteIndex != 0 {
+			return fmt.Errorf("--delete and --delete-index cannot be combined")
+		}
 
 		p, err := lookupProject(cfg.NotesDirectory, args[0])
 		if err != nil {
 			return err
 		}
 
-		if deleteLine != "" {
-			if err := denote.DeleteLogEntry(p.FilePath, deleteLine); err != nil {
+		if deleteLine != "" || deleteIndex != 0 {
+			var err error
+			if deleteIndex != 0 {
+				err = denote.DeleteLogEntryByIndex(p.FilePath, deleteIndex)
+			} else {
+				err = denote.DeleteLogEntry(p.FilePath, deleteLine)
+			}
+			if err != nil {
 				return fmt.Errorf("failed to delete log entry: %v", err)
 			}
 			if !globalFlags.Quiet {
@@ -858,12 +1270,16 @@ func projectLogCommand(cfg *config.Config) *Command {
 		}
 
 		if len(args) < 2 {
-			return fmt.Errorf("message required (or use --delete)")
+			return fmt.Errorf("message required (or use --delete or --delete-index)")
 		}
 
 		message := strings.Join(args[1:], " ")
+		at, err := parseLogDate(logDate)
+		if err != nil {
+			return err
+		}
 
-		if err := denote.AddLogEntry(p.FilePath, message); err != nil {
+		if err := denote.AddLogEntryAt(p.FilePath, message, at); err != nil {
 			return fmt.Errorf("failed to add log entry: %v", err)
 		}
 		if !globalFlags.Quiet {
@@ -875,6 +1291,14 @@ func projectLogCommand(cfg *config.Config) *Command {
 	return cmd
 }
 
+// formatProgress renders done-vs-total task counts for project show.
+func formatProgress(done, total int) string {
+	if total == 0 {
+		return "no tasks"
+	}
+	return fmt.Sprintf("%d/%d done (%d%%)", done, total, done*100/total)
+}
+
 // sortProjects sorts projects by the specified field
 func sortProjects(projects []*denote.Project, sortBy string, reverse bool) {
 	sort.Slice(projects, func(i, j int) bool {
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"github.com/mph-llm-experiments/atask/internal/task"
+)
+
+// writeTemplate puts a template file under a temporary XDG config home.
+func writeTemplate(t *testing.T, name, content string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", home)
+	dir := filepath.Join(home, "atask", "templates")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProjectNewFromTemplate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+	writeTemplate(t, "release", `tasks:
+  - title: Write changelog
+    priority: p1
+    estimate: 2
+    due: +3d
+  - title: Tag release
+    due: +1w
+    tags: [ops]
+  - title: Announce
+`)
+
+	captureStdout(t, func() {
+		if err := projectNewCommand(cfg).Execute([]string{"--area", "work", "--template", "release", "Launch"}); err != nil {
+			t.Errorf("project new --template error = %v", err)
+		}
+	})
+
+	scanner := denote.NewScanner(dir)
+	projects, err := scanner.FindProjects()
+	if err != nil || len(projects) != 1 {
+		t.Fatalf("FindProjects = %d projects, err %v; want 1", len(projects), err)
+	}
+	projectID := strconv.Itoa(projects[0].IndexID)
+
+	tasks, err := scanner.FindTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTitle := make(map[string]*denote.Task)
+	for _, tk := range tasks {
+		if tk.TaskMetadata.ProjectID != projectID || tk.TaskMetadata.Area != "work" {
+			t.Errorf("task %q: project %q, area %q; want %s and work", tk.Title, tk.TaskMetadata.ProjectID, tk.TaskMetadata.Area, projectID)
+		}
+		byTitle[tk.Title] = tk
+	}
+	if len(byTitle) != 3 {
+		t.Fatalf("template created %d tasks, want 3", len(byTitle))
+	}
+
+	today := time.Now()
+	changelog := byTitle["Write changelog"]
+	if changelog.TaskMetadata.Priority != "p1" || changelog.TaskMetadata.Estimate != 2 || changelog.TaskMetadata.DueDate != today.AddDate(0, 0, 3).Format("2006-01-02") {
+		t.Errorf("Write changelog: priority %q, estimate %d, due %q", changelog.TaskMetadata.Priority, changelog.TaskMetadata.Estimate, changelog.TaskMetadata.DueDate)
+	}
+	tag := byTitle["Tag release"]
+	if tag.TaskMetadata.DueDate != today.AddDate(0, 0, 7).Format("2006-01-02") || !tag.HasTag("ops") {
+		t.Errorf("Tag release: due %q, tags %v; want a week out and ops", tag.TaskMetadata.DueDate, tag.Tags)
+	}
+	if due := byTitle["Announce"].TaskMetadata.DueDate; due != "" {
+		t.Errorf("Announce due = %q, want none", due)
+	}
+}
+
+func TestProjectNewTemplateErrorsCreateNothing(t *testing.T) {
+	writeTemplate(t, "broken", "tasks:\n  - title: Plan\n    estimate: 4\n")
+
+	for _, name := range []string{"missing", "broken"} {
+		dir := t.TempDir()
+		err := projectNewCommand(&config.Config{NotesDirectory: dir}).Execute([]string{"--template", name, "Launch"})
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("project new --template %s error = %v, want one naming the template", name, err)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("project new --template %s wrote %d files, want none", name, len(entries))
+		}
+	}
+}
+
+func TestProjectMoveTasksFilterAndDryRun(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	from, err := task.CreateProject(dir, "Old site", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := task.CreateProject(dir, "New site", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromID, toID := strconv.Itoa(from.IndexID), strconv.Itoa(to.IndexID)
+
+	for _, tc := range []struct{ title, status string }{
+		{"Port pages", denote.TaskStatusOpen},
+		{"Fix links", denote.TaskStatusOpen},
+		{"Launch old site", denote.TaskStatusDone},
+	} {
+		newTestTask(t, dir, tc.title, func(tk *denote.Task) {
+			tk.TaskMetadata.ProjectID = fromID
+			tk.TaskMetadata.Status = tc.status
+		})
+	}
+
+	projectOf := func() map[string]string {
+		t.Helper()
+		tasks, err := denote.NewScanner(dir).FindTasks()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]string)
+		for _, tk := range tasks {
+			got[tk.Title] = tk.TaskMetadata.ProjectID
+		}
+		return got
+	}
+
+	// Dry run reports the count but writes nothing
+	out := captureStdout(t, func() {
+		if err := projectMoveTasksCommand(cfg).Execute([]string{"--from", fromID, "--to", toID, "--status", "open", "--dry-run"}); err != nil {
+			t.Errorf("move-tasks --dry-run error = %v", err)
+		}
+	})
+	if !strings.Contains(out, "Would move 2 tasks") {
+		t.Errorf("move-tasks --dry-run output:\n%s\nwant a count of 2", out)
+	}
+	for title, project := range projectOf() {
+		if project != fromID {
+			t.Errorf("after --dry-run, %q is in project %s, want unchanged %s", title, project, fromID)
+		}
+	}
+
+	// The status filter leaves the done task behind
+	captureStdout(t, func() {
+		if err := projectMoveTasksCommand(cfg).Execute([]string{"--from", fromID, "--to", toID, "--status", "open"}); err != nil {
+			t.Errorf("move-tasks error = %v", err)
+		}
+	})
+	want := map[string]string{"Port pages": toID, "Fix links": toID, "Launch old site": fromID}
+	for title, project := range projectOf() {
+		if project != want[title] {
+			t.Errorf("after move-tasks --status open, %q is in project %s, want %s", title, project, want[title])
+		}
+	}
+
+	// A missing destination is rejected before anything moves
+	if err := projectMoveTasksCommand(cfg).Execute([]string{"--from", fromID, "--to", "999"}); err == nil {
+		t.Error("move-tasks to a missing project succeeded, want error")
+	}
+}
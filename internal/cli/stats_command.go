@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+// StatsCommand returns the stats command, a dashboard of task counts
+func StatsCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "stats",
+		Usage:       "atask stats",
+		Description: "Show open, done-this-week, and overdue counts with open tasks by area and priority",
+		Run: func(c *Command, args []string) error {
+			scanner := denote.NewScanner(cfg.NotesDirectory)
+			tasks, err := scanner.FindTasks()
+			if err != nil {
+				return fmt.Errorf("failed to scan directory: %v", err)
+			}
+			warnParseErrors(scanner)
+
+			stats := struct {
+				SchemaVersion int            `json:"schema_version"`
+				Open          int            `json:"open"`
+				DoneThisWeek  int            `json:"done_this_week"`
+				Overdue       int            `json:"overdue"`
+				ByArea        map[string]int `json:"by_area"`
+				ByPriority    map[string]int `json:"by_priority"`
+			}{
+				SchemaVersion: jsonSchemaVersion,
+				ByArea:        make(map[string]int),
+				ByPriority:    make(map[string]int),
+			}
+
+			now := time.Now()
+			for _, t := range tasks {
+				// Only tasks completed since completed_date was recorded
+				// can count toward the week
+				if t.TaskMetadata.Status == denote.TaskStatusDone && completedThisWeek(t.TaskMetadata.CompletedDate, now) {
+					stats.DoneThisWeek++
+				}
+				if !denote.IsOpenTaskStatus(t.TaskMetadata.Status) {
+					continue
+				}
+
+				stats.Open++
+				if isOverdueAfterGrace(t.TaskMetadata.DueDate, cfg.OverdueGrace) {
+					stats.Overdue++
+				}
+				area := t.TaskMetadata.Area
+				if area == "" {
+					area = "none"
+				}
+				stats.ByArea[area]++
+				priority := t.TaskMetadata.Priority
+				if priority == "" {
+					priority = "none"
+				}
+				stats.ByPriority[priority]++
+			}
+
+			if globalFlags.JSON {
+				data, err := json.MarshalIndent(stats, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			fmt.Printf("Open:            %d\n", stats.Open)
+			fmt.Printf("Done this week:  %d\n", stats.DoneThisWeek)
+			fmt.Printf("Overdue:         %d\n", stats.Overdue)
+			printStatsTable("By area", stats.ByArea)
+			printStatsTable("By priority", stats.ByPriority)
+			return nil
+		},
+	}
+}
+
+// completedThisWeek reports whether a completed_date falls within the seven
+// calendar days ending today. Dates are compared as calendar days, since a
+// day across a DST change is not 24 hours long.
+func completedThisWeek(completed string, now time.Time) bool {
+	d, err := time.ParseInLocation("2006-01-02", completed, now.Location())
+	if err != nil {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return !d.After(today) && !d.Before(today.AddDate(0, 0, -6))
+}
+
+// printStatsTable prints one breakdown of open tasks, largest group first.
+func printStatsTable(heading string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	fmt.Printf("\n%s:\n", heading)
+	for _, k := range keys {
+		fmt.Printf("  %-12s %d\n", truncateColumn(k, 12), counts[k])
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+)
+
+func TestCompletedThisWeekCountsCalendarDays(t *testing.T) {
+	now := time.Date(2026, 6, 10, 9, 0, 0, 0, time.Local)
+	for _, tc := range []struct {
+		completed string
+		want      bool
+	}{
+		{"2026-06-10", true},
+		{"2026-06-04", true},
+		{"2026-06-03", false},
+		{"2026-06-11", false},
+		{"", false},
+	} {
+		if got := completedThisWeek(tc.completed, now); got != tc.want {
+			t.Errorf("completedThisWeek(%q) = %v, want %v", tc.completed, got, tc.want)
+		}
+	}
+
+	// Six calendar days back across the spring-forward change are only 143
+	// hours, which truncating day arithmetic miscounts
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no tz database: %v", err)
+	}
+	justAfterMidnight := time.Date(2026, 3, 11, 0, 30, 0, 0, ny)
+	if !completedThisWeek("2026-03-05", justAfterMidnight) {
+		t.Error("task done six days ago across DST is not counted this week")
+	}
+	if completedThisWeek("2026-03-04", justAfterMidnight) {
+		t.Error("task done seven days ago across DST is counted this week")
+	}
+}
+
+func TestStatsJSONHasSchemaVersion(t *testing.T) {
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+	dir := t.TempDir()
+	newTestTask(t, dir, "Water plants", nil)
+
+	out := captureStdout(t, func() {
+		if err := StatsCommand(&config.Config{NotesDirectory: dir}).Execute(nil); err != nil {
+			t.Errorf("stats error = %v", err)
+		}
+	})
+	var stats struct {
+		SchemaVersion int `json:"schema_version"`
+		Open          int `json:"open"`
+	}
+	if err := json.Unmarshal([]byte(out), &stats); err != nil {
+		t.Fatalf("stats --json output is not JSON: %v\n%s", err, out)
+	}
+	if stats.SchemaVersion != jsonSchemaVersion || stats.Open != 1 {
+		t.Errorf("stats --json = %+v, want schema_version %d and 1 open", stats, jsonSchemaVersion)
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+const defaultSummaryFormat = "{overdue} overdue / {soon} due soon / {open} open"
+
+// taskSummaryCommand prints compact task counts for status bars and prompts
+func taskSummaryCommand(cfg *config.Config) *Command {
+	var (
+		format string
+		area   string
+	)
+
+	cmd := &Command{
+		Name:        "summary",
+		Usage:       "atask summary [--format template] [--area area]",
+		Description: "Print overdue, due-soon, and open task counts on one line",
+		Flags:       flag.NewFlagSet("summary", flag.ExitOnError),
+	}
+
+	cmd.Flags.StringVar(&format, "format", defaultSummaryFormat, "Template with {overdue}, {soon}, {today}, {open}")
+	cmd.Flags.StringVar(&area, "area", "", "Only count tasks in this area")
+
+	cmd.Run = func(c *Command, args []string) error {
+		if area == "" {
+			area = globalFlags.Area
+		}
+
+		scanner := denote.NewScanner(cfg.NotesDirectory)
+
+		// Match the default list: tasks of inactive projects are not counted
+		projects, err := scanner.FindProjects()
+		if err != nil {
+			return fmt.Errorf("failed to scan directory: %v", err)
+		}
+		hiddenProjectIDs := make(map[string]bool)
+		for _, p := range projects {
+			if p.ProjectMetadata.Status == denote.ProjectStatusPaused ||
+				p.ProjectMetadata.Status == denote.ProjectStatusCancelled ||
+				p.HasNotBegun() {
+				hiddenProjectIDs[strconv.Itoa(p.IndexID)] = true
+			}
+		}
+
+		tasks, err := scanner.FindTasks()
+		if err != nil {
+			return fmt.Errorf("failed to scan directory: %v", err)
+		}
+
+		var counts struct {
+			Overdue int `json:"overdue"`
+			Soon    int `json:"soon"`
+			Today   int `json:"today"`
+			Open    int `json:"open"`
+		}
+		for _, t := range tasks {
+			if !denote.IsOpenTaskStatus(t.TaskMetadata.Status) {
+				continue
+			}
+			if t.TaskMetadata.ProjectID != "" && hiddenProjectIDs[t.TaskMetadata.ProjectID] {
+				continue
+			}
+			if area != "" && t.TaskMetadata.Area != area {
+				continue
+			}
+
+			counts.Open++
+			due := t.TaskMetadata.DueDate
+			if isOverdueAfterGrace(due, cfg.OverdueGrace) {
+				counts.Overdue++
+			}
+			if denote.IsDueSoon(due, cfg.SoonHorizon) {
+				counts.Soon++
+			}
+			if due != "" && denote.DaysUntilDue(due) == 0 {
+				counts.Today++
+			}
+		}
+
+		if globalFlags.JSON {
+			data, err := json.MarshalIndent(counts, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		line := strings.NewReplacer(
+			"{overdue}", strconv.Itoa(counts.Overdue),
+			"{soon}", strconv.Itoa(counts.Soon),
+			"{today}", strconv.Itoa(counts.Today),
+			"{open}", strconv.Itoa(counts.Open),
+		).Replace(format)
+		fmt.Println(line)
+		return nil
+	}
+
+	return cmd
+}
@@ -4,6 +4,8 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 
 	"github.com/mph-llm-experiments/acore"
 	"github.com/mph-llm-experiments/atask/internal/config"
@@ -13,10 +15,11 @@ func SyncCommand(cfg *config.Config) *Command {
 	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
 	push := fs.Bool("push", false, "Push local changes to R2 (default)")
 	pull := fs.Bool("pull", false, "Pull remote changes from R2")
+	dryRun := fs.Bool("dry-run", false, "Report what would be transferred without syncing")
 
 	return &Command{
 		Name:        "sync",
-		Usage:       "atask sync [--push|--pull]",
+		Usage:       "atask sync [--push|--pull] [--dry-run]",
 		Description: "Sync task files with Cloudflare R2",
 		Flags:       fs,
 		Run: func(cmd *Command, args []string) error {
@@ -40,11 +43,25 @@ func SyncCommand(cfg *config.Config) *Command {
 				return fmt.Errorf("creating R2 store: %w", err)
 			}
 
-			result, err := acore.SyncApp(local, remote, direction, acore.SyncOpts{Delete: true})
+			// A dry run transfers nothing, so it doesn't need the lock
+			if !*dryRun {
+				release, err := acquireSyncLock(cfg)
+				if err != nil {
+					return err
+				}
+				defer release()
+			}
+
+			result, err := acore.SyncApp(local, remote, direction, acore.SyncOpts{Delete: true, DryRun: *dryRun})
 			if err != nil {
 				return fmt.Errorf("sync failed: %w", err)
 			}
 
+			if *dryRun {
+				printSyncPlan(result, direction, cfg.NotesDirectory)
+				return nil
+			}
+
 			if !globalFlags.Quiet {
 				printSyncResult(result, direction)
 			}
@@ -75,6 +92,74 @@ func printSyncResult(result *acore.SyncResult, direction string) {
 	}
 }
 
+// printSyncPlan reports what a dry-run sync would transfer and delete.
+// Byte counts are taken from the local copy of each file, so they are exact
+// for files leaving this machine and approximate for files coming from R2.
+func printSyncPlan(result *acore.SyncResult, direction string, dir string) {
+	// Errors come first: a plan that failed to list files is not in sync
+	for _, err := range result.Errors {
+		fmt.Printf("  error: %v\n", err)
+	}
+	if len(result.Pushed) == 0 && len(result.Deleted) == 0 {
+		if len(result.Errors) == 0 {
+			fmt.Println("Already in sync. Nothing would be transferred.")
+		} else {
+			fmt.Printf("Dry run failed with %d error(s); the plan may be incomplete\n", len(result.Errors))
+		}
+		return
+	}
+
+	verb := "push"
+	if direction == "pull" {
+		verb = "pull"
+	}
+
+	if len(result.Pushed) > 0 {
+		size, unknown := localFileSizes(dir, result.Pushed)
+		fmt.Printf("Would %s %d files (%s)\n", verb, len(result.Pushed), describeSize(size, unknown))
+	}
+	if len(result.Deleted) > 0 {
+		size, unknown := localFileSizes(dir, result.Deleted)
+		fmt.Printf("Would delete %d files from target (%s)\n", len(result.Deleted), describeSize(size, unknown))
+	}
+	fmt.Println("Dry run: no files were transferred")
+}
+
+// localFileSizes sums the sizes of the named files in dir. Files that don't
+// exist locally are counted in unknown rather than contributing to the total.
+func localFileSizes(dir string, names []string) (total int64, unknown int) {
+	for _, name := range names {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			unknown++
+			continue
+		}
+		total += info.Size()
+	}
+	return total, unknown
+}
+
+// describeSize formats a byte count, noting how many files had no local size.
+func describeSize(size int64, unknown int) string {
+	s := "~" + formatBytes(size)
+	if unknown > 0 {
+		s += fmt.Sprintf(", %d remote-only files not counted", unknown)
+	}
+	return s
+}
+
+// formatBytes renders a byte count in B, KB, or MB.
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
 // SyncOnStartup pulls from R2 if configured. Errors are logged, not fatal.
 func SyncOnStartup(cfg *config.Config) {
 	acoreCfg, err := acore.LoadConfig()
@@ -91,6 +176,16 @@ func SyncOnStartup(cfg *config.Config) {
 		return
 	}
 
+	// Never race another process syncing the same vault
+	release, err := acquireSyncLock(cfg)
+	if err != nil {
+		if err != errSyncLocked || globalFlags.Verbose {
+			log.Printf("sync pull skipped: %v", err)
+		}
+		return
+	}
+	defer release()
+
 	if _, err := acore.SyncApp(local, remote, "pull", acore.SyncOpts{Delete: false}); err != nil {
 		log.Printf("sync pull: %v", err)
 	}
@@ -112,6 +207,16 @@ func SyncOnShutdown(cfg *config.Config) {
 		return
 	}
 
+	// Never race another process syncing the same vault
+	release, err := acquireSyncLock(cfg)
+	if err != nil {
+		if err != errSyncLocked || globalFlags.Verbose {
+			log.Printf("sync push skipped: %v", err)
+		}
+		return
+	}
+	defer release()
+
 	if _, err := acore.SyncApp(local, remote, "push", acore.SyncOpts{Delete: false}); err != nil {
 		log.Printf("sync push: %v", err)
 	}
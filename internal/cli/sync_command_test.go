@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsSyncDryRun(t *testing.T) {
+	for _, tc := range []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"sync", "--dry-run"}, true},
+		{[]string{"sync", "-dry-run"}, true},
+		{[]string{"sync", "--dry-run=true"}, true},
+		{[]string{"sync", "--pull", "--dry-run=1"}, true},
+		{[]string{"sync", "--dry-run=false"}, false},
+		{[]string{"sync"}, false},
+		{[]string{"sync", "--bogus"}, false},
+		{[]string{"list", "--dry-run"}, false},
+		{nil, false},
+	} {
+		if got := isSyncDryRun(tc.args); got != tc.want {
+			t.Errorf("isSyncDryRun(%q) = %v, want %v", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestLocalFileSizes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.md"), []byte("12345"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "b.md"), []byte("123"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	total, unknown := localFileSizes(dir, []string{"a.md", "b.md", "remote-only.md"})
+	if total != 8 || unknown != 1 {
+		t.Errorf("localFileSizes = (%d, %d), want (8, 1)", total, unknown)
+	}
+	if total, unknown := localFileSizes(dir, nil); total != 0 || unknown != 0 {
+		t.Errorf("localFileSizes of no files = (%d, %d), want (0, 0)", total, unknown)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for _, tc := range []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{1024 * 1024, "1.0 MB"},
+		{5 * 1024 * 1024 / 2, "2.5 MB"},
+	} {
+		if got := formatBytes(tc.n); got != tc.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestDescribeSize(t *testing.T) {
+	if got := describeSize(2048, 0); got != "~2.0 KB" {
+		t.Errorf("describeSize(2048, 0) = %q, want ~2.0 KB", got)
+	}
+	got := describeSize(10, 3)
+	if !strings.HasPrefix(got, "~10 B") || !strings.Contains(got, "3 remote-only files not counted") {
+		t.Errorf("describeSize(10, 3) = %q, want the size and the uncounted files", got)
+	}
+}
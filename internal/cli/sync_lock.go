@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+)
+
+const (
+	syncLockFile = ".atask-sync.lock"
+
+	// staleSyncLockAge is how old a lock must be before it is assumed to
+	// belong to a crashed sync and is removed. Syncs finish in seconds.
+	staleSyncLockAge = 10 * time.Minute
+
+	syncLockPoll = 200 * time.Millisecond
+)
+
+// errSyncLocked reports that another sync holds the lock.
+var errSyncLocked = errors.New("another sync is already running")
+
+// acquireSyncLock takes the vault's sync lock so a foreground `sync` and the
+// startup/shutdown syncs of other atask processes never transfer at once.
+// In "skip" mode it fails immediately with errSyncLocked when the lock is
+// held; in "wait" mode it retries until sync.lock_timeout elapses. The
+// returned func releases the lock, unless it has since been cleared as
+// stale and taken by another process.
+func acquireSyncLock(cfg *config.Config) (func(), error) {
+	path := filepath.Join(cfg.NotesDirectory, syncLockFile)
+	deadline := time.Now().Add(time.Duration(cfg.Sync.LockTimeout) * time.Second)
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			// The nonce makes the token unique even if a PID is reused
+			token := fmt.Sprintf("%d %s %s\n", os.Getpid(), newLockNonce(), time.Now().Format(time.RFC3339))
+			_, werr := f.WriteString(token)
+			if cerr := f.Close(); werr == nil {
+				werr = cerr
+			}
+			if werr != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("writing sync lock: %w", werr)
+			}
+			return func() { removeSyncLock(path, []byte(token)) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("creating sync lock: %w", err)
+		}
+
+		// A lock left behind by a crashed process is cleared and retried. One
+		// that can't be cleared is waited on like any other.
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleSyncLockAge {
+			if stale, err := os.ReadFile(path); err == nil && removeSyncLock(path, stale) {
+				continue
+			}
+		}
+
+		if cfg.Sync.LockMode == "skip" || !time.Now().Before(deadline) {
+			return nil, errSyncLocked
+		}
+		time.Sleep(syncLockPoll)
+	}
+}
+
+// removeSyncLock deletes the lock at path only if it still holds token. The
+// file is first renamed aside, which only one process can do, and checked
+// there; a lock some other process took in the meantime is put back. It
+// reports whether the lock was moved off path, so the path may be free.
+func removeSyncLock(path string, token []byte) bool {
+	aside := fmt.Sprintf("%s.%s", path, newLockNonce())
+	if err := os.Rename(path, aside); err != nil {
+		return false
+	}
+	if held, err := os.ReadFile(aside); err == nil && !bytes.Equal(held, token) {
+		// Link fails rather than overwrite if the path was taken again
+		os.Link(aside, path)
+	}
+	os.Remove(aside)
+	return true
+}
+
+// newLockNonce returns a random hex string for lock tokens and temp names.
+func newLockNonce() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+)
+
+func TestSyncLock(t *testing.T) {
+	cfg := &config.Config{NotesDirectory: t.TempDir()}
+	cfg.Sync.LockMode = "skip"
+
+	release, err := acquireSyncLock(cfg)
+	if err != nil {
+		t.Fatalf("first acquire error = %v", err)
+	}
+	if _, err := acquireSyncLock(cfg); err != errSyncLocked {
+		t.Fatalf("second acquire error = %v, want errSyncLocked", err)
+	}
+
+	// Wait mode gives up once the timeout passes
+	cfg.Sync.LockMode = "wait"
+	if _, err := acquireSyncLock(cfg); err != errSyncLocked {
+		t.Fatalf("wait acquire with zero timeout error = %v, want errSyncLocked", err)
+	}
+
+	release()
+	release, err = acquireSyncLock(cfg)
+	if err != nil {
+		t.Fatalf("acquire after release error = %v", err)
+	}
+	release()
+}
+
+func TestSyncLockClearsStaleLock(t *testing.T) {
+	cfg := &config.Config{NotesDirectory: t.TempDir()}
+	cfg.Sync.LockMode = "skip"
+
+	path := filepath.Join(cfg.NotesDirectory, syncLockFile)
+	if err := os.WriteFile(path, []byte("1 crashed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleSyncLockAge)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	release, err := acquireSyncLock(cfg)
+	if err != nil {
+		t.Fatalf("acquire over stale lock error = %v", err)
+	}
+	release()
+}
+
+func TestSyncLockReleaseKeepsAnotherHoldersLock(t *testing.T) {
+	cfg := &config.Config{NotesDirectory: t.TempDir()}
+	cfg.Sync.LockMode = "skip"
+
+	release, err := acquireSyncLock(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process judged the lock stale, cleared it, and took its own
+	path := filepath.Join(cfg.NotesDirectory, syncLockFile)
+	other := []byte("4242 othernonce 2026-01-01T00:00:00Z\n")
+	if err := os.WriteFile(path, other, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	release()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("release removed the other holder's lock: %v", err)
+	}
+	if string(got) != string(other) {
+		t.Errorf("lock after release = %q, want the other holder's %q", got, other)
+	}
+	entries, err := os.ReadDir(cfg.NotesDirectory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("notes directory has %d entries after release, want only the lock", len(entries))
+	}
+}
+
+func TestSyncLockGivesUpOnUnremovableStaleLock(t *testing.T) {
+	cfg := &config.Config{NotesDirectory: t.TempDir()}
+
+	// A directory can't be read as a lock token, so it is never cleared,
+	// even by root
+	path := filepath.Join(cfg.NotesDirectory, syncLockFile)
+	if err := os.Mkdir(path, 0755); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * staleSyncLockAge)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []string{"skip", "wait"} {
+		cfg.Sync.LockMode = mode
+		done := make(chan error, 1)
+		go func() {
+			_, err := acquireSyncLock(cfg)
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			if err != errSyncLocked {
+				t.Errorf("%s acquire over unremovable stale lock error = %v, want errSyncLocked", mode, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s acquire over unremovable stale lock did not return", mode)
+		}
+	}
+}
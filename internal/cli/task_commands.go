@@ -1,17 +1,20 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-runewidth"
 	"github.com/mph-llm-experiments/acore"
 	"github.com/mph-llm-experiments/atask/internal/config"
 	"github.com/mph-llm-experiments/atask/internal/denote"
@@ -31,19 +34,92 @@ func TaskCommand(cfg *config.Config) *Command {
 	cmd.Subcommands = []*Command{
 		taskNewCommand(cfg),
 		taskListCommand(cfg),
+		taskSummaryCommand(cfg),
 		taskShowCommand(cfg),
 		taskQueryCommand(cfg),
 		taskUpdateCommand(cfg),
 		taskBatchUpdateCommand(cfg),
 		taskDoneCommand(cfg),
+		taskStartCommand(cfg),
+		taskReopenCommand(cfg),
+		taskPromoteCommand(cfg),
+		taskDemoteCommand(cfg),
+		taskBumpCommand(cfg),
+		taskPlanCommand(cfg),
 		taskLogCommand(cfg),
 		taskEditCommand(cfg),
 		taskDeleteCommand(cfg),
+		taskMergeCommand(cfg),
+		taskMoveCommand(cfg),
+		taskArchiveCommand(cfg),
 	}
 
 	return cmd
 }
 
+// warnParseErrors reports files the scanner skipped because they failed to
+// parse, so a malformed file doesn't silently vanish from listings.
+// Verbose mode has already printed them during the scan.
+func warnParseErrors(scanner *denote.Scanner) {
+	if globalFlags.Quiet || globalFlags.Verbose {
+		return
+	}
+	for _, e := range scanner.ParseErrors() {
+		fmt.Fprintf(os.Stderr, "Warning: skipped unparseable file %s\n", e.Error())
+	}
+}
+
+// relationFlag collects related entity ULIDs from a repeatable,
+// comma-separated flag such as --add-person
+type relationFlag []string
+
+func (f *relationFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *relationFlag) Set(val string) error {
+	for _, id := range strings.Split(val, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			*f = append(*f, id)
+		}
+	}
+	return nil
+}
+
+// tagFlag collects tag patterns from a repeatable, comma-separated flag
+// such as list --tag. Patterns are lowercased since tags match without case.
+type tagFlag []string
+
+func (f *tagFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *tagFlag) Set(val string) error {
+	for _, tag := range strings.Split(val, ",") {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			*f = append(*f, tag)
+		}
+	}
+	return nil
+}
+
+// validateTaskFields rejects a priority or status that isn't one of the
+// known values. Empty values mean "not given" and pass.
+func validateTaskFields(priority, status string) error {
+	if priority != "" && !denote.IsValidPriority(priority) {
+		return fmt.Errorf("invalid priority: %s (valid: p1, p2, p3)", priority)
+	}
+	if status != "" && !denote.IsValidTaskStatus(status) {
+		return fmt.Errorf("invalid status: %s (valid: open, in_progress, done, paused, delegated, dropped)", status)
+	}
+	return nil
+}
+
+// validateEstimate rejects an estimate outside the point buckets that
+// downstream tooling assumes. 0 means unset.
+func validateEstimate(estimate int) error {
+	if estimate != 0 && !denote.IsValidEstimate(estimate) {
+		return fmt.Errorf("invalid estimate: %d (must be 0, 1, 2, 3, 5, 8, or 13)", estimate)
+	}
+	return nil
+}
+
 // lookupTask finds a task by integer index_id or ULID string.
 func lookupTask(dir string, identifier string) (*denote.Task, error) {
 	// Try as integer index_id first
@@ -64,11 +140,16 @@ func taskNewCommand(cfg *config.Config) *Command {
 		estimate int
 		tags     string
 		recur    string
+		people   relationFlag
+		tasks    relationFlag
+		ideas    relationFlag
+		today    bool
+		template string
 	)
 
 	cmd := &Command{
 		Name:        "new",
-		Usage:       "atask task new <title> [options]",
+		Usage:       "atask task new <title> [options] [--template name]",
 		Description: "Create a new task",
 		Flags:       flag.NewFlagSet("task-new", flag.ExitOnError),
 	}
@@ -80,15 +161,72 @@ func taskNewCommand(cfg *config.Config) *Command {
 	cmd.Flags.StringVar(&project, "project", "", "Project name or ID")
 	cmd.Flags.IntVar(&estimate, "estimate", 0, "Time estimate")
 	cmd.Flags.StringVar(&tags, "tags", "", "Comma-separated tags")
-	cmd.Flags.StringVar(&recur, "recur", "", "Recurrence pattern (daily, weekly, monthly, yearly, every Nd/Nw/Nm/Ny, every mon,wed,fri)")
+	cmd.Flags.StringVar(&recur, "recur", "", "Recurrence pattern (daily, weekly, monthly, yearly, every Nd/Nw/Nm/Ny, every mon,wed,fri, every 2nd tue, after:Nd or every +Nd)")
+	cmd.Flags.Var(&people, "add-person", "Add related contact (ULID, repeatable or comma-separated)")
+	cmd.Flags.Var(&tasks, "add-task", "Add related task (ULID, repeatable or comma-separated)")
+	cmd.Flags.Var(&ideas, "add-idea", "Add related idea (ULID, repeatable or comma-separated)")
+	cmd.Flags.BoolVar(&today, "today", false, "Plan the task for today (sets planned_for)")
+	cmd.Flags.StringVar(&template, "template", "", "Start from a template in ~/.config/atask/templates (flags override its values)")
 
 	cmd.Run = func(c *Command, args []string) error {
+		// Template values fill in whatever the flags leave unset
+		var body string
+		if template != "" {
+			tmpl, err := task.LoadTaskTemplate(config.TemplatesDir(), template)
+			if err != nil {
+				return err
+			}
+			if len(args) == 0 && tmpl.Title != "" {
+				args = []string{tmpl.Title}
+			}
+			if priority == "" {
+				priority = tmpl.Priority
+			}
+			if estimate == 0 {
+				estimate = tmpl.Estimate
+			}
+			if due == "" {
+				due = tmpl.DueDate(time.Now())
+			}
+			if area == "" {
+				area = tmpl.Area
+			}
+			if tags == "" {
+				tags = strings.Join(tmpl.Tags, ",")
+			}
+			body = tmpl.Body
+		}
+
 		if len(args) == 0 {
 			return fmt.Errorf("title required")
 		}
 
 		title := strings.Join(args, " ")
 
+		// The global area flag wins over --area
+		taskArea := globalFlags.Area
+		if taskArea == "" {
+			taskArea = area
+		}
+
+		// Configured area defaults fill in what flags and template left unset
+		if d, ok := cfg.AreaDefaults[taskArea]; ok && taskArea != "" {
+			if priority == "" {
+				priority = d.Priority
+			}
+			if estimate == 0 {
+				estimate = d.Estimate
+			}
+		}
+
+		// Validate priority and estimate before anything is written
+		if err := validateTaskFields(priority, ""); err != nil {
+			return err
+		}
+		if err := validateEstimate(estimate); err != nil {
+			return err
+		}
+
 		// Parse tags
 		var tagList []string
 		if tags != "" {
@@ -101,14 +239,15 @@ func taskNewCommand(cfg *config.Config) *Command {
 		// Validate recurrence pattern if provided
 		var recurPattern string
 		if recur != "" {
-			if due == "" {
-				return fmt.Errorf("--due is required when --recur is set")
-			}
 			var err error
 			recurPattern, err = recurrence.ParsePattern(recur)
 			if err != nil {
 				return fmt.Errorf("invalid recurrence pattern: %v", err)
 			}
+			// After-completion patterns get their first due date on first completion
+			if due == "" && !recurrence.IsAfterCompletion(recurPattern) {
+				return fmt.Errorf("--due is required when --recur is set (except for after:<N> patterns)")
+			}
 		}
 
 		// Parse due date if provided
@@ -121,14 +260,15 @@ func taskNewCommand(cfg *config.Config) *Command {
 			dueDate = parsed
 		}
 
-		// Create the task (use global area flag)
-		taskFile, err := task.CreateTask(cfg.NotesDirectory, title, "", tagList, globalFlags.Area)
+		// Create the task
+		taskFile, err := task.CreateTask(cfg.NotesDirectory, title, body, tagList, taskArea)
 		if err != nil {
 			return fmt.Errorf("failed to create task: %v", err)
 		}
 
 		// Update metadata if provided
-		if priority != "" || dueDate != "" || project != "" || estimate > 0 || recurPattern != "" {
+		hasRelations := len(people) > 0 || len(tasks) > 0 || len(ideas) > 0
+		if priority != "" || dueDate != "" || project != "" || estimate > 0 || recurPattern != "" || hasRelations || today {
 			t, err := denote.ParseTaskFile(taskFile.FilePath)
 			if err != nil {
 				return fmt.Errorf("failed to read created task: %v", err)
@@ -157,6 +297,23 @@ func taskNewCommand(cfg *config.Config) *Command {
 			if recurPattern != "" {
 				t.TaskMetadata.Recur = recurPattern
 			}
+			if today {
+				t.PlannedFor = time.Now().Format("2006-01-02")
+			}
+
+			// Cross-app relationships, as in update
+			for _, id := range people {
+				acore.AddRelation(&t.RelatedPeople, id)
+				acore.SyncRelation(t.Type, t.ID, id)
+			}
+			for _, id := range tasks {
+				acore.AddRelation(&t.RelatedTasks, id)
+				acore.SyncRelation(t.Type, t.ID, id)
+			}
+			for _, id := range ideas {
+				acore.AddRelation(&t.RelatedIdeas, id)
+				acore.SyncRelation(t.Type, t.ID, id)
+			}
 
 			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
 				return fmt.Errorf("failed to update task metadata: %v", err)
@@ -176,7 +333,11 @@ func taskNewCommand(cfg *config.Config) *Command {
 		}
 
 		if !globalFlags.Quiet {
-			fmt.Printf("Created task: %s\n", final.FilePath)
+			if final.PlannedFor != "" {
+				fmt.Printf("Created task: %s (planned for today)\n", final.FilePath)
+			} else {
+				fmt.Printf("Created task: %s\n", final.FilePath)
+			}
 		}
 
 		return nil
@@ -187,20 +348,45 @@ func taskNewCommand(cfg *config.Config) *Command {
 
 // taskShowCommand shows details for a single task
 func taskShowCommand(cfg *config.Config) *Command {
-	return &Command{
+	var logJSON bool
+	var outTemplate string
+	var withRelated bool
+
+	cmd := &Command{
 		Name:        "show",
-		Usage:       "atask show <id>",
+		Usage:       "atask show <id> [--log-json] [--output-template tmpl] [--with-related]",
 		Description: "Show task details by index_id or ULID",
+		Flags:       flag.NewFlagSet("task-show", flag.ExitOnError),
 		Run: func(cmd *Command, args []string) error {
 			if len(args) == 0 {
 				return fmt.Errorf("usage: atask show <id>")
 			}
+			if logJSON && outTemplate != "" {
+				return fmt.Errorf("--output-template cannot be combined with --log-json")
+			}
+			tmpl, err := outputTemplate(outTemplate, "")
+			if err != nil {
+				return err
+			}
 
 			t, err := lookupTask(cfg.NotesDirectory, args[0])
 			if err != nil {
 				return err
 			}
 
+			if tmpl != nil {
+				return writeTasksTemplate(os.Stdout, tmpl, []denote.Task{*t})
+			}
+
+			if logJSON {
+				data, err := json.MarshalIndent(denote.ParseLogEntries(t.Content), "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
 			if globalFlags.JSON {
 				type jsonTask struct {
 					*denote.Task
@@ -247,7 +433,7 @@ func taskShowCommand(cfg *config.Config) *Command {
 				fmt.Printf("  Project:  %s\n", projectName)
 			}
 			if t.TaskMetadata.Estimate > 0 {
-				fmt.Printf("  Estimate: %d\n", t.TaskMetadata.Estimate)
+				fmt.Printf("  Estimate: %s\n", formatEstimate(cfg, t.TaskMetadata.Estimate))
 			}
 			if t.TaskMetadata.Assignee != "" {
 				fmt.Printf("  Assignee: %s\n", t.TaskMetadata.Assignee)
@@ -255,6 +441,9 @@ func taskShowCommand(cfg *config.Config) *Command {
 			if t.TaskMetadata.Recur != "" {
 				fmt.Printf("  Recur:    %s\n", t.TaskMetadata.Recur)
 			}
+			if t.PlannedFor != "" {
+				fmt.Printf("  Planned:  %s\n", t.PlannedFor)
+			}
 			fmt.Println()
 
 			if t.Created != "" {
@@ -263,6 +452,9 @@ func taskShowCommand(cfg *config.Config) *Command {
 			if t.Modified != "" {
 				fmt.Printf("  Modified: %s\n", t.Modified)
 			}
+			if t.TaskMetadata.CompletedDate != "" {
+				fmt.Printf("  Done:     %s\n", t.TaskMetadata.CompletedDate)
+			}
 
 			var tagStrs []string
 			for _, tag := range t.Tags {
@@ -274,16 +466,48 @@ func taskShowCommand(cfg *config.Config) *Command {
 				fmt.Printf("\n  Tags: %s\n", strings.Join(tagStrs, " "))
 			}
 
+			// One scan serves the related, referencing, and blocker sections;
+			// if it fails they fall back to raw IDs
+			allTasks, _ := denote.NewScanner(cfg.NotesDirectory).FindTasks()
+			tasksByID := make(map[string]*denote.Task, len(allTasks))
+			for _, other := range allTasks {
+				tasksByID[other.ID] = other
+			}
+
 			if len(t.RelatedPeople) > 0 || len(t.RelatedTasks) > 0 || len(t.RelatedIdeas) > 0 {
+				people, tasks, ideas := t.RelatedPeople, t.RelatedTasks, t.RelatedIdeas
+				if withRelated {
+					people = relatedLabels(people, func(id string) string { return externalEntityLabel("apeople", id) })
+					tasks = relatedLabels(tasks, func(id string) string { return relatedTaskLabel(tasksByID, id) })
+					ideas = relatedLabels(ideas, func(id string) string { return externalEntityLabel("anote", id) })
+				}
 				fmt.Println()
-				if len(t.RelatedPeople) > 0 {
-					fmt.Printf("  Related people: %s\n", strings.Join(t.RelatedPeople, ", "))
+				if len(people) > 0 {
+					fmt.Printf("  Related people: %s\n", strings.Join(people, ", "))
+				}
+				if len(tasks) > 0 {
+					fmt.Printf("  Related tasks:  %s\n", strings.Join(tasks, ", "))
 				}
-				if len(t.RelatedTasks) > 0 {
-					fmt.Printf("  Related tasks:  %s\n", strings.Join(t.RelatedTasks, ", "))
+				if len(ideas) > 0 {
+					fmt.Printf("  Related ideas:  %s\n", strings.Join(ideas, ", "))
+				}
+			}
+
+			if referencing := task.TasksReferencing(allTasks, t.ID); len(referencing) > 0 {
+				fmt.Println("\n  Referenced by:")
+				for _, r := range referencing {
+					fmt.Printf("    #%d %s (%s)\n", r.IndexID, r.Title, r.TaskMetadata.Status)
 				}
-				if len(t.RelatedIdeas) > 0 {
-					fmt.Printf("  Related ideas:  %s\n", strings.Join(t.RelatedIdeas, ", "))
+			}
+
+			if len(t.TaskMetadata.BlockedBy) > 0 {
+				fmt.Println("\n  Blocked by:")
+				for _, id := range t.TaskMetadata.BlockedBy {
+					if b, ok := tasksByID[id]; ok {
+						fmt.Printf("    #%d %s (%s)\n", b.IndexID, b.Title, b.TaskMetadata.Status)
+					} else {
+						fmt.Printf("    %s (missing, ignored)\n", id)
+					}
 				}
 			}
 
@@ -294,23 +518,96 @@ func taskShowCommand(cfg *config.Config) *Command {
 			return nil
 		},
 	}
+
+	cmd.Flags.BoolVar(&logJSON, "log-json", false, "Print only the parsed log entries as JSON")
+	cmd.Flags.StringVar(&outTemplate, "output-template", "", "Render the task with a Go template, e.g. '{{.IndexID}} {{.Title}}'")
+	cmd.Flags.BoolVar(&withRelated, "with-related", false, "Resolve related task, people, and idea IDs to their titles")
+
+	return cmd
+}
+
+// relatedLabels maps each related ID through label.
+func relatedLabels(ids []string, label func(string) string) []string {
+	labels := make([]string, len(ids))
+	for i, id := range ids {
+		labels[i] = label(id)
+	}
+	return labels
+}
+
+// relatedTaskLabel renders a related task ULID as "title (#index)", or the
+// raw ID when no such task exists.
+func relatedTaskLabel(tasksByID map[string]*denote.Task, id string) string {
+	t, ok := tasksByID[id]
+	if !ok {
+		return id
+	}
+	return fmt.Sprintf("%s (#%d)", t.Title, t.IndexID)
+}
+
+// externalEntityLabel asks a sibling tool (apeople, anote) for an entity's
+// name via `<bin> show <id> --json`. It falls back to the raw ID when the
+// tool is not installed, fails, or returns nothing usable.
+func externalEntityLabel(bin, id string) string {
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return id
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "show", id, "--json").Output()
+	if err != nil {
+		return id
+	}
+	var entity struct {
+		Title   string `json:"title"`
+		Name    string `json:"name"`
+		IndexID int    `json:"index_id"`
+	}
+	if err := json.Unmarshal(out, &entity); err != nil {
+		return id
+	}
+	name := entity.Title
+	if name == "" {
+		name = entity.Name
+	}
+	if name == "" {
+		return id
+	}
+	if entity.IndexID > 0 {
+		return fmt.Sprintf("%s (#%d)", name, entity.IndexID)
+	}
+	return name
 }
 
 // taskListCommand lists tasks
 func taskListCommand(cfg *config.Config) *Command {
 	var (
-		all        bool
-		area       string
-		status     string
-		priority   string
-		project    string
-		overdue    bool
-		soon       bool
-		sortBy     string
-		reverse    bool
-		search     string
-		plannedFor string
-		tag        string
+		all          bool
+		area         string
+		status       string
+		priority     string
+		project      string
+		overdue      bool
+		soon         bool
+		soonDays     int
+		sortBy       string
+		reverse      bool
+		search       string
+		plannedFor   string
+		tags         tagFlag
+		excludeStr   string
+		graceStr     string
+		showAssignee bool
+		showTotals   bool
+		jsonComputed bool
+		limit        int
+		format       string
+		withArchived bool
+		countOnly    bool
+		outTemplate  string
+		groupBy      string
+		watch        bool
 	)
 
 	cmd := &Command{
@@ -320,273 +617,646 @@ func taskListCommand(cfg *config.Config) *Command {
 		Flags:       flag.NewFlagSet("task-list", flag.ExitOnError),
 	}
 
-	cmd.Flags.BoolVar(&all, "all", false, "Show all tasks (default: open only)")
+	cmd.Flags.BoolVar(&all, "all", false, "Show all tasks (default: open and in-progress only)")
 	cmd.Flags.StringVar(&area, "area", "", "Filter by area")
 	cmd.Flags.StringVar(&status, "status", "", "Filter by status")
+	cmd.Flags.StringVar(&excludeStr, "exclude-status", "", "Comma-separated statuses to hide (shows all other statuses)")
 	cmd.Flags.StringVar(&priority, "p", "", "Filter by priority (p1, p2, p3)")
 	cmd.Flags.StringVar(&priority, "priority", "", "Filter by priority (p1, p2, p3)")
 	cmd.Flags.StringVar(&project, "project", "", "Filter by project")
 	cmd.Flags.BoolVar(&overdue, "overdue", false, "Show only overdue tasks")
 	cmd.Flags.BoolVar(&soon, "soon", false, "Show tasks due soon")
+	cmd.Flags.IntVar(&soonDays, "soon-days", 0, "Days ahead --soon looks for this run (default from config)")
+	cmd.Flags.StringVar(&graceStr, "overdue-grace", "", "Days past due before a task counts as overdue (e.g. 2d; default from config)")
 	cmd.Flags.StringVar(&search, "search", "", "Search in task content (full-text)")
 	cmd.Flags.StringVar(&plannedFor, "planned-for", "", "Filter by planned_for date (today, YYYY-MM-DD, or any)")
-	cmd.Flags.StringVar(&tag, "tag", "", "Filter by tag")
-	cmd.Flags.StringVar(&sortBy, "sort", "modified", "Sort by: modified, priority, due, created")
+	cmd.Flags.Var(&tags, "tag", "Filter by tag, repeatable; all must match, ignoring case (client/* matches sub-tags)")
+	cmd.Flags.StringVar(&sortBy, "sort", "modified", "Sort by: modified, priority, due, created, age, project")
 	cmd.Flags.BoolVar(&reverse, "reverse", false, "Reverse sort order")
+	cmd.Flags.BoolVar(&showAssignee, "show-assignee", false, "Add an assignee column")
+	cmd.Flags.BoolVar(&showTotals, "totals", false, "Add a totals row: tasks shown, overdue, and summed estimate")
+	cmd.Flags.BoolVar(&jsonComputed, "json-computed", false, "Add computed blocked and open_blockers fields to --json output")
+	cmd.Flags.IntVar(&limit, "limit", 0, "Show at most N tasks after sorting (0 for no limit)")
+	cmd.Flags.StringVar(&format, "format", "", "Output format: text, json, csv, jsonl (default: text, or json with --json)")
+	cmd.Flags.BoolVar(&withArchived, "include-archived", false, "Also scan tasks moved to archive/")
+	cmd.Flags.BoolVar(&countOnly, "count", false, "Print only the number of matching tasks")
+	cmd.Flags.StringVar(&outTemplate, "output-template", "", "Render each task with a Go template, e.g. '{{.IndexID}} {{.Title}}'")
+	cmd.Flags.StringVar(&groupBy, "group-by", "", "Group tasks under headers by: area, project, priority, status")
+	cmd.Flags.BoolVar(&watch, "watch", false, "Redraw the list whenever task files change (Ctrl-C to exit)")
 
 	cmd.Flags.BoolVar(&all, "a", false, "Show all tasks (short)")
 	cmd.Flags.StringVar(&sortBy, "s", "modified", "Sort by (short)")
 	cmd.Flags.BoolVar(&reverse, "r", false, "Reverse sort (short)")
+	cmd.Flags.IntVar(&limit, "n", 0, "Limit results (short)")
 
 	cmd.Run = func(c *Command, args []string) error {
 		if globalFlags.TUI {
 			return fmt.Errorf("TUI integration not yet implemented")
 		}
 
-		scanner := denote.NewScanner(cfg.NotesDirectory)
+		outFormat, err := outputFormat(format)
+		if err != nil {
+			return err
+		}
+		tmpl, err := outputTemplate(outTemplate, format)
+		if err != nil {
+			return err
+		}
+		switch groupBy {
+		case "", "area", "project", "priority", "status":
+		default:
+			return fmt.Errorf("invalid --group-by: %s (valid: area, project, priority, status)", groupBy)
+		}
+		if groupBy != "" && (tmpl != nil || outFormat == formatCSV || outFormat == formatJSONL) {
+			return fmt.Errorf("--group-by only applies to text and json output")
+		}
+		if watch && (outFormat != formatText || tmpl != nil || countOnly) {
+			return fmt.Errorf("--watch only works with text output, not --json, --format, --output-template, or --count")
+		}
 
-		// Get all projects for name lookup and hidden status
-		projects, _ := scanner.FindProjects()
-		projectNames := make(map[string]string)
-		hiddenProjectIDs := make(map[string]bool)
-		for _, p := range projects {
-			idStr := strconv.Itoa(p.IndexID)
-			projectNames[idStr] = p.Title
-			if p.ProjectMetadata.Status == denote.ProjectStatusPaused ||
-				p.ProjectMetadata.Status == denote.ProjectStatusCancelled ||
-				p.HasNotBegun() {
-				hiddenProjectIDs[idStr] = true
+		excluded := make(map[string]bool)
+		if excludeStr != "" {
+			for _, s := range strings.Split(excludeStr, ",") {
+				s = strings.TrimSpace(s)
+				if !denote.IsValidTaskStatus(s) {
+					return fmt.Errorf("invalid status in --exclude-status: %s", s)
+				}
+				excluded[s] = true
 			}
 		}
 
-		// Get all tasks
-		allTasks, err := scanner.FindTasks()
+		grace := cfg.OverdueGrace
+		if graceStr != "" {
+			n, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(graceStr), "d"))
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid --overdue-grace: %s (expected Nd, e.g. 2d)", graceStr)
+			}
+			grace = n
+		}
+
+		horizon, err := soonHorizon(cfg, soonDays)
 		if err != nil {
-			return fmt.Errorf("failed to scan directory: %v", err)
+			return err
 		}
 
-		// Filter tasks
-		var tasks []denote.Task
-		for _, t := range allTasks {
-			if !all && status == "" && t.TaskMetadata.Status != denote.TaskStatusOpen && t.TaskMetadata.Status != "" {
-				continue
-			}
-			if status != "" && t.TaskMetadata.Status != status {
-				continue
-			}
-			if !all && t.TaskMetadata.ProjectID != "" && hiddenProjectIDs[t.TaskMetadata.ProjectID] {
-				continue
+		// render scans and prints once; --watch repeats it on every change
+		render := func() error {
+			scanner := denote.NewScanner(cfg.NotesDirectory)
+
+			// Get all projects for name lookup and hidden status
+			projects, _ := scanner.FindProjects()
+			projectNames := make(map[string]string)
+			hiddenProjectIDs := make(map[string]bool)
+			for _, p := range projects {
+				idStr := strconv.Itoa(p.IndexID)
+				projectNames[idStr] = p.Title
+				if p.ProjectMetadata.Status == denote.ProjectStatusPaused ||
+					p.ProjectMetadata.Status == denote.ProjectStatusCancelled ||
+					p.HasNotBegun() {
+					hiddenProjectIDs[idStr] = true
+				}
 			}
 
-			filterArea := area
-			if filterArea == "" {
-				filterArea = globalFlags.Area
+			// Get all tasks
+			allTasks, err := scanTasks(scanner, withArchived)
+			if err != nil {
+				return err
 			}
-			if filterArea != "" && t.TaskMetadata.Area != filterArea {
-				continue
+			warnParseErrors(scanner)
+
+			// Filter tasks
+			var tasks []denote.Task
+			for _, t := range allTasks {
+				// --exclude-status replaces the default open-only filter
+				if !all && status == "" && len(excluded) == 0 && !denote.IsOpenTaskStatus(t.TaskMetadata.Status) {
+					continue
+				}
+				if status != "" && t.TaskMetadata.Status != status {
+					continue
+				}
+				if excluded[t.TaskMetadata.Status] {
+					continue
+				}
+				if !all && t.TaskMetadata.ProjectID != "" && hiddenProjectIDs[t.TaskMetadata.ProjectID] {
+					continue
+				}
+
+				filterArea := area
+				if filterArea == "" {
+					filterArea = globalFlags.Area
+				}
+				if filterArea != "" && t.TaskMetadata.Area != filterArea {
+					continue
+				}
+				if priority != "" && t.TaskMetadata.Priority != priority {
+					continue
+				}
+				if project != "" && t.TaskMetadata.ProjectID != project {
+					continue
+				}
+				if overdue && !isOverdueAfterGrace(t.TaskMetadata.DueDate, grace) {
+					continue
+				}
+				if soon && !denote.IsDueSoon(t.TaskMetadata.DueDate, horizon) {
+					continue
+				}
+				if !hasAllTags(t, tags) {
+					continue
+				}
+				if search != "" {
+					if !strings.Contains(strings.ToLower(t.Content), strings.ToLower(search)) {
+						continue
+					}
+				}
+				if plannedFor != "" {
+					switch strings.ToLower(plannedFor) {
+					case "any":
+						if t.PlannedFor == "" {
+							continue
+						}
+					case "today":
+						if t.PlannedFor != time.Now().Format("2006-01-02") {
+							continue
+						}
+					default:
+						if t.PlannedFor != plannedFor {
+							continue
+						}
+					}
+				}
+				tasks = append(tasks, *t)
 			}
-			if priority != "" && t.TaskMetadata.Priority != priority {
-				continue
+
+			if countOnly {
+				return printCount(len(tasks))
 			}
-			if project != "" && t.TaskMetadata.ProjectID != project {
-				continue
+
+			sortTasks(tasks, sortBy, reverse, projectNames)
+			matched := len(tasks)
+			tasks = limitTasks(tasks, limit)
+
+			// Totals cover exactly the filtered tasks being shown
+			var totals *listTotals
+			if showTotals {
+				totals = &listTotals{Count: len(tasks)}
+				for _, t := range tasks {
+					totals.Estimate += t.TaskMetadata.Estimate
+					if t.TaskMetadata.Status != denote.TaskStatusDone &&
+						t.TaskMetadata.Status != denote.TaskStatusDropped &&
+						isOverdueAfterGrace(t.TaskMetadata.DueDate, grace) {
+						totals.Overdue++
+					}
+				}
 			}
-			if overdue && !denote.IsOverdue(t.TaskMetadata.DueDate) {
-				continue
+
+			if tmpl != nil {
+				return writeTasksTemplate(os.Stdout, tmpl, tasks)
 			}
-			if soon && !denote.IsDueSoon(t.TaskMetadata.DueDate, cfg.SoonHorizon) {
-				continue
+			if outFormat == formatCSV {
+				return writeTasksCSV(os.Stdout, tasks, projectNames)
 			}
-			if tag != "" && !t.HasTag(tag) {
-				continue
+			if outFormat == formatJSONL {
+				return writeTasksJSONL(os.Stdout, tasks, projectNames, jsonComputed)
 			}
-			if search != "" {
-				if !strings.Contains(strings.ToLower(t.Content), strings.ToLower(search)) {
-					continue
+
+			if outFormat == formatJSON {
+				type TaskJSON struct {
+					denote.Task
+					ProjectName string `json:"project_name,omitempty"`
+					// Readiness, only with --json-computed
+					Blocked      *bool    `json:"blocked,omitempty"`
+					OpenBlockers []string `json:"open_blockers,omitempty"`
 				}
-			}
-			if plannedFor != "" {
-				switch strings.ToLower(plannedFor) {
-				case "any":
-					if t.PlannedFor == "" {
-						continue
+				type Output struct {
+					SchemaVersion int         `json:"schema_version"`
+					Tasks         []TaskJSON  `json:"tasks"`
+					Count         int         `json:"count"`
+					Total         int         `json:"total"`
+					Totals        *listTotals `json:"totals,omitempty"`
+				}
+
+				jsonTasks := make([]TaskJSON, len(tasks))
+				for i, t := range tasks {
+					jsonTasks[i] = TaskJSON{
+						Task:        t,
+						ProjectName: projectNames[t.ProjectID],
 					}
-				case "today":
-					if t.PlannedFor != time.Now().Format("2006-01-02") {
-						continue
+					if jsonComputed {
+						blocked := t.IsBlocked()
+						jsonTasks[i].Blocked = &blocked
+						jsonTasks[i].OpenBlockers = t.OpenBlockers
 					}
-				default:
-					if t.PlannedFor != plannedFor {
-						continue
+				}
+
+				var output interface{} = Output{SchemaVersion: jsonSchemaVersion, Tasks: jsonTasks, Count: len(tasks), Total: matched, Totals: totals}
+				if groupBy != "" {
+					// tasks stays alongside groups so the envelope keeps its
+					// schema_version 1 shape
+					groups := make(map[string][]TaskJSON)
+					for _, jt := range jsonTasks {
+						name := taskGroupName(&jt.Task, groupBy, projectNames)
+						groups[name] = append(groups[name], jt)
 					}
+					output = struct {
+						SchemaVersion int                   `json:"schema_version"`
+						Tasks         []TaskJSON            `json:"tasks"`
+						GroupBy       string                `json:"group_by"`
+						Groups        map[string][]TaskJSON `json:"groups"`
+						Count         int                   `json:"count"`
+						Total         int                   `json:"total"`
+						Totals        *listTotals           `json:"totals,omitempty"`
+					}{jsonSchemaVersion, jsonTasks, groupBy, groups, len(tasks), matched, totals}
+				}
+				jsonBytes, err := json.MarshalIndent(output, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal JSON: %w", err)
 				}
+				fmt.Println(string(jsonBytes))
+				return nil
 			}
-			tasks = append(tasks, *t)
-		}
 
-		sortTasks(tasks, sortBy, reverse)
-
-		if globalFlags.JSON {
-			type TaskJSON struct {
-				denote.Task
-				ProjectName string `json:"project_name,omitempty"`
+			if globalFlags.NoColor || color.NoColor {
+				color.NoColor = true
 			}
-			type Output struct {
-				Tasks []TaskJSON `json:"tasks"`
-				Count int        `json:"count"`
+
+			doneColor := color.New(color.FgGreen)
+			overdueColor := color.New(color.FgRed, color.Bold)
+			priorityHighColor := color.New(color.FgRed, color.Bold)
+			priorityMedColor := color.New(color.FgYellow)
+
+			if !globalFlags.Quiet {
+				fmt.Printf("%s:\n\n", tasksHeader(len(tasks), matched))
+			}
+
+			ic := icons(cfg)
+			printTask := func(t denote.Task) {
+				statusIcon := ic.taskStatus(t.TaskMetadata.Status)
+
+				priorityStr := ic.priorityMarker("")
+				if t.TaskMetadata.Priority != "" {
+					pStr := ic.priorityMarker(t.TaskMetadata.Priority)
+					switch t.TaskMetadata.Priority {
+					case "p1":
+						priorityStr = priorityHighColor.Sprint(pStr)
+					case "p2":
+						priorityStr = priorityMedColor.Sprint(pStr)
+					default:
+						priorityStr = pStr
+					}
+				}
+
+				dueStr := "            "
+				if t.TaskMetadata.DueDate != "" {
+					ds := fmt.Sprintf("[%s]", t.TaskMetadata.DueDate)
+					if isOverdueAfterGrace(t.TaskMetadata.DueDate, grace) {
+						dueStr = overdueColor.Sprint(ds)
+					} else {
+						dueStr = ds
+					}
+				}
+
+				title := t.Title
+				if t.TaskMetadata.Recur != "" {
+					title = ic.recurring + " " + title
+				}
+				if t.IsBlocked() {
+					title = ic.blocked + " " + title
+				}
+				// Delegated tasks name who has them unless the column shows it
+				if !showAssignee && t.TaskMetadata.Status == denote.TaskStatusDelegated && t.TaskMetadata.Assignee != "" {
+					title += " @" + t.TaskMetadata.Assignee
+				}
+				title = padColumn(title, 50)
+				areaStr := padColumn(t.TaskMetadata.Area, 10)
+
+				assigneeCol := ""
+				if showAssignee {
+					assigneeCol = padColumn(t.TaskMetadata.Assignee, 10) + " "
+				}
+
+				projectName := ""
+				if t.TaskMetadata.ProjectID != "" {
+					if name, ok := projectNames[t.TaskMetadata.ProjectID]; ok && name != "" {
+						projectName = ic.arrow + " " + name
+					} else {
+						projectName = ic.arrow + " " + t.TaskMetadata.ProjectID
+					}
+				}
+
+				line := fmt.Sprintf("%3d %s %s %s  %s %s %s%s",
+					t.IndexID,
+					statusIcon,
+					priorityStr,
+					dueStr,
+					title,
+					areaStr,
+					assigneeCol,
+					projectName,
+				)
+
+				if t.TaskMetadata.Status == denote.TaskStatusDone {
+					fmt.Println(doneColor.Sprint(line))
+				} else {
+					fmt.Println(line)
+				}
 			}
 
-			jsonTasks := make([]TaskJSON, len(tasks))
-			for i, t := range tasks {
-				jsonTasks[i] = TaskJSON{
-					Task:        t,
-					ProjectName: projectNames[t.ProjectID],
+			if groupBy != "" {
+				for i, g := range groupTasks(tasks, groupBy, projectNames) {
+					if i > 0 {
+						fmt.Println()
+					}
+					fmt.Printf("%s (%d):\n", g.Name, len(g.Tasks))
+					for _, t := range g.Tasks {
+						printTask(t)
+					}
+				}
+			} else {
+				for _, t := range tasks {
+					printTask(t)
 				}
 			}
 
-			output := Output{Tasks: jsonTasks, Count: len(tasks)}
-			jsonBytes, err := json.MarshalIndent(output, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal JSON: %w", err)
+			if totals != nil {
+				fmt.Printf("\nTotal: %d tasks, %d overdue, estimate %s\n",
+					totals.Count, totals.Overdue, formatEstimate(cfg, totals.Estimate))
 			}
-			fmt.Println(string(jsonBytes))
+
 			return nil
 		}
 
-		if globalFlags.NoColor || color.NoColor {
-			color.NoColor = true
+		if watch {
+			return watchTaskList(cfg.NotesDirectory, render)
 		}
+		return render()
+	}
 
-		doneColor := color.New(color.FgGreen)
-		overdueColor := color.New(color.FgRed, color.Bold)
-		priorityHighColor := color.New(color.FgRed, color.Bold)
-		priorityMedColor := color.New(color.FgYellow)
+	return cmd
+}
 
-		if !globalFlags.Quiet {
-			fmt.Printf("Tasks (%d):\n\n", len(tasks))
+// hasAllTags reports whether t matches every --tag pattern.
+func hasAllTags(t *denote.Task, patterns []string) bool {
+	for _, pattern := range patterns {
+		if !t.HasTagMatching(pattern) {
+			return false
 		}
+	}
+	return true
+}
 
-		for _, t := range tasks {
-			statusIcon := "○"
-			switch t.TaskMetadata.Status {
-			case denote.TaskStatusDone:
-				statusIcon = "✓"
-			case denote.TaskStatusPaused:
-				statusIcon = "⏸"
-			case denote.TaskStatusDelegated:
-				statusIcon = "→"
-			case denote.TaskStatusDropped:
-				statusIcon = "⨯"
-			}
-
-			priorityStr := "    "
-			if t.TaskMetadata.Priority != "" {
-				pStr := fmt.Sprintf("[%s]", t.TaskMetadata.Priority)
-				switch t.TaskMetadata.Priority {
-				case "p1":
-					priorityStr = priorityHighColor.Sprint(pStr)
-				case "p2":
-					priorityStr = priorityMedColor.Sprint(pStr)
-				default:
-					priorityStr = pStr
-				}
-			}
+// scanTasks finds the tasks list and query work on, adding those in
+// archive/ when withArchived is set.
+func scanTasks(scanner *denote.Scanner, withArchived bool) ([]*denote.Task, error) {
+	tasks, err := scanner.FindTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan directory: %v", err)
+	}
+	if withArchived {
+		archived, err := scanner.FindArchivedTasks()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan archive: %v", err)
+		}
+		tasks = append(tasks, archived...)
+	}
+	return tasks, nil
+}
 
-			dueStr := "            "
-			if t.TaskMetadata.DueDate != "" {
-				ds := fmt.Sprintf("[%s]", t.TaskMetadata.DueDate)
-				if denote.IsOverdue(t.TaskMetadata.DueDate) {
-					dueStr = overdueColor.Sprint(ds)
-				} else {
-					dueStr = ds
-				}
-			}
+// limitTasks keeps the first limit tasks; a limit of 0 or less keeps all.
+func limitTasks(tasks []denote.Task, limit int) []denote.Task {
+	if limit > 0 && len(tasks) > limit {
+		return tasks[:limit]
+	}
+	return tasks
+}
 
-			title := t.Title
-			if t.TaskMetadata.Recur != "" {
-				title = "↻ " + title
-			}
-			if len(title) > 50 {
-				title = title[:47] + "..."
-			}
+// printCount prints a bare match count for --count, or {"count": N} with
+// --json. --limit does not apply: the count is every task that matched.
+func printCount(n int) error {
+	if globalFlags.JSON {
+		data, err := json.Marshal(map[string]int{"count": n})
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	fmt.Println(n)
+	return nil
+}
 
-			areaStr := ""
-			if t.TaskMetadata.Area != "" {
-				areaStr = t.TaskMetadata.Area
-				if len(areaStr) > 10 {
-					areaStr = areaStr[:7] + "..."
-				}
-			}
+// tasksHeader titles list and query output, noting when --limit hid tasks.
+func tasksHeader(shown, total int) string {
+	if shown < total {
+		return fmt.Sprintf("Tasks (showing %d of %d)", shown, total)
+	}
+	return fmt.Sprintf("Tasks (%d)", total)
+}
 
-			projectName := ""
-			if t.TaskMetadata.ProjectID != "" {
-				if name, ok := projectNames[t.TaskMetadata.ProjectID]; ok && name != "" {
-					projectName = "→ " + name
-				} else {
-					projectName = "→ " + t.TaskMetadata.ProjectID
-				}
-			}
+// noGroup heads the --group-by section of tasks with no value for the key.
+const noGroup = "(none)"
 
-			line := fmt.Sprintf("%3d %s %s %s  %-50s %-10s %s",
-				t.IndexID,
-				statusIcon,
-				priorityStr,
-				dueStr,
-				title,
-				areaStr,
-				projectName,
-			)
+// taskGroup is one section of list --group-by output.
+type taskGroup struct {
+	Name  string
+	Tasks []denote.Task
+}
 
-			if t.TaskMetadata.Status == denote.TaskStatusDone {
-				fmt.Println(doneColor.Sprint(line))
-			} else {
-				fmt.Println(line)
+// taskGroupName returns the --group-by section t belongs in: its area,
+// project name (index_id when the project is unknown), priority, or status,
+// or noGroup when unset.
+func taskGroupName(t *denote.Task, groupBy string, projectNames map[string]string) string {
+	var name string
+	switch groupBy {
+	case "area":
+		name = t.TaskMetadata.Area
+	case "project":
+		name = t.TaskMetadata.ProjectID
+		if n := projectNames[name]; n != "" {
+			name = n
+		}
+	case "priority":
+		name = t.TaskMetadata.Priority
+	case "status":
+		name = t.TaskMetadata.Status
+	}
+	if name == "" {
+		return noGroup
+	}
+	return name
+}
+
+// groupTasks splits tasks into --group-by sections, keeping their order
+// within each section. Priorities and statuses appear in their natural
+// order, areas and projects alphabetically, and noGroup always comes last.
+// Sections with no tasks are never returned.
+func groupTasks(tasks []denote.Task, groupBy string, projectNames map[string]string) []taskGroup {
+	index := make(map[string]int)
+	var groups []taskGroup
+	for _, t := range tasks {
+		name := taskGroupName(&t, groupBy, projectNames)
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, taskGroup{Name: name})
+		}
+		groups[i].Tasks = append(groups[i].Tasks, t)
+	}
+
+	rank := func(name string) int {
+		order := map[string][]string{
+			"priority": {"p1", "p2", "p3"},
+			"status": {denote.TaskStatusOpen, denote.TaskStatusInProgress, denote.TaskStatusPaused,
+				denote.TaskStatusDelegated, denote.TaskStatusDone, denote.TaskStatusDropped},
+		}[groupBy]
+		for i, v := range order {
+			if v == name {
+				return i
 			}
 		}
+		return len(order)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].Name, groups[j].Name
+		if (a == noGroup) != (b == noGroup) {
+			return b == noGroup
+		}
+		if ra, rb := rank(a), rank(b); ra != rb {
+			return ra < rb
+		}
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	return groups
+}
 
-		return nil
+// listTotals summarizes the tasks shown by list --totals.
+type listTotals struct {
+	Count    int `json:"count"`
+	Overdue  int `json:"overdue"`
+	Estimate int `json:"estimate"`
+}
+
+// truncateColumn shortens s to fit a list column of width terminal cells.
+// Widths are display widths, so wide (CJK, emoji) characters count as two,
+// and s is only ever cut between runes.
+func truncateColumn(s string, width int) string {
+	if runewidth.StringWidth(s) > width {
+		return runewidth.Truncate(s, width, "...")
 	}
+	return s
+}
 
-	return cmd
+// padColumn truncates s like truncateColumn, then pads it with spaces to
+// exactly width terminal cells. Use it instead of %-Ns, which pads by rune
+// count and misaligns wide characters.
+func padColumn(s string, width int) string {
+	return runewidth.FillRight(truncateColumn(s, width), width)
+}
+
+// isOverdueAfterGrace reports whether a due date is more than grace days in
+// the past. A grace of 0 matches denote.IsOverdue.
+func isOverdueAfterGrace(dueDate string, grace int) bool {
+	return overdueAfterGraceAt(dueDate, grace, time.Now())
+}
+
+// overdueAfterGraceAt is isOverdueAfterGrace as of now. It compares calendar
+// dates, since a day across a DST change is not 24 hours long.
+func overdueAfterGraceAt(dueDate string, grace int, now time.Time) bool {
+	due, err := time.ParseInLocation("2006-01-02", dueDate, now.Location())
+	if err != nil {
+		return false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return due.AddDate(0, 0, grace).Before(today)
 }
 
-// sortTasks sorts tasks by the specified field
-func sortTasks(tasks []denote.Task, sortBy string, reverse bool) {
+// sortTasks sorts tasks by the specified field. Ties are broken by index_id
+// so that text and JSON output are stable across runs.
+func sortTasks(tasks []denote.Task, sortBy string, reverse bool, projectNames map[string]string) {
 	sort.Slice(tasks, func(i, j int) bool {
-		var less bool
+		a, b := &tasks[i], &tasks[j]
+		if reverse {
+			a, b = b, a
+		}
 
 		switch sortBy {
+		case "project":
+			// Grouped by project name (tasks without a project last),
+			// then priority, then due date within each project
+			pa, pb := a.TaskMetadata.ProjectID, b.TaskMetadata.ProjectID
+			if pa != pb {
+				if pa == "" {
+					return false
+				}
+				if pb == "" {
+					return true
+				}
+				na, nb := strings.ToLower(projectNames[pa]), strings.ToLower(projectNames[pb])
+				if na != nb {
+					return na < nb
+				}
+				return pa < pb
+			}
+			if pra, prb := priorityValue(a.TaskMetadata.Priority), priorityValue(b.TaskMetadata.Priority); pra != prb {
+				return pra < prb
+			}
+			if da, db := a.TaskMetadata.DueDate, b.TaskMetadata.DueDate; da != db {
+				if da == "" {
+					return false
+				}
+				if db == "" {
+					return true
+				}
+				return da < db
+			}
+
 		case "priority":
-			pi := priorityValue(tasks[i].TaskMetadata.Priority)
-			pj := priorityValue(tasks[j].TaskMetadata.Priority)
-			less = pi < pj
+			pa := priorityValue(a.TaskMetadata.Priority)
+			pb := priorityValue(b.TaskMetadata.Priority)
+			if pa != pb {
+				return pa < pb
+			}
 
 		case "due":
-			di := tasks[i].TaskMetadata.DueDate
-			dj := tasks[j].TaskMetadata.DueDate
-			if di == "" && dj == "" {
-				less = false
-			} else if di == "" {
-				less = false
-			} else if dj == "" {
-				less = true
-			} else {
-				less = di < dj
+			da := a.TaskMetadata.DueDate
+			db := b.TaskMetadata.DueDate
+			if da != db {
+				if da == "" {
+					return false
+				}
+				if db == "" {
+					return true
+				}
+				return da < db
 			}
 
 		case "created":
-			less = tasks[i].ID < tasks[j].ID
+			if a.ID != b.ID {
+				return a.ID < b.ID
+			}
+
+		case "age":
+			// Oldest first by real creation time, which ID order only
+			// approximates once legacy Denote IDs are mixed in
+			if ta, tb := a.CreationTime(), b.CreationTime(); !ta.Equal(tb) {
+				if ta.IsZero() {
+					return false
+				}
+				if tb.IsZero() {
+					return true
+				}
+				return ta.Before(tb)
+			}
 
 		case "modified":
 			fallthrough
 		default:
-			less = tasks[i].ModTime.After(tasks[j].ModTime)
+			if !a.ModTime.Equal(b.ModTime) {
+				return a.ModTime.After(b.ModTime)
+			}
 		}
 
-		if reverse {
-			return !less
-		}
-		return less
+		return a.IndexID < b.IndexID
 	})
 }
 
@@ -604,38 +1274,110 @@ func priorityValue(p string) int {
 	}
 }
 
-// parseTaskIdentifiers parses task ID arguments, returning integer IDs and
-// string entity IDs (ULIDs) separately. Supports ranges and comma-separated
-// lists for integer IDs.
-func parseTaskIdentifiers(args []string) (intIDs []int, entityIDs []string, err error) {
+// projectTaskRef addresses the Nth task of a project, written @<project>/<n>.
+// Project is the project's index_id or ULID.
+type projectTaskRef struct {
+	Project  string
+	Position int
+}
+
+func (r projectTaskRef) String() string {
+	return fmt.Sprintf("@%s/%d", r.Project, r.Position)
+}
+
+// parseIntRange parses "N-M" into its bounds. ok is false when part is not
+// a pair of integers, so the caller can try other forms.
+func parseIntRange(part string) (start, end int, ok bool, err error) {
+	if !strings.Contains(part, "-") || strings.HasPrefix(part, "-") {
+		return 0, 0, false, nil
+	}
+	rangeParts := strings.Split(part, "-")
+	if len(rangeParts) != 2 {
+		return 0, 0, false, nil
+	}
+	start, errS := strconv.Atoi(strings.TrimSpace(rangeParts[0]))
+	end, errE := strconv.Atoi(strings.TrimSpace(rangeParts[1]))
+	if errS != nil || errE != nil {
+		return 0, 0, false, nil
+	}
+	if start > end {
+		return 0, 0, true, fmt.Errorf("invalid range: %d > %d", start, end)
+	}
+	return start, end, true, nil
+}
+
+// parseProjectTaskRefs parses "@<project>/<n>" or "@<project>/<n>-<m>".
+func parseProjectTaskRefs(part string) ([]projectTaskRef, error) {
+	project, positions, found := strings.Cut(strings.TrimPrefix(part, "@"), "/")
+	if !found || project == "" || positions == "" {
+		return nil, fmt.Errorf("invalid project task reference %q: want @<project>/<n> or @<project>/<n>-<m>", part)
+	}
+
+	start, end, ok, err := parseIntRange(positions)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		n, err := strconv.Atoi(positions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project task reference %q: want @<project>/<n> or @<project>/<n>-<m>", part)
+		}
+		start, end = n, n
+	}
+	if start < 1 {
+		return nil, fmt.Errorf("invalid project task reference %q: positions start at 1", part)
+	}
+
+	var refs []projectTaskRef
+	for i := start; i <= end; i++ {
+		refs = append(refs, projectTaskRef{Project: project, Position: i})
+	}
+	return refs, nil
+}
+
+// parseTaskIdentifiers parses task ID arguments, returning integer IDs,
+// string entity IDs (ULIDs), and project-scoped references separately.
+// Supports ranges and comma-separated lists for integer IDs. An argument
+// starting with @ addresses tasks by position within a project
+// (@12/1-3); each comma-separated item is parsed on its own, so a bare
+// number after a scoped reference is still a global index_id.
+func parseTaskIdentifiers(args []string) (intIDs []int, entityIDs []string, projectRefs []projectTaskRef, err error) {
 	seenInt := make(map[int]bool)
 	seenStr := make(map[string]bool)
+	seenRef := make(map[projectTaskRef]bool)
 
 	for _, arg := range args {
 		parts := strings.Split(arg, ",")
 		for _, part := range parts {
 			part = strings.TrimSpace(part)
 
+			if strings.HasPrefix(part, "@") {
+				refs, err := parseProjectTaskRefs(part)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				for _, ref := range refs {
+					if !seenRef[ref] {
+						projectRefs = append(projectRefs, ref)
+						seenRef[ref] = true
+					}
+				}
+				continue
+			}
+
 			// Check if it looks like an integer range (e.g. "1-5")
-			if strings.Contains(part, "-") && !strings.HasPrefix(part, "-") {
-				rangeParts := strings.Split(part, "-")
-				if len(rangeParts) == 2 {
-					start, errS := strconv.Atoi(strings.TrimSpace(rangeParts[0]))
-					end, errE := strconv.Atoi(strings.TrimSpace(rangeParts[1]))
-					if errS == nil && errE == nil {
-						if start > end {
-							return nil, nil, fmt.Errorf("invalid range: %d > %d", start, end)
-						}
-						for i := start; i <= end; i++ {
-							if !seenInt[i] {
-								intIDs = append(intIDs, i)
-								seenInt[i] = true
-							}
-						}
-						continue
+			start, end, ok, err := parseIntRange(part)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if ok {
+				for i := start; i <= end; i++ {
+					if !seenInt[i] {
+						intIDs = append(intIDs, i)
+						seenInt[i] = true
 					}
 				}
-				// Not a valid integer range — treat as entity ID (ULIDs contain hyphens? No, but fall through)
+				continue
 			}
 
 			// Try as integer
@@ -655,41 +1397,48 @@ func parseTaskIdentifiers(args []string) (intIDs []int, entityIDs []string, err
 	}
 
 	sort.Ints(intIDs)
-	return intIDs, entityIDs, nil
+	return intIDs, entityIDs, projectRefs, nil
 }
 
 // parseTaskIDs parses task ID arguments (supports ranges and lists).
 // Only returns integer IDs — use parseTaskIdentifiers for ULID support.
 func parseTaskIDs(args []string) ([]int, error) {
-	intIDs, entityIDs, err := parseTaskIdentifiers(args)
+	intIDs, entityIDs, projectRefs, err := parseTaskIdentifiers(args)
 	if err != nil {
 		return nil, err
 	}
 	if len(entityIDs) > 0 {
 		return nil, fmt.Errorf("invalid task ID: %s", entityIDs[0])
 	}
+	if len(projectRefs) > 0 {
+		return nil, fmt.Errorf("invalid task ID: %s", projectRefs[0])
+	}
 	return intIDs, nil
 }
 
 func taskUpdateCommand(cfg *config.Config) *Command {
 	var (
-		title        string
-		priority     string
-		due          string
-		begin        string
-		area         string
-		project      string
-		estimate     int
-		status       string
-		recur        string
-		tags         string
-		planFor      string
-		addPerson    string
-		removePerson string
-		addTask      string
-		removeTask   string
-		addIdea      string
-		removeIdea   string
+		title         string
+		priority      string
+		due           string
+		begin         string
+		area          string
+		project       string
+		estimate      int
+		status        string
+		recur         string
+		tags          string
+		planFor       string
+		addPerson     string
+		removePerson  string
+		addTask       string
+		removeTask    string
+		addIdea       string
+		removeIdea    string
+		addBlocker    string
+		removeBlocker string
+		assignee      string
+		ignoreErrors  bool
 	)
 
 	cmd := &Command{
@@ -702,12 +1451,13 @@ func taskUpdateCommand(cfg *config.Config) *Command {
 	cmd.Flags.StringVar(&title, "title", "", "Set title")
 	cmd.Flags.StringVar(&priority, "p", "", "Set priority (p1, p2, p3)")
 	cmd.Flags.StringVar(&priority, "priority", "", "Set priority (p1, p2, p3)")
-	cmd.Flags.StringVar(&due, "due", "", "Set due date")
-	cmd.Flags.StringVar(&begin, "begin", "", "Set begin/start date")
+	cmd.Flags.StringVar(&due, "due", "", "Set due date; +3d/-1w shifts the current one (use 'none' to clear)")
+	cmd.Flags.StringVar(&begin, "begin", "", "Set begin/start date (use 'none' to clear)")
 	cmd.Flags.StringVar(&area, "area", "", "Set area")
 	cmd.Flags.StringVar(&project, "project", "", "Set project")
-	cmd.Flags.IntVar(&estimate, "estimate", -1, "Set time estimate")
-	cmd.Flags.StringVar(&status, "status", "", "Set status (open, done, paused, delegated, dropped)")
+	cmd.Flags.IntVar(&estimate, "estimate", -1, "Set time estimate (1, 2, 3, 5, 8, 13; 0 to clear)")
+	cmd.Flags.StringVar(&status, "status", "", "Set status (open, in_progress, done, paused, delegated, dropped)")
+	cmd.Flags.StringVar(&assignee, "assignee", "", "Set who the task is delegated to (use 'none' to clear)")
 	cmd.Flags.StringVar(&recur, "recur", "", "Set recurrence (use 'none' to clear)")
 	cmd.Flags.StringVar(&tags, "tags", "", "Set tags (comma-separated, use 'none' to clear)")
 	cmd.Flags.StringVar(&planFor, "plan-for", "", "Set planned_for date (natural language, YYYY-MM-DD, or 'none' to clear)")
@@ -718,12 +1468,25 @@ func taskUpdateCommand(cfg *config.Config) *Command {
 	cmd.Flags.StringVar(&removeTask, "remove-task", "", "Remove related task (ULID)")
 	cmd.Flags.StringVar(&addIdea, "add-idea", "", "Add related idea (ULID)")
 	cmd.Flags.StringVar(&removeIdea, "remove-idea", "", "Remove related idea (ULID)")
+	cmd.Flags.StringVar(&addBlocker, "add-blocker", "", "Add a task that must finish first (ULID)")
+	cmd.Flags.StringVar(&removeBlocker, "remove-blocker", "", "Remove a blocking task (ULID)")
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
 
 	cmd.Run = func(c *Command, args []string) error {
 		if len(args) == 0 {
 			return fmt.Errorf("task IDs required")
 		}
 
+		if err := validateTaskFields(priority, status); err != nil {
+			return err
+		}
+		// -1 means --estimate was not given
+		if estimate != -1 {
+			if err := validateEstimate(estimate); err != nil {
+				return err
+			}
+		}
+
 		var recurPattern string
 		var clearRecur bool
 		if recur != "" {
@@ -738,46 +1501,23 @@ func taskUpdateCommand(cfg *config.Config) *Command {
 			}
 		}
 
-		intIDs, entityIDs, err := parseTaskIdentifiers(args)
-		if err != nil {
-			return err
+		// A blocker must be a task that exists now; removing one that has
+		// since been deleted is still allowed
+		if addBlocker != "" {
+			if _, err := task.FindTaskByEntityID(cfg.NotesDirectory, addBlocker); err != nil {
+				return fmt.Errorf("blocker task %s not found", addBlocker)
+			}
 		}
 
-		scanner := denote.NewScanner(cfg.NotesDirectory)
-		allTasks, err := scanner.FindTasks()
+		tasksToUpdate, failed, err := resolveTasks(cfg.NotesDirectory, args)
 		if err != nil {
-			return fmt.Errorf("failed to scan directory: %v", err)
-		}
-
-		tasksByID := make(map[int]*denote.Task)
-		tasksByEntityID := make(map[string]*denote.Task)
-		for _, t := range allTasks {
-			tasksByID[t.IndexID] = t
-			tasksByEntityID[t.ID] = t
+			return err
 		}
+		requested := len(tasksToUpdate) + failed
 
 		// Track updated tasks for JSON output
 		var updatedTasks []*denote.Task
 
-		// Collect tasks to update from both integer and entity IDs
-		var tasksToUpdate []*denote.Task
-		for _, id := range intIDs {
-			t, ok := tasksByID[id]
-			if !ok {
-				fmt.Fprintf(os.Stderr, "Task with ID %d not found\n", id)
-				continue
-			}
-			tasksToUpdate = append(tasksToUpdate, t)
-		}
-		for _, eid := range entityIDs {
-			t, ok := tasksByEntityID[eid]
-			if !ok {
-				fmt.Fprintf(os.Stderr, "Task with ID %s not found\n", eid)
-				continue
-			}
-			tasksToUpdate = append(tasksToUpdate, t)
-		}
-
 		updated := 0
 		for _, t := range tasksToUpdate {
 
@@ -791,22 +1531,22 @@ func taskUpdateCommand(cfg *config.Config) *Command {
 				changed = true
 			}
 			if due != "" {
-				parsedDue, err := denote.ParseNaturalDate(due)
+				dueChanged, err := setDateField(&t.TaskMetadata.DueDate, due)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Invalid due date for task ID %d: %v\n", t.IndexID, err)
+					failed++
 					continue
 				}
-				t.TaskMetadata.DueDate = parsedDue
-				changed = true
+				changed = changed || dueChanged
 			}
 			if begin != "" {
-				parsedBegin, err := denote.ParseNaturalDate(begin)
+				beginChanged, err := setDateField(&t.TaskMetadata.StartDate, begin)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Invalid begin date for task ID %d: %v\n", t.IndexID, err)
+					failed++
 					continue
 				}
-				t.TaskMetadata.StartDate = parsedBegin
-				changed = true
+				changed = changed || beginChanged
 			}
 			if area != "" {
 				t.TaskMetadata.Area = area
@@ -816,11 +1556,13 @@ func taskUpdateCommand(cfg *config.Config) *Command {
 				projectNum, err := strconv.Atoi(project)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Invalid project ID for task %d: %s (must be numeric)\n", t.IndexID, project)
+					failed++
 					continue
 				}
 				p, err := task.FindProjectByID(cfg.NotesDirectory, projectNum)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Project %d not found for task %d\n", projectNum, t.IndexID)
+					failed++
 					continue
 				}
 				t.TaskMetadata.ProjectID = strconv.Itoa(p.IndexID)
@@ -831,7 +1573,15 @@ func taskUpdateCommand(cfg *config.Config) *Command {
 				changed = true
 			}
 			if status != "" {
-				t.TaskMetadata.Status = status
+				t.SetStatus(status)
+				changed = true
+			}
+			if assignee != "" {
+				if strings.ToLower(assignee) == "none" {
+					t.TaskMetadata.Assignee = ""
+				} else {
+					t.TaskMetadata.Assignee = assignee
+				}
 				changed = true
 			}
 			if clearRecur {
@@ -878,6 +1628,7 @@ func taskUpdateCommand(cfg *config.Config) *Command {
 					parsed, err := denote.ParseNaturalDate(planFor)
 					if err != nil {
 						fmt.Fprintf(os.Stderr, "Invalid --plan-for date for task ID %d: %v\n", t.IndexID, err)
+						failed++
 						continue
 					}
 					t.PlannedFor = parsed
@@ -916,10 +1667,24 @@ func taskUpdateCommand(cfg *config.Config) *Command {
 				acore.UnsyncRelation(t.Type, t.ID, removeIdea)
 				changed = true
 			}
+			if addBlocker != "" {
+				if addBlocker == t.ID {
+					fmt.Fprintf(os.Stderr, "Task ID %d cannot block itself\n", t.IndexID)
+					failed++
+					continue
+				}
+				acore.AddRelation(&t.BlockedBy, addBlocker)
+				changed = true
+			}
+			if removeBlocker != "" {
+				acore.RemoveRelation(&t.BlockedBy, removeBlocker)
+				changed = true
+			}
 
 			if changed {
 				if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
 					fmt.Fprintf(os.Stderr, "Failed to update task ID %d: %v\n", t.IndexID, err)
+					failed++
 					continue
 				}
 				updated++
@@ -947,137 +1712,1022 @@ func taskUpdateCommand(cfg *config.Config) *Command {
 				data, _ := json.MarshalIndent(results, "", "  ")
 				fmt.Println(string(data))
 			}
-			return nil
+			return batchError(failed, requested, ignoreErrors)
 		}
 
 		if updated == 0 && !globalFlags.Quiet {
 			fmt.Println("No tasks updated")
 		}
 
-		return nil
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+func taskDoneCommand(cfg *config.Config) *Command {
+	var (
+		ignoreErrors bool
+		dryRun       bool
+	)
+
+	cmd := &Command{
+		Name:        "done",
+		Usage:       "atask task done <task-ids> [--dry-run] [--ignore-errors]",
+		Description: "Mark tasks as done",
+		Flags:       flag.NewFlagSet("task-done", flag.ExitOnError),
+	}
+
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
+	cmd.Flags.BoolVar(&dryRun, "dry-run", false, "Show each task's status and next recurrence without changing anything")
+
+	cmd.Run = func(c *Command, args []string) error {
+		ic := icons(cfg)
+		if len(args) == 0 {
+			return fmt.Errorf("task IDs required")
+		}
+
+		tasksToUpdate, failed, err := resolveTasks(cfg.NotesDirectory, args)
+		if err != nil {
+			return err
+		}
+		requested := len(tasksToUpdate) + failed
+
+		if dryRun {
+			for _, t := range tasksToUpdate {
+				fmt.Printf("Task ID %d (%s): %s\n", t.IndexID, t.TaskMetadata.Status, t.Title)
+				if t.TaskMetadata.Recur == "" {
+					continue
+				}
+				nextDue, err := nextRecurrenceDue(t)
+				switch {
+				case err != nil:
+					fmt.Printf("  %s %s: %v\n", ic.recurring, t.TaskMetadata.Recur, err)
+				case nextDue == "":
+					fmt.Printf("  %s %s: no next instance (task has no due date)\n", ic.recurring, t.TaskMetadata.Recur)
+				default:
+					fmt.Printf("  %s %s: next instance due %s\n", ic.recurring, t.TaskMetadata.Recur, nextDue)
+				}
+			}
+			return batchError(failed, requested, ignoreErrors)
+		}
+
+		updated := 0
+		for _, t := range tasksToUpdate {
+			t.SetStatus(denote.TaskStatusDone)
+			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to mark task %d as done: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+			updated++
+			if !globalFlags.Quiet {
+				fmt.Printf("%s Task ID %d marked as done: %s\n", ic.done, t.IndexID, t.Title)
+			}
+
+			if err := handleRecurrence(cfg, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to create recurring task for ID %d: %v\n", t.IndexID, err)
+			}
+		}
+
+		if updated == 0 && !globalFlags.Quiet {
+			fmt.Println("No tasks marked as done")
+		}
+
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+// taskReopenCommand reverts done (or dropped) tasks to open
+func taskReopenCommand(cfg *config.Config) *Command {
+	var ignoreErrors bool
+
+	cmd := &Command{
+		Name:        "reopen",
+		Usage:       "atask task reopen <task-ids> [--ignore-errors]",
+		Description: "Set tasks back to open",
+		Flags:       flag.NewFlagSet("task-reopen", flag.ExitOnError),
+	}
+
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
+
+	cmd.Run = func(c *Command, args []string) error {
+		ic := icons(cfg)
+		if len(args) == 0 {
+			return fmt.Errorf("task IDs required")
+		}
+
+		tasksToUpdate, failed, err := resolveTasks(cfg.NotesDirectory, args)
+		if err != nil {
+			return err
+		}
+		requested := len(tasksToUpdate) + failed
+
+		var reopened []*denote.Task
+		for _, t := range tasksToUpdate {
+			wasDone := t.TaskMetadata.Status == denote.TaskStatusDone
+			t.SetStatus(denote.TaskStatusOpen)
+			t.TaskMetadata.TodayDate = ""
+			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to reopen task %d: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+			reopened = append(reopened, t)
+			if !globalFlags.JSON && !globalFlags.Quiet {
+				fmt.Printf("%s Task ID %d reopened: %s\n", ic.open, t.IndexID, t.Title)
+			}
+
+			// Completing a recurring task created its next instance; leave
+			// it for the user to keep or delete
+			if wasDone && t.TaskMetadata.Recur != "" {
+				fmt.Fprintf(os.Stderr, "Warning: task %d recurs (%s); its next instance may already exist\n", t.IndexID, t.TaskMetadata.Recur)
+			}
+		}
+
+		if globalFlags.JSON {
+			if reopened == nil {
+				reopened = []*denote.Task{}
+			}
+			data, err := json.MarshalIndent(reopened, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else if len(reopened) == 0 && !globalFlags.Quiet {
+			fmt.Println("No tasks reopened")
+		}
+
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+// taskStartCommand marks tasks as in progress
+func taskStartCommand(cfg *config.Config) *Command {
+	var ignoreErrors bool
+
+	cmd := &Command{
+		Name:        "start",
+		Usage:       "atask task start <task-ids> [--ignore-errors]",
+		Description: "Mark tasks as in progress",
+		Flags:       flag.NewFlagSet("task-start", flag.ExitOnError),
+	}
+
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
+
+	cmd.Run = func(c *Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("task IDs required")
+		}
+
+		tasksToUpdate, failed, err := resolveTasks(cfg.NotesDirectory, args)
+		if err != nil {
+			return err
+		}
+		requested := len(tasksToUpdate) + failed
+
+		updated := 0
+		for _, t := range tasksToUpdate {
+			t.SetStatus(denote.TaskStatusInProgress)
+			// Record when work began, keeping any start date already set
+			if t.TaskMetadata.StartDate == "" {
+				t.TaskMetadata.StartDate = time.Now().Format("2006-01-02")
+			}
+			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to start task %d: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+			updated++
+			if !globalFlags.Quiet {
+				fmt.Printf("%s Task ID %d in progress: %s\n", icons(cfg).taskStatus(denote.TaskStatusInProgress), t.IndexID, t.Title)
+			}
+		}
+
+		if updated == 0 && !globalFlags.Quiet {
+			fmt.Println("No tasks started")
+		}
+
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+// resolveTasks looks up tasks by index_id, ULID, or project-scoped
+// @<project>/<n> arguments (ranges and comma-separated lists allowed).
+// Missing IDs are reported on stderr, skipped, and counted in missing. A
+// task named more than once is returned once.
+func resolveTasks(dir string, args []string) (found []*denote.Task, missing int, err error) {
+	intIDs, entityIDs, projectRefs, err := parseTaskIdentifiers(args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	scanner := denote.NewScanner(dir)
+	allTasks, err := scanner.FindTasks()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan directory: %v", err)
+	}
+
+	tasksByID := make(map[int][]*denote.Task)
+	tasksByEntityID := make(map[string]*denote.Task)
+	for _, t := range allTasks {
+		tasksByID[t.IndexID] = append(tasksByID[t.IndexID], t)
+		tasksByEntityID[t.ID] = t
+	}
+
+	seen := make(map[string]bool)
+	add := func(t *denote.Task) {
+		if !seen[t.ID] {
+			found = append(found, t)
+			seen[t.ID] = true
+		}
+	}
+
+	for _, id := range intIDs {
+		matches := tasksByID[id]
+		switch len(matches) {
+		case 0:
+			fmt.Fprintf(os.Stderr, "Task with ID %d not found\n", id)
+			missing++
+			continue
+		case 1:
+			add(matches[0])
+			continue
+		}
+		// Refuse the whole batch rather than write to a guessed file
+		paths := make([]string, len(matches))
+		for i, t := range matches {
+			paths[i] = t.FilePath
+		}
+		return nil, 0, task.AmbiguousIDError("task", id, paths)
+	}
+	for _, eid := range entityIDs {
+		t, ok := tasksByEntityID[eid]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Task with ID %s not found\n", eid)
+			missing++
+			continue
+		}
+		add(t)
+	}
+
+	if len(projectRefs) == 0 {
+		return found, missing, nil
+	}
+
+	projects, err := scanner.FindProjects()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to scan directory: %v", err)
+	}
+	tasksByProject := projectTaskPositions(projects, allTasks)
+	for _, ref := range projectRefs {
+		tasks, ok := tasksByProject[ref.Project]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Project with ID %s not found\n", ref.Project)
+			missing++
+			continue
+		}
+		if ref.Position > len(tasks) {
+			fmt.Fprintf(os.Stderr, "Task %s not found: project has %d tasks\n", ref, len(tasks))
+			missing++
+			continue
+		}
+		add(tasks[ref.Position-1])
+	}
+	return found, missing, nil
+}
+
+// projectTaskPositions maps each project's index_id and ULID to its tasks
+// in creation order, the order @<project>/<n> positions count in. Every
+// status is included so positions don't shift as tasks are completed.
+func projectTaskPositions(projects []*denote.Project, allTasks []*denote.Task) map[string][]*denote.Task {
+	byIndexID := make(map[string][]*denote.Task)
+	for _, t := range allTasks {
+		if t.TaskMetadata.ProjectID != "" {
+			byIndexID[t.TaskMetadata.ProjectID] = append(byIndexID[t.TaskMetadata.ProjectID], t)
+		}
+	}
+
+	positions := make(map[string][]*denote.Task)
+	for _, p := range projects {
+		indexID := strconv.Itoa(p.IndexID)
+		tasks := byIndexID[indexID]
+		sortProjectTasks(tasks, "created", false)
+		if tasks == nil {
+			tasks = []*denote.Task{}
+		}
+		positions[indexID] = tasks
+		positions[p.ID] = tasks
+	}
+	return positions
+}
+
+// batchError turns per-ID failures of a batch command into a non-zero exit
+// once every other ID has been processed. ignore (--ignore-errors) keeps
+// the exit status zero.
+func batchError(failed, requested int, ignore bool) error {
+	if failed == 0 || ignore {
+		return nil
+	}
+	return fmt.Errorf("%d of %d requested IDs failed", failed, requested)
+}
+
+// priorityLadder orders priorities from lowest to highest; "" means none.
+var priorityLadder = []string{"", denote.PriorityP3, denote.PriorityP2, denote.PriorityP1}
+
+// shiftPriority moves a priority up (step > 0) or down (step < 0) the ladder,
+// stopping at p1 and none.
+func shiftPriority(current string, step int) string {
+	idx := 0
+	for i, p := range priorityLadder {
+		if p == current {
+			idx = i
+			break
+		}
+	}
+	idx += step
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(priorityLadder) {
+		idx = len(priorityLadder) - 1
+	}
+	return priorityLadder[idx]
+}
+
+func taskPromoteCommand(cfg *config.Config) *Command {
+	return taskPriorityShiftCommand(cfg, "promote", "Raise task priority one step (none→p3→p2→p1)", 1)
+}
+
+func taskDemoteCommand(cfg *config.Config) *Command {
+	return taskPriorityShiftCommand(cfg, "demote", "Lower task priority one step (p1→p2→p3→none)", -1)
+}
+
+// taskPriorityShiftCommand builds the promote/demote commands.
+func taskPriorityShiftCommand(cfg *config.Config, name, description string, step int) *Command {
+	var ignoreErrors bool
+
+	cmd := &Command{
+		Name:        name,
+		Usage:       fmt.Sprintf("atask task %s <task-ids> [--ignore-errors]", name),
+		Description: description,
+		Flags:       flag.NewFlagSet("task-"+name, flag.ExitOnError),
+	}
+
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
+
+	cmd.Run = func(c *Command, args []string) error {
+		ic := icons(cfg)
+		if len(args) == 0 {
+			return fmt.Errorf("task IDs required")
+		}
+
+		tasks, failed, err := resolveTasks(cfg.NotesDirectory, args)
+		if err != nil {
+			return err
+		}
+		requested := len(tasks) + failed
+
+		var updatedTasks []*denote.Task
+		for _, t := range tasks {
+			oldPriority := t.TaskMetadata.Priority
+			newPriority := shiftPriority(oldPriority, step)
+			if newPriority == oldPriority {
+				if !globalFlags.JSON && !globalFlags.Quiet {
+					fmt.Printf("Task ID %d already at %s: %s\n", t.IndexID, displayPriority(oldPriority), t.Title)
+				}
+				continue
+			}
+
+			t.TaskMetadata.Priority = newPriority
+			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to update task ID %d: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+			updatedTasks = append(updatedTasks, t)
+			if !globalFlags.JSON && !globalFlags.Quiet {
+				fmt.Printf("Task ID %d: %s %s %s: %s\n", t.IndexID, displayPriority(oldPriority), ic.arrow, displayPriority(newPriority), t.Title)
+			}
+		}
+
+		if globalFlags.JSON {
+			if updatedTasks == nil {
+				updatedTasks = []*denote.Task{}
+			}
+			data, _ := json.MarshalIndent(updatedTasks, "", "  ")
+			fmt.Println(string(data))
+		}
+
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+// taskBumpCommand shifts due dates by a signed relative offset
+func taskBumpCommand(cfg *config.Config) *Command {
+	var (
+		keepWeekday  bool
+		fromToday    bool
+		ignoreErrors bool
+	)
+
+	cmd := &Command{
+		Name:        "bump",
+		Usage:       "atask task bump <task-ids> <+Nd|-Nw|+Nm|+Ny> [--keep-weekday] [--from-today]",
+		Description: "Move due dates forward or back by a relative offset",
+		Flags:       flag.NewFlagSet("task-bump", flag.ExitOnError),
+	}
+
+	cmd.Flags.BoolVar(&keepWeekday, "keep-weekday", false, "Snap the result to the original due date's weekday")
+	cmd.Flags.BoolVar(&fromToday, "from-today", false, "Bump tasks without a due date from today instead of failing")
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
+
+	cmd.Run = func(c *Command, args []string) error {
+		ic := icons(cfg)
+		if len(args) < 2 {
+			return fmt.Errorf("usage: atask bump <task-ids> <offset>")
+		}
+
+		offset, err := denote.ParseRelativeOffset(args[len(args)-1])
+		if err != nil {
+			return err
+		}
+
+		tasks, failed, err := resolveTasks(cfg.NotesDirectory, args[:len(args)-1])
+		if err != nil {
+			return err
+		}
+		requested := len(tasks) + failed
+
+		var updatedTasks []*denote.Task
+		for _, t := range tasks {
+			oldDue := t.TaskMetadata.DueDate
+			base := oldDue
+			if oldDue == "" {
+				if !fromToday {
+					fmt.Fprintf(os.Stderr, "Task ID %d has no due date to bump (use --from-today)\n", t.IndexID)
+					failed++
+					continue
+				}
+				oldDue = "none"
+				base = time.Now().Format("2006-01-02")
+			}
+			due, err := time.ParseInLocation("2006-01-02", base, time.Now().Location())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid due date for task ID %d: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+
+			bumped := offset.Apply(due)
+			if keepWeekday {
+				// Snap away from the original date so the bump is never undone
+				bumped = denote.SnapToWeekday(bumped, due.Weekday(), offset.N >= 0)
+			}
+
+			t.TaskMetadata.DueDate = bumped.Format("2006-01-02")
+			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to update task ID %d: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+			updatedTasks = append(updatedTasks, t)
+			if !globalFlags.JSON && !globalFlags.Quiet {
+				fmt.Printf("Task ID %d: %s %s %s: %s\n", t.IndexID, oldDue, ic.arrow, t.TaskMetadata.DueDate, t.Title)
+			}
+		}
+
+		if globalFlags.JSON {
+			if updatedTasks == nil {
+				updatedTasks = []*denote.Task{}
+			}
+			data, _ := json.MarshalIndent(updatedTasks, "", "  ")
+			fmt.Println(string(data))
+		}
+
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+// isTaskIDArg reports whether arg parses entirely as index_ids or
+// project-scoped references, so it can't be mistaken for a date.
+func isTaskIDArg(arg string) bool {
+	intIDs, entityIDs, projectRefs, err := parseTaskIdentifiers([]string{arg})
+	return err == nil && len(entityIDs) == 0 && len(intIDs)+len(projectRefs) > 0
+}
+
+func taskPlanCommand(cfg *config.Config) *Command {
+	var (
+		clearPlan    bool
+		ignoreErrors bool
+	)
+
+	cmd := &Command{
+		Name:        "plan",
+		Usage:       "atask task plan <task-ids> [date] | atask task plan --clear <task-ids>",
+		Description: "Plan tasks for a day (default today), setting planned_for",
+		Flags:       flag.NewFlagSet("task-plan", flag.ExitOnError),
+	}
+
+	cmd.Flags.BoolVar(&clearPlan, "clear", false, "Unset planned_for instead of setting it")
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
+
+	cmd.Run = func(c *Command, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("usage: atask plan <task-ids> [date]")
+		}
+
+		// Without --clear, a trailing argument that isn't an index_id and
+		// parses as a date is the day to plan for
+		planned := time.Now().Format("2006-01-02")
+		if clearPlan {
+			planned = ""
+		} else if last := args[len(args)-1]; len(args) > 1 && !isTaskIDArg(last) {
+			if parsed, err := denote.ParseNaturalDate(last); err == nil {
+				planned = parsed
+				args = args[:len(args)-1]
+			}
+		}
+
+		tasks, failed, err := resolveTasks(cfg.NotesDirectory, args)
+		if err != nil {
+			return err
+		}
+		requested := len(tasks) + failed
+
+		var updatedTasks []*denote.Task
+		for _, t := range tasks {
+			t.PlannedFor = planned
+			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to update task ID %d: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+			updatedTasks = append(updatedTasks, t)
+			if !globalFlags.JSON && !globalFlags.Quiet {
+				if planned == "" {
+					fmt.Printf("Task ID %d unplanned: %s\n", t.IndexID, t.Title)
+				} else {
+					fmt.Printf("Task ID %d planned for %s: %s\n", t.IndexID, planned, t.Title)
+				}
+			}
+		}
+
+		if globalFlags.JSON {
+			if updatedTasks == nil {
+				updatedTasks = []*denote.Task{}
+			}
+			data, _ := json.MarshalIndent(updatedTasks, "", "  ")
+			fmt.Println(string(data))
+		}
+
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+// formatEstimate renders estimate points, adding a wall-clock approximation
+// such as "5 (≈2.5h)" when minutes per point are configured.
+func formatEstimate(cfg *config.Config, points int) string {
+	if cfg.Tasks.EstimateMinutes <= 0 {
+		return strconv.Itoa(points)
+	}
+	approx := icons(cfg).approx
+	minutes := points * cfg.Tasks.EstimateMinutes
+	if minutes < 60 {
+		return fmt.Sprintf("%d (%s%dm)", points, approx, minutes)
+	}
+	hours := strconv.FormatFloat(float64(minutes)/60, 'f', 1, 64)
+	return fmt.Sprintf("%d (%s%sh)", points, approx, strings.TrimSuffix(hours, ".0"))
+}
+
+// displayPriority renders an empty priority as "none".
+func displayPriority(p string) string {
+	if p == "" {
+		return "none"
+	}
+	return p
+}
+
+func taskLogCommand(cfg *config.Config) *Command {
+	var (
+		deleteLine   string
+		deleteIndex  int
+		listEntries  bool
+		logDate      string
+		ignoreErrors bool
+	)
+
+	cmd := &Command{
+		Name:        "log",
+		Usage:       "atask task log <task-ids> [message] [--date <date>] [--list] [--delete <line>] [--delete-index <n>]",
+		Description: "Add, list, or delete timestamped log entries on one or more tasks",
+		Flags:       flag.NewFlagSet("task-log", flag.ExitOnError),
+	}
+
+	cmd.Flags.StringVar(&deleteLine, "delete", "", "Delete a log entry matching this exact line")
+	cmd.Flags.IntVar(&deleteIndex, "delete-index", 0, "Delete the Nth log entry (1-based, newest first, as numbered by --list)")
+	cmd.Flags.BoolVar(&listEntries, "list", false, "List the task's log entries, numbered")
+	cmd.Flags.StringVar(&logDate, "date", "", "Date the entry (YYYY-MM-DD or natural language, e.g. yesterday; default today)")
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
+
+	cmd.Run = func(c *Command, args []string) error {
+		if len(args) < 1 {
+			return fmt.Errorf("task ID required")
+		}
+		deleting := deleteLine != "" || deleteIndex != 0
+		if deleteLine != "" && deleteIndex != 0 {
+			return fmt.Errorf("--delete and --delete-index cannot be combined")
+		}
+		if listEntries && deleting {
+			return fmt.Errorf("--list cannot be combined with --delete or --delete-index")
+		}
+		if listEntries {
+			return listTaskLog(cfg, args[0])
+		}
+		if !deleting && len(args) < 2 {
+			return fmt.Errorf("message required (or use --list, --delete, or --delete-index)")
+		}
+
+		// IDs accept the same ranges and lists as update/done
+		tasks, failed, err := resolveTasks(cfg.NotesDirectory, args[:1])
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return fmt.Errorf("no matching tasks found")
+		}
+		requested := len(tasks) + failed
+
+		written := 0
+		if deleting {
+			for _, t := range tasks {
+				var err error
+				if deleteIndex != 0 {
+					err = denote.DeleteLogEntryByIndex(t.FilePath, deleteIndex)
+				} else {
+					err = denote.DeleteLogEntry(t.FilePath, deleteLine)
+				}
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to delete log entry from task ID %d: %v\n", t.IndexID, err)
+					failed++
+					continue
+				}
+				written++
+				if !globalFlags.Quiet {
+					fmt.Printf("Deleted log entry from task ID %d: %s\n", t.IndexID, t.Title)
+				}
+			}
+			if written == 0 {
+				return fmt.Errorf("failed to delete log entry")
+			}
+			return batchError(failed, requested, ignoreErrors)
+		}
+
+		message := strings.Join(args[1:], " ")
+		at, err := parseLogDate(logDate)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range tasks {
+			if err := denote.AddLogEntryAt(t.FilePath, message, at); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to add log entry to task ID %d: %v\n", t.IndexID, err)
+				failed++
+				continue
+			}
+			written++
+			if !globalFlags.Quiet {
+				fmt.Printf("Added log entry to task ID %d: %s\n", t.IndexID, t.Title)
+			}
+		}
+
+		if written == 0 {
+			return fmt.Errorf("failed to add log entry")
+		}
+		if len(tasks) > 1 && !globalFlags.Quiet {
+			fmt.Printf("Wrote %d of %d log entries\n", written, len(tasks))
+		}
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+// parseLogDate resolves a log --date value to a time on that day, or now
+// when empty.
+func parseLogDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	parsed, err := denote.ParseNaturalDate(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --date: %v", err)
+	}
+	return time.ParseInLocation("2006-01-02", parsed, time.Local)
+}
+
+// listTaskLog prints a task's log entries numbered from 1 in file order
+// (newest first), the numbers `log --delete-index` accepts.
+func listTaskLog(cfg *config.Config, id string) error {
+	t, err := lookupTask(cfg.NotesDirectory, id)
+	if err != nil {
+		return err
+	}
+	entries := denote.ParseLogEntries(t.Content)
+
+	if globalFlags.JSON {
+		type numberedEntry struct {
+			Index int `json:"index"`
+			denote.LogEntry
+		}
+		numbered := make([]numberedEntry, len(entries))
+		for i, e := range entries {
+			numbered[i] = numberedEntry{Index: i + 1, LogEntry: e}
+		}
+		data, err := json.MarshalIndent(numbered, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		if !globalFlags.Quiet {
+			fmt.Printf("No log entries on task ID %d: %s\n", t.IndexID, t.Title)
+		}
+		return nil
+	}
+	for i, e := range entries {
+		fmt.Printf("%3d  %s\n", i+1, e.Line())
+	}
+	return nil
+}
+
+func taskEditCommand(cfg *config.Config) *Command {
+	return &Command{
+		Name:        "edit",
+		Usage:       "atask task edit <task-id>",
+		Description: "Open task file in $EDITOR",
+		Run: func(c *Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("usage: atask task edit <task-id>")
+			}
+
+			t, err := lookupTask(cfg.NotesDirectory, args[0])
+			if err != nil {
+				return err
+			}
+
+			editor := os.Getenv("EDITOR")
+			if editor == "" {
+				editor = "vi"
+			}
+
+			cmd := exec.Command(editor, t.FilePath)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			return cmd.Run()
+		},
 	}
-
-	return cmd
 }
 
-func taskDoneCommand(cfg *config.Config) *Command {
+func taskDeleteCommand(cfg *config.Config) *Command {
+	var (
+		confirm      bool
+		ignoreErrors bool
+	)
+
 	cmd := &Command{
-		Name:        "done",
-		Usage:       "atask task done <task-ids>",
-		Description: "Mark tasks as done",
+		Name:        "delete",
+		Usage:       "atask task delete <task-ids> --confirm [--ignore-errors]",
+		Description: "Delete task files",
+		Flags:       flag.NewFlagSet("task-delete", flag.ExitOnError),
 	}
 
+	cmd.Flags.BoolVar(&confirm, "confirm", false, "Required: actually delete the files")
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
+
 	cmd.Run = func(c *Command, args []string) error {
 		if len(args) == 0 {
-			return fmt.Errorf("task IDs required")
+			return fmt.Errorf("usage: atask task delete <task-ids> --confirm")
 		}
 
-		intIDs, entityIDs, err := parseTaskIdentifiers(args)
+		tasksToDelete, failed, err := resolveTasks(cfg.NotesDirectory, args)
 		if err != nil {
 			return err
 		}
-
-		scanner := denote.NewScanner(cfg.NotesDirectory)
-		allTasks, err := scanner.FindTasks()
-		if err != nil {
-			return fmt.Errorf("failed to scan directory: %v", err)
-		}
-
-		tasksByID := make(map[int]*denote.Task)
-		tasksByEntityID := make(map[string]*denote.Task)
-		for _, t := range allTasks {
-			tasksByID[t.IndexID] = t
-			tasksByEntityID[t.ID] = t
+		if len(tasksToDelete) == 0 {
+			return fmt.Errorf("no matching tasks to delete")
 		}
+		requested := len(tasksToDelete) + failed
 
-		var tasksToUpdate []*denote.Task
-		for _, id := range intIDs {
-			t, ok := tasksByID[id]
-			if !ok {
-				fmt.Fprintf(os.Stderr, "Task with ID %d not found\n", id)
-				continue
+		if !confirm {
+			var lines []string
+			for _, t := range tasksToDelete {
+				lines = append(lines, fmt.Sprintf("  #%d %s (%s)", t.IndexID, t.Title, t.FilePath))
 			}
-			tasksToUpdate = append(tasksToUpdate, t)
-		}
-		for _, eid := range entityIDs {
-			t, ok := tasksByEntityID[eid]
-			if !ok {
-				fmt.Fprintf(os.Stderr, "Task with ID %s not found\n", eid)
-				continue
-			}
-			tasksToUpdate = append(tasksToUpdate, t)
+			return fmt.Errorf("use --confirm to delete %d task(s):\n%s", len(tasksToDelete), strings.Join(lines, "\n"))
 		}
 
-		updated := 0
-		for _, t := range tasksToUpdate {
-			t.TaskMetadata.Status = denote.TaskStatusDone
-			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to mark task %d as done: %v\n", t.IndexID, err)
+		type deleteResult struct {
+			Deleted bool   `json:"deleted"`
+			IndexID int    `json:"index_id"`
+			Title   string `json:"title"`
+			File    string `json:"file"`
+		}
+		results := []deleteResult{}
+		for _, t := range tasksToDelete {
+			if err := os.Remove(t.FilePath); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to delete task %d: %v\n", t.IndexID, err)
+				failed++
+				results = append(results, deleteResult{IndexID: t.IndexID, Title: t.Title, File: t.FilePath})
 				continue
 			}
-			updated++
-			if !globalFlags.Quiet {
-				fmt.Printf("✓ Task ID %d marked as done: %s\n", t.IndexID, t.Title)
-			}
-
-			if err := handleRecurrence(cfg, t); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to create recurring task for ID %d: %v\n", t.IndexID, err)
+			results = append(results, deleteResult{Deleted: true, IndexID: t.IndexID, Title: t.Title, File: t.FilePath})
+			if !globalFlags.JSON && !globalFlags.Quiet {
+				fmt.Printf("Deleted task #%d: %s\n", t.IndexID, t.Title)
 			}
 		}
 
-		if updated == 0 && !globalFlags.Quiet {
-			fmt.Println("No tasks marked as done")
+		if globalFlags.JSON {
+			data, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
 		}
 
-		return nil
+		return batchError(failed, requested, ignoreErrors)
 	}
 
 	return cmd
 }
 
-func taskLogCommand(cfg *config.Config) *Command {
-	var deleteLine string
+// taskMergeCommand folds a duplicate task into another and drops or
+// removes the duplicate
+func taskMergeCommand(cfg *config.Config) *Command {
+	var (
+		dryRun bool
+		del    bool
+	)
 
 	cmd := &Command{
-		Name:        "log",
-		Usage:       "atask task log <task-id> [message] [--delete <line>]",
-		Description: "Add or delete a timestamped log entry on a task",
-		Flags:       flag.NewFlagSet("task-log", flag.ExitOnError),
+		Name:        "merge",
+		Usage:       "atask task merge <from-id> <into-id> [--dry-run] [--delete]",
+		Description: "Merge a duplicate task into another task",
+		Flags:       flag.NewFlagSet("task-merge", flag.ExitOnError),
 	}
 
-	cmd.Flags.StringVar(&deleteLine, "delete", "", "Delete a log entry matching this exact line")
+	cmd.Flags.BoolVar(&dryRun, "dry-run", false, "Show what would be merged without changing files")
+	cmd.Flags.BoolVar(&del, "delete", false, "Delete the source task instead of marking it dropped")
 
 	cmd.Run = func(c *Command, args []string) error {
-		if len(args) < 1 {
-			return fmt.Errorf("task ID required")
+		if len(args) != 2 {
+			return fmt.Errorf("usage: atask task merge <from-id> <into-id>")
 		}
 
-		t, err := lookupTask(cfg.NotesDirectory, args[0])
+		source, err := lookupTask(cfg.NotesDirectory, args[0])
 		if err != nil {
 			return err
 		}
+		target, err := lookupTask(cfg.NotesDirectory, args[1])
+		if err != nil {
+			return err
+		}
+		if source.ID == target.ID {
+			return fmt.Errorf("cannot merge task %d into itself", source.IndexID)
+		}
+
+		sourceAction := "drop"
+		if del {
+			sourceAction = "delete"
+		}
+
+		// Tasks other than the pair that point at the source get pointed at
+		// the target
+		tasks, err := denote.NewScanner(cfg.NotesDirectory).FindTasks()
+		if err != nil {
+			return fmt.Errorf("failed to find tasks: %v", err)
+		}
+		redirected := task.RedirectReferences(tasks, source, target)
+
+		merged := *target
+		task.MergeMetadata(source, &merged)
+		added := mergeAdditions{
+			Tags:          newItems(target.Tags, merged.Tags),
+			RelatedPeople: newItems(target.RelatedPeople, merged.RelatedPeople),
+			RelatedIdeas:  newItems(target.RelatedIdeas, merged.RelatedIdeas),
+			RelatedTasks:  newItems(target.RelatedTasks, merged.RelatedTasks),
+			BlockedBy:     newItems(target.BlockedBy, merged.BlockedBy),
+		}
 
-		if deleteLine != "" {
-			if err := denote.DeleteLogEntry(t.FilePath, deleteLine); err != nil {
-				return fmt.Errorf("failed to delete log entry: %v", err)
+		if dryRun {
+			if globalFlags.JSON {
+				redirectedIDs := make([]int, 0, len(redirected))
+				for _, t := range redirected {
+					redirectedIDs = append(redirectedIDs, t.IndexID)
+				}
+				result := map[string]interface{}{
+					"dry_run":    true,
+					"from":       source.IndexID,
+					"into":       target.IndexID,
+					"source":     sourceAction,
+					"added":      added,
+					"redirected": redirectedIDs,
+				}
+				data, _ := json.MarshalIndent(result, "", "  ")
+				fmt.Println(string(data))
+				return nil
 			}
-			if !globalFlags.Quiet {
-				fmt.Printf("Deleted log entry from task ID %d: %s\n", t.IndexID, t.Title)
+			fmt.Printf("Would merge task #%d (%s) into #%d (%s)\n", source.IndexID, source.Title, target.IndexID, target.Title)
+			for _, tag := range added.Tags {
+				fmt.Printf("  add tag: %s\n", tag)
 			}
+			for _, id := range added.RelatedPeople {
+				fmt.Printf("  add related person: %s\n", id)
+			}
+			for _, id := range added.RelatedIdeas {
+				fmt.Printf("  add related idea: %s\n", id)
+			}
+			for _, id := range added.RelatedTasks {
+				fmt.Printf("  add related task: %s\n", id)
+			}
+			for _, id := range added.BlockedBy {
+				fmt.Printf("  add blocked by: %s\n", id)
+			}
+			if strings.TrimSpace(source.Content) != "" {
+				fmt.Printf("  append body and log from #%d\n", source.IndexID)
+			}
+			for _, t := range redirected {
+				fmt.Printf("  point task #%d (%s) at #%d\n", t.IndexID, t.Title, target.IndexID)
+			}
+			fmt.Printf("  %s task #%d\n", sourceAction, source.IndexID)
 			return nil
 		}
 
-		if len(args) < 2 {
-			return fmt.Errorf("message required (or use --delete)")
+		if err := task.MergeTask(source, target); err != nil {
+			return err
 		}
 
-		message := strings.Join(args[1:], " ")
+		var failed int
+		for _, t := range redirected {
+			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: failed to update references in task %d: %v\n", t.IndexID, err)
+				failed++
+			}
+		}
 
-		if err := denote.AddLogEntry(t.FilePath, message); err != nil {
-			return fmt.Errorf("failed to add log entry: %v", err)
+		// Back-link the people and ideas the target picked up
+		for _, id := range added.RelatedPeople {
+			acore.SyncRelation(target.Type, target.ID, id)
 		}
-		if !globalFlags.Quiet {
-			fmt.Printf("Added log entry to task ID %d: %s\n", t.IndexID, t.Title)
+		for _, id := range added.RelatedIdeas {
+			acore.SyncRelation(target.Type, target.ID, id)
+		}
+
+		if del {
+			if err := os.Remove(source.FilePath); err != nil {
+				return fmt.Errorf("merged, but failed to delete task %d: %w", source.IndexID, err)
+			}
+			for _, id := range source.RelatedPeople {
+				acore.UnsyncRelation(source.Type, source.ID, id)
+			}
+			for _, id := range source.RelatedIdeas {
+				acore.UnsyncRelation(source.Type, source.ID, id)
+			}
+		} else {
+			source.SetStatus(denote.TaskStatusDropped)
+			if err := task.UpdateTaskFile(source.FilePath, source); err != nil {
+				return fmt.Errorf("merged, but failed to drop task %d: %v", source.IndexID, err)
+			}
+		}
+
+		if globalFlags.JSON {
+			data, err := json.MarshalIndent(target, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		} else if !globalFlags.Quiet {
+			fmt.Printf("Merged task #%d into #%d: %s\n", source.IndexID, target.IndexID, target.Title)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("merged, but %d task(s) still reference task %d", failed, source.IndexID)
 		}
 		return nil
 	}
@@ -1085,94 +2735,159 @@ func taskLogCommand(cfg *config.Config) *Command {
 	return cmd
 }
 
-func taskEditCommand(cfg *config.Config) *Command {
-	return &Command{
-		Name:        "edit",
-		Usage:       "atask task edit <task-id>",
-		Description: "Open task file in $EDITOR",
-		Run: func(c *Command, args []string) error {
-			if len(args) == 0 {
-				return fmt.Errorf("usage: atask task edit <task-id>")
-			}
+// mergeAdditions is what a merge adds to the target's tags and relations.
+type mergeAdditions struct {
+	Tags          []string `json:"tags"`
+	RelatedPeople []string `json:"related_people"`
+	RelatedIdeas  []string `json:"related_ideas"`
+	RelatedTasks  []string `json:"related_tasks"`
+	BlockedBy     []string `json:"blocked_by"`
+}
 
-			t, err := lookupTask(cfg.NotesDirectory, args[0])
-			if err != nil {
-				return err
-			}
+// newItems returns the items of after that are not in before, never nil.
+func newItems(before, after []string) []string {
+	items := []string{}
+	for _, item := range after {
+		if !slices.Contains(before, item) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
 
-			editor := os.Getenv("EDITOR")
-			if editor == "" {
-				editor = "vi"
-			}
+// taskMoveCommand reassigns tasks' area and project in one step, checking
+// that the target project exists
+func taskMoveCommand(cfg *config.Config) *Command {
+	var (
+		toArea       string
+		toProject    string
+		inheritArea  bool
+		ignoreErrors bool
+	)
 
-			cmd := exec.Command(editor, t.FilePath)
-			cmd.Stdin = os.Stdin
-			cmd.Stdout = os.Stdout
-			cmd.Stderr = os.Stderr
-			return cmd.Run()
-		},
+	cmd := &Command{
+		Name:        "move",
+		Usage:       "atask task move <task-ids> [--to-area area] [--to-project id] [--inherit-area]",
+		Description: "Move tasks to another area and/or project",
+		Flags:       flag.NewFlagSet("task-move", flag.ExitOnError),
 	}
-}
 
-func taskDeleteCommand(cfg *config.Config) *Command {
-	return &Command{
-		Name:        "delete",
-		Usage:       "atask task delete <task-id> [--confirm]",
-		Description: "Delete a task file",
-		Run: func(c *Command, args []string) error {
-			if len(args) == 0 {
-				return fmt.Errorf("usage: atask task delete <task-id> [--confirm]")
-			}
+	cmd.Flags.StringVar(&toArea, "to-area", "", "Set area (use 'none' to clear)")
+	cmd.Flags.StringVar(&toProject, "to-project", "", "Set project by index_id or ULID (use 'none' to clear)")
+	cmd.Flags.BoolVar(&inheritArea, "inherit-area", false, "Set the area to the target project's area")
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some IDs are missing or fail")
 
-			confirm := false
-			idRef := ""
-			for _, arg := range args {
-				if arg == "--confirm" {
-					confirm = true
-				} else if idRef == "" {
-					idRef = arg
-				}
-			}
-			if idRef == "" {
-				return fmt.Errorf("usage: atask task delete <task-id> [--confirm]")
-			}
+	cmd.Run = func(c *Command, args []string) error {
+		ic := icons(cfg)
+		if len(args) == 0 {
+			return fmt.Errorf("task IDs required")
+		}
+		if inheritArea && (toProject == "" || strings.ToLower(toProject) == "none") {
+			return fmt.Errorf("--inherit-area requires a --to-project target")
+		}
+		if toArea == "" && toProject == "" {
+			return fmt.Errorf("nothing to move: use --to-area and/or --to-project")
+		}
+		if inheritArea && toArea != "" {
+			return fmt.Errorf("use either --to-area or --inherit-area, not both")
+		}
 
-			t, err := lookupTask(cfg.NotesDirectory, idRef)
+		// Resolve the target before touching any task
+		var target *denote.Project
+		newProjectID, newArea := "", toArea
+		if toProject != "" && strings.ToLower(toProject) != "none" {
+			p, err := lookupProject(cfg.NotesDirectory, toProject)
 			if err != nil {
-				return err
+				return fmt.Errorf("project %s not found", toProject)
 			}
-
-			if !confirm {
-				return fmt.Errorf("use --confirm to delete task '%s' (%s)", t.Title, t.FilePath)
+			target = p
+			newProjectID = strconv.Itoa(p.IndexID)
+			if inheritArea {
+				if p.ProjectMetadata.Area == "" {
+					return fmt.Errorf("project %d has no area to inherit", p.IndexID)
+				}
+				newArea = p.ProjectMetadata.Area
 			}
+		}
+		if strings.ToLower(newArea) == "none" {
+			newArea = ""
+		}
 
-			if err := os.Remove(t.FilePath); err != nil {
-				return fmt.Errorf("failed to delete task: %w", err)
+		tasksToMove, failed, err := resolveTasks(cfg.NotesDirectory, args)
+		if err != nil {
+			return err
+		}
+		requested := len(tasksToMove) + failed
+
+		var moved []*denote.Task
+		for _, t := range tasksToMove {
+			var changes []string
+			if toArea != "" || inheritArea {
+				if t.TaskMetadata.Area != newArea {
+					changes = append(changes, fmt.Sprintf("area %s %s %s", orNone(t.TaskMetadata.Area), ic.arrow, orNone(newArea)))
+					t.TaskMetadata.Area = newArea
+				}
+			}
+			if toProject != "" && t.TaskMetadata.ProjectID != newProjectID {
+				to := "(none)"
+				if target != nil {
+					to = fmt.Sprintf("%s (#%d)", target.Title, target.IndexID)
+				}
+				changes = append(changes, fmt.Sprintf("project %s %s %s", orNone(t.TaskMetadata.ProjectID), ic.arrow, to))
+				t.TaskMetadata.ProjectID = newProjectID
 			}
 
-			if globalFlags.JSON {
-				result := map[string]interface{}{
-					"deleted":  true,
-					"index_id": t.IndexID,
-					"title":    t.Title,
-					"file":     t.FilePath,
+			if len(changes) == 0 {
+				if !globalFlags.JSON && !globalFlags.Quiet {
+					fmt.Printf("Task ID %d already in place: %s\n", t.IndexID, t.Title)
 				}
-				data, _ := json.MarshalIndent(result, "", "  ")
-				fmt.Println(string(data))
-				return nil
+				continue
+			}
+			if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to move task %d: %v\n", t.IndexID, err)
+				failed++
+				continue
 			}
+			moved = append(moved, t)
+			if !globalFlags.JSON && !globalFlags.Quiet {
+				fmt.Printf("Moved task ID %d: %s (%s)\n", t.IndexID, t.Title, strings.Join(changes, ", "))
+			}
+		}
 
-			if !globalFlags.Quiet {
-				fmt.Printf("Deleted task #%d: %s\n", t.IndexID, t.Title)
+		if globalFlags.JSON {
+			if moved == nil {
+				moved = []*denote.Task{}
 			}
-			return nil
-		},
+			data, err := json.MarshalIndent(moved, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+		}
+
+		return batchError(failed, requested, ignoreErrors)
+	}
+
+	return cmd
+}
+
+// orNone labels an empty field value for change reports.
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
 	}
+	return s
 }
 
 func taskQueryCommand(cfg *config.Config) *Command {
 	var sortBy string
 	var reverse bool
+	var limit int
+	var format string
+	var withArchived bool
+	var countOnly bool
+	var outTemplate string
+	var soonDays int
 
 	cmd := &Command{
 		Name:        "query",
@@ -1181,11 +2896,34 @@ func taskQueryCommand(cfg *config.Config) *Command {
 		Flags:       flag.NewFlagSet("task-query", flag.ExitOnError),
 	}
 
-	cmd.Flags.StringVar(&sortBy, "sort", "modified", "Sort by: priority, due, created, modified")
+	cmd.Flags.StringVar(&sortBy, "sort", "modified", "Sort by: priority, due, created, age, modified, project")
 	cmd.Flags.BoolVar(&reverse, "r", false, "Reverse sort order")
 	cmd.Flags.BoolVar(&reverse, "reverse", false, "Reverse sort order")
+	cmd.Flags.IntVar(&limit, "limit", 0, "Show at most N tasks after sorting (0 for no limit)")
+	cmd.Flags.IntVar(&limit, "n", 0, "Limit results (short)")
+	cmd.Flags.StringVar(&format, "format", "", "Output format: text, json, csv, jsonl (default: text, or json with --json)")
+	cmd.Flags.BoolVar(&withArchived, "include-archived", false, "Also scan tasks moved to archive/")
+	cmd.Flags.BoolVar(&countOnly, "count", false, "Print only the number of matching tasks")
+	cmd.Flags.StringVar(&outTemplate, "output-template", "", "Render each task with a Go template, e.g. '{{.IndexID}} {{.Title}}'")
+	cmd.Flags.IntVar(&soonDays, "soon-days", 0, "Days ahead due:soon looks for this run (default from config)")
 
 	cmd.Run = func(c *Command, args []string) error {
+		outFormat, err := outputFormat(format)
+		if err != nil {
+			return err
+		}
+		tmpl, err := outputTemplate(outTemplate, format)
+		if err != nil {
+			return err
+		}
+		horizon, err := soonHorizon(cfg, soonDays)
+		if err != nil {
+			return err
+		}
+		// due:soon reads the horizon from the config Evaluate is given
+		evalCfg := *cfg
+		evalCfg.SoonHorizon = horizon
+
 		if len(args) == 0 {
 			return fmt.Errorf("query expression required\n\nExamples:\n  atask query \"status:open AND priority:p1\"\n  atask query \"area:work AND (priority:p1 OR priority:p2)\"\n  atask query \"due:soon AND NOT status:done\"")
 		}
@@ -1198,9 +2936,9 @@ func taskQueryCommand(cfg *config.Config) *Command {
 		}
 
 		scanner := denote.NewScanner(cfg.NotesDirectory)
-		allTasks, err := scanner.FindTasks()
+		allTasks, err := scanTasks(scanner, withArchived)
 		if err != nil {
-			return fmt.Errorf("failed to find tasks: %v", err)
+			return err
 		}
 
 		projects, _ := scanner.FindProjects()
@@ -1208,24 +2946,43 @@ func taskQueryCommand(cfg *config.Config) *Command {
 		for _, p := range projects {
 			projectNames[strconv.Itoa(p.IndexID)] = p.Title
 		}
+		warnParseErrors(scanner)
 
 		var tasks []denote.Task
 		for _, t := range allTasks {
-			if ast.Evaluate(t, cfg) {
+			if ast.Evaluate(t, &evalCfg) {
 				tasks = append(tasks, *t)
 			}
 		}
 
-		sortTasks(tasks, sortBy, reverse)
+		if countOnly {
+			return printCount(len(tasks))
+		}
 
-		if globalFlags.JSON {
+		sortTasks(tasks, sortBy, reverse, projectNames)
+		matched := len(tasks)
+		tasks = limitTasks(tasks, limit)
+
+		if tmpl != nil {
+			return writeTasksTemplate(os.Stdout, tmpl, tasks)
+		}
+		if outFormat == formatCSV {
+			return writeTasksCSV(os.Stdout, tasks, projectNames)
+		}
+		if outFormat == formatJSONL {
+			return writeTasksJSONL(os.Stdout, tasks, projectNames, false)
+		}
+
+		if outFormat == formatJSON {
 			type TaskJSON struct {
 				denote.Task
 				ProjectName string `json:"project_name,omitempty"`
 			}
 			type Output struct {
-				Tasks []TaskJSON `json:"tasks"`
-				Count int        `json:"count"`
+				SchemaVersion int        `json:"schema_version"`
+				Tasks         []TaskJSON `json:"tasks"`
+				Count         int        `json:"count"`
+				Total         int        `json:"total"`
 			}
 
 			jsonTasks := make([]TaskJSON, len(tasks))
@@ -1236,7 +2993,7 @@ func taskQueryCommand(cfg *config.Config) *Command {
 				}
 			}
 
-			output := Output{Tasks: jsonTasks, Count: len(tasks)}
+			output := Output{SchemaVersion: jsonSchemaVersion, Tasks: jsonTasks, Count: len(tasks), Total: matched}
 			jsonBytes, err := json.MarshalIndent(output, "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to marshal JSON: %w", err)
@@ -1255,31 +3012,26 @@ func taskQueryCommand(cfg *config.Config) *Command {
 		priorityMedColor := color.New(color.FgYellow)
 
 		if !globalFlags.Quiet {
-			fmt.Printf("Tasks (%d):\n\n", len(tasks))
+			fmt.Printf("%s:\n\n", tasksHeader(len(tasks), matched))
 		}
 
+		ic := icons(cfg)
 		for _, t := range tasks {
-			statusIcon := "○"
-			switch t.TaskMetadata.Status {
-			case denote.TaskStatusDone:
-				statusIcon = doneColor.Sprint("✓")
-			case denote.TaskStatusPaused:
-				statusIcon = "⏸"
-			case denote.TaskStatusDropped:
-				statusIcon = "⨯"
-			case denote.TaskStatusDelegated:
-				statusIcon = "→"
-			}
-
-			priorityStr := "   "
+			statusIcon := ic.taskStatus(t.TaskMetadata.Status)
+			if t.TaskMetadata.Status == denote.TaskStatusDone {
+				statusIcon = doneColor.Sprint(statusIcon)
+			}
+
+			priorityStr := ic.priorityMarker("")
 			if t.TaskMetadata.Priority != "" {
+				pStr := ic.priorityMarker(t.TaskMetadata.Priority)
 				switch t.TaskMetadata.Priority {
 				case denote.PriorityP1:
-					priorityStr = priorityHighColor.Sprintf("[%s]", t.TaskMetadata.Priority)
+					priorityStr = priorityHighColor.Sprint(pStr)
 				case denote.PriorityP2:
-					priorityStr = priorityMedColor.Sprintf("[%s]", t.TaskMetadata.Priority)
+					priorityStr = priorityMedColor.Sprint(pStr)
 				default:
-					priorityStr = fmt.Sprintf("[%s]", t.TaskMetadata.Priority)
+					priorityStr = pStr
 				}
 			}
 
@@ -1294,27 +3046,27 @@ func taskQueryCommand(cfg *config.Config) *Command {
 
 			title := t.Title
 			if t.TaskMetadata.Recur != "" {
-				title = "↻ " + title
+				title = ic.recurring + " " + title
 			}
-			if len(title) > 50 {
-				title = title[:47] + "..."
+			if t.IsBlocked() {
+				title = ic.blocked + " " + title
 			}
-
-			areaStr := ""
-			if t.TaskMetadata.Area != "" {
-				areaStr = t.TaskMetadata.Area
+			if t.TaskMetadata.Status == denote.TaskStatusDelegated && t.TaskMetadata.Assignee != "" {
+				title += " @" + t.TaskMetadata.Assignee
 			}
+			title = padColumn(title, 50)
+			areaStr := padColumn(t.TaskMetadata.Area, 10)
 
 			projectName := ""
 			if t.TaskMetadata.ProjectID != "" {
 				if name, ok := projectNames[t.TaskMetadata.ProjectID]; ok && name != "" {
-					projectName = "→ " + name
+					projectName = ic.arrow + " " + name
 				} else {
-					projectName = "→ " + t.TaskMetadata.ProjectID
+					projectName = ic.arrow + " " + t.TaskMetadata.ProjectID
 				}
 			}
 
-			line := fmt.Sprintf("%3d %s %s %s  %-50s %-10s %s",
+			line := fmt.Sprintf("%3d %s %s %s  %s %s %s",
 				t.IndexID,
 				statusIcon,
 				priorityStr,
@@ -1335,15 +3087,16 @@ func taskQueryCommand(cfg *config.Config) *Command {
 
 func taskBatchUpdateCommand(cfg *config.Config) *Command {
 	var (
-		whereClause string
-		priority    string
-		due         string
-		area        string
-		project     string
-		estimate    int
-		status      string
-		recur       string
-		preview     bool
+		whereClause  string
+		priority     string
+		due          string
+		area         string
+		project      string
+		estimate     int
+		status       string
+		recur        string
+		preview      bool
+		ignoreErrors bool
 	)
 
 	cmd := &Command{
@@ -1355,15 +3108,17 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 
 	cmd.Flags.StringVar(&whereClause, "where", "", "Query expression to filter tasks")
 	cmd.Flags.StringVar(&priority, "priority", "", "Set priority (p1, p2, p3)")
-	cmd.Flags.StringVar(&due, "due", "", "Set due date")
+	cmd.Flags.StringVar(&due, "due", "", "Set due date (use 'none' to clear)")
 	cmd.Flags.StringVar(&area, "area", "", "Set area")
 	cmd.Flags.StringVar(&project, "project", "", "Set project")
-	cmd.Flags.IntVar(&estimate, "estimate", -1, "Set time estimate")
-	cmd.Flags.StringVar(&status, "status", "", "Set status (open, done, paused, delegated, dropped)")
+	cmd.Flags.IntVar(&estimate, "estimate", -1, "Set time estimate (1, 2, 3, 5, 8, 13; 0 to clear)")
+	cmd.Flags.StringVar(&status, "status", "", "Set status (open, in_progress, done, paused, delegated, dropped)")
 	cmd.Flags.StringVar(&recur, "recur", "", "Set recurrence (use 'none' to clear)")
 	cmd.Flags.BoolVar(&preview, "preview", false, "Preview changes without applying them")
+	cmd.Flags.BoolVar(&ignoreErrors, "ignore-errors", false, "Exit 0 even if some matching tasks fail to update")
 
 	cmd.Run = func(c *Command, args []string) error {
+		ic := icons(cfg)
 		if whereClause == "" {
 			return fmt.Errorf("--where clause required\n\nExample:\n  atask batch-update --where \"status:open AND due:past\" --status paused")
 		}
@@ -1372,6 +3127,16 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 			return fmt.Errorf("at least one field to update must be specified (--priority, --due, --area, --project, --estimate, --status, or --recur)")
 		}
 
+		if err := validateTaskFields(priority, status); err != nil {
+			return err
+		}
+		// -1 means --estimate was not given
+		if estimate != -1 {
+			if err := validateEstimate(estimate); err != nil {
+				return err
+			}
+		}
+
 		ast, err := query.Parse(whereClause)
 		if err != nil {
 			return fmt.Errorf("failed to parse --where clause: %v", err)
@@ -1401,12 +3166,13 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 		}
 		fmt.Println()
 
-		var parsedDue string
-		if due != "" {
-			parsedDue, err = denote.ParseNaturalDate(due)
+		// Validate the due date once; "none" clears it on every task
+		if due != "" && !strings.EqualFold(due, "none") {
+			parsedDue, err := denote.ParseNaturalDate(due)
 			if err != nil {
 				return fmt.Errorf("invalid due date: %v", err)
 			}
+			due = parsedDue
 		}
 
 		var recurPattern string
@@ -1425,32 +3191,34 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 
 		changes := []string{}
 		if priority != "" {
-			changes = append(changes, fmt.Sprintf("priority → %s", priority))
+			changes = append(changes, fmt.Sprintf("priority %s %s", ic.arrow, priority))
 		}
-		if due != "" {
-			changes = append(changes, fmt.Sprintf("due_date → %s", parsedDue))
+		if strings.EqualFold(due, "none") {
+			changes = append(changes, "due_date "+ic.arrow+" (cleared)")
+		} else if due != "" {
+			changes = append(changes, fmt.Sprintf("due_date %s %s", ic.arrow, due))
 		}
 		if area != "" {
-			changes = append(changes, fmt.Sprintf("area → %s", area))
+			changes = append(changes, fmt.Sprintf("area %s %s", ic.arrow, area))
 		}
 		if project != "" {
-			changes = append(changes, fmt.Sprintf("project_id → %s", project))
+			changes = append(changes, fmt.Sprintf("project_id %s %s", ic.arrow, project))
 		}
 		if estimate >= 0 {
-			changes = append(changes, fmt.Sprintf("estimate → %d", estimate))
+			changes = append(changes, fmt.Sprintf("estimate %s %d", ic.arrow, estimate))
 		}
 		if status != "" {
-			changes = append(changes, fmt.Sprintf("status → %s", status))
+			changes = append(changes, fmt.Sprintf("status %s %s", ic.arrow, status))
 		}
 		if clearRecur {
-			changes = append(changes, "recur → (cleared)")
+			changes = append(changes, "recur "+ic.arrow+" (cleared)")
 		} else if recurPattern != "" {
-			changes = append(changes, fmt.Sprintf("recur → %s", recurPattern))
+			changes = append(changes, fmt.Sprintf("recur %s %s", ic.arrow, recurPattern))
 		}
 
 		fmt.Printf("Changes to apply:\n")
 		for _, change := range changes {
-			fmt.Printf("  • %s\n", change)
+			fmt.Printf("  %s %s\n", ic.bullet, change)
 		}
 		fmt.Println()
 
@@ -1459,7 +3227,7 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 			return nil
 		}
 
-		updated := 0
+		updated, failed := 0, 0
 		for _, t := range matchingTasks {
 			changed := false
 
@@ -1468,8 +3236,8 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 				changed = true
 			}
 			if due != "" {
-				t.TaskMetadata.DueDate = parsedDue
-				changed = true
+				dueChanged, _ := setDateField(&t.TaskMetadata.DueDate, due)
+				changed = changed || dueChanged
 			}
 			if area != "" {
 				t.TaskMetadata.Area = area
@@ -1479,11 +3247,13 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 				projectNum, err := strconv.Atoi(project)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Invalid project ID: %s (must be numeric)\n", project)
+					failed++
 					continue
 				}
 				p, err := task.FindProjectByID(cfg.NotesDirectory, projectNum)
 				if err != nil {
 					fmt.Fprintf(os.Stderr, "Project %d not found\n", projectNum)
+					failed++
 					continue
 				}
 				t.TaskMetadata.ProjectID = strconv.Itoa(p.IndexID)
@@ -1494,7 +3264,7 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 				changed = true
 			}
 			if status != "" {
-				t.TaskMetadata.Status = status
+				t.SetStatus(status)
 				changed = true
 			}
 			if clearRecur {
@@ -1508,6 +3278,7 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 			if changed {
 				if err := task.UpdateTaskFile(t.FilePath, t); err != nil {
 					fmt.Fprintf(os.Stderr, "Failed to update task %d: %v\n", t.IndexID, err)
+					failed++
 					continue
 				}
 				updated++
@@ -1520,30 +3291,95 @@ func taskBatchUpdateCommand(cfg *config.Config) *Command {
 			}
 		}
 
-		fmt.Printf("✓ Updated %d task(s)\n", updated)
-		return nil
+		fmt.Printf("%s Updated %d task(s)\n", ic.done, updated)
+		return batchError(failed, len(matchingTasks), ignoreErrors)
 	}
 
 	return cmd
 }
 
-// handleRecurrence checks if a completed task has a recurrence pattern and creates the next instance.
-func handleRecurrence(cfg *config.Config, t *denote.Task) error {
-	if t.TaskMetadata.Recur == "" || t.TaskMetadata.DueDate == "" {
-		return nil
+// soonHorizon returns the --soon-days override, or the configured horizon
+// when it is 0.
+func soonHorizon(cfg *config.Config, soonDays int) (int, error) {
+	if soonDays < 0 {
+		return 0, fmt.Errorf("invalid --soon-days: %d (must be 0 or more)", soonDays)
 	}
+	if soonDays == 0 {
+		return cfg.SoonHorizon, nil
+	}
+	return soonDays, nil
+}
 
-	currentDue, err := time.ParseInLocation("2006-01-02", t.TaskMetadata.DueDate, time.Now().Location())
+// setDateField applies a --due or --begin value to a date field: "none"
+// (any case) clears it, a signed offset (+3d, -1w) shifts the current date
+// (or today when the field is empty), and anything else is parsed as a
+// date. It reports whether the field changed, so clearing an empty date is
+// a no-op.
+func setDateField(field *string, value string) (bool, error) {
+	if strings.EqualFold(value, "none") {
+		changed := *field != ""
+		*field = ""
+		return changed, nil
+	}
+	var parsed string
+	var err error
+	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+		parsed, err = denote.ParseRelativeDate(dateAnchor(*field), value)
+	} else {
+		parsed, err = denote.ParseNaturalDate(value)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to parse due date %q: %w", t.TaskMetadata.DueDate, err)
+		return false, err
+	}
+	*field = parsed
+	return true, nil
+}
+
+// dateAnchor is the base a relative --due or --begin offset shifts: the
+// field's current date, or today when it is empty or unparseable.
+func dateAnchor(current string) time.Time {
+	if d, err := time.ParseInLocation("2006-01-02", current, time.Local); err == nil {
+		return d
+	}
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// nextRecurrenceDue computes the due date of the instance that completing t
+// would create, or "" when t does not recur (or recurs from a due date it
+// lacks).
+func nextRecurrenceDue(t *denote.Task) (string, error) {
+	if t.TaskMetadata.Recur == "" {
+		return "", nil
+	}
+	afterCompletion := recurrence.IsAfterCompletion(t.TaskMetadata.Recur)
+	if t.TaskMetadata.DueDate == "" && !afterCompletion {
+		return "", nil
+	}
+
+	var currentDue time.Time
+	if t.TaskMetadata.DueDate != "" {
+		var err error
+		currentDue, err = time.ParseInLocation("2006-01-02", t.TaskMetadata.DueDate, time.Now().Location())
+		if err != nil && !afterCompletion {
+			return "", fmt.Errorf("failed to parse due date %q: %w", t.TaskMetadata.DueDate, err)
+		}
 	}
 
 	nextDue, err := recurrence.NextDueDate(t.TaskMetadata.Recur, currentDue)
 	if err != nil {
-		return fmt.Errorf("failed to compute next due date: %w", err)
+		return "", fmt.Errorf("failed to compute next due date: %w", err)
 	}
+	return nextDue.Format("2006-01-02"), nil
+}
 
-	newDueStr := nextDue.Format("2006-01-02")
+// handleRecurrence checks if a completed task has a recurrence pattern and creates the next instance.
+// After-completion patterns don't need a due date; the next one counts from today.
+func handleRecurrence(cfg *config.Config, t *denote.Task) error {
+	newDueStr, err := nextRecurrenceDue(t)
+	if err != nil || newDueStr == "" {
+		return err
+	}
 
 	newTask, err := task.CloneTaskForRecurrence(cfg.NotesDirectory, t, newDueStr)
 	if err != nil {
@@ -1551,8 +3387,8 @@ func handleRecurrence(cfg *config.Config, t *denote.Task) error {
 	}
 
 	if !globalFlags.Quiet {
-		fmt.Printf("↻ Created recurring task ID %d: %s (due %s)\n",
-			newTask.IndexID, newTask.Title, newDueStr)
+		fmt.Printf("%s Created recurring task ID %d: %s (due %s)\n",
+			icons(cfg).recurring, newTask.IndexID, newTask.Title, newDueStr)
 	}
 
 	return nil
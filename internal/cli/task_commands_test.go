@@ -0,0 +1,1661 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"github.com/mph-llm-experiments/atask/internal/task"
+)
+
+func TestTaskNewRejectsInvalidEstimate(t *testing.T) {
+	dir := t.TempDir()
+	cmd := taskNewCommand(&config.Config{NotesDirectory: dir})
+
+	err := cmd.Execute([]string{"Write report", "--estimate", "4"})
+	if err == nil || !strings.Contains(err.Error(), "invalid estimate: 4") {
+		t.Fatalf("new --estimate 4 error = %v, want invalid estimate", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("new with invalid estimate wrote %d files, want none", len(entries))
+	}
+}
+
+func TestTaskUpdateClearsDatesWithNone(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	created := newTestTask(t, dir, "Renew passport", func(tk *denote.Task) {
+		tk.TaskMetadata.DueDate = "2026-03-01"
+		tk.TaskMetadata.StartDate = "2026-02-01"
+	})
+
+	cmd := taskUpdateCommand(cfg)
+	if err := cmd.Execute([]string{"--due", "None", "--begin", "none", strconv.Itoa(created.IndexID)}); err != nil {
+		t.Fatalf("update --due none error = %v", err)
+	}
+
+	got, err := denote.ParseTaskFile(created.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TaskMetadata.DueDate != "" || got.TaskMetadata.StartDate != "" {
+		t.Errorf("after --due none --begin none: due %q, start %q, want both empty", got.TaskMetadata.DueDate, got.TaskMetadata.StartDate)
+	}
+}
+
+func TestTaskUpdateClearingEmptyDateIsNoop(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	created := newTestTask(t, dir, "Water plants", nil)
+	before, err := os.ReadFile(created.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := taskUpdateCommand(cfg)
+	if err := cmd.Execute([]string{"--due", "none", strconv.Itoa(created.IndexID)}); err != nil {
+		t.Fatalf("update --due none error = %v", err)
+	}
+
+	after, err := os.ReadFile(created.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Errorf("--due none on a task without a due date rewrote the file:\n%s", after)
+	}
+}
+
+func TestTaskUpdateRelativeDueAnchorsOnCurrentDue(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	created := newTestTask(t, dir, "File taxes", func(tk *denote.Task) {
+		tk.TaskMetadata.DueDate = "2026-01-31"
+	})
+
+	for _, step := range []struct{ offset, want string }{
+		{"+1m", "2026-02-28"},
+		{"+1w", "2026-03-07"},
+		{"-2d", "2026-03-05"},
+	} {
+		cmd := taskUpdateCommand(cfg)
+		if err := cmd.Execute([]string{"--due", step.offset, strconv.Itoa(created.IndexID)}); err != nil {
+			t.Fatalf("update --due %s error = %v", step.offset, err)
+		}
+		got, err := denote.ParseTaskFile(created.FilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.TaskMetadata.DueDate != step.want {
+			t.Errorf("update --due %s: due = %s, want %s", step.offset, got.TaskMetadata.DueDate, step.want)
+		}
+	}
+}
+
+func TestTaskShowWithRelatedResolvesTasks(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+	// No apeople on PATH, so people fall back to their raw IDs
+	t.Setenv("PATH", t.TempDir())
+
+	other := newTestTask(t, dir, "Order parts", nil)
+	const missing = "01JZZZZZZZZZZZZZZZZZZZZZZZ"
+	const person = "01JPERSONPERSONPERSONPERSO"
+	created := newTestTask(t, dir, "Fix bike", func(tk *denote.Task) {
+		tk.RelatedTasks = []string{other.ID, missing}
+		tk.RelatedPeople = []string{person}
+	})
+
+	show := func(args ...string) string {
+		return captureStdout(t, func() {
+			if err := taskShowCommand(cfg).Execute(args); err != nil {
+				t.Errorf("show %v error = %v", args, err)
+			}
+		})
+	}
+	id := strconv.Itoa(created.IndexID)
+
+	out := show("--with-related", id)
+	wantTasks := fmt.Sprintf("Related tasks:  Order parts (#%d), %s", other.IndexID, missing)
+	if !strings.Contains(out, wantTasks) {
+		t.Errorf("show --with-related output missing %q:\n%s", wantTasks, out)
+	}
+	if !strings.Contains(out, "Related people: "+person) {
+		t.Errorf("show --with-related without apeople should print the raw person ID:\n%s", out)
+	}
+
+	// Off by default
+	out = show(id)
+	if !strings.Contains(out, "Related tasks:  "+other.ID+", "+missing) {
+		t.Errorf("show without --with-related should print raw IDs:\n%s", out)
+	}
+}
+
+func TestTaskShowListsReferencingTasks(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	target := newTestTask(t, dir, "Draft budget", nil)
+	source := newTestTask(t, dir, "Review budget", func(tk *denote.Task) {
+		tk.RelatedTasks = []string{target.ID}
+	})
+
+	show := func(id int) string {
+		return captureStdout(t, func() {
+			if err := taskShowCommand(cfg).Execute([]string{strconv.Itoa(id)}); err != nil {
+				t.Errorf("show %d error = %v", id, err)
+			}
+		})
+	}
+
+	out := show(target.IndexID)
+	want := fmt.Sprintf("Referenced by:\n    #%d Review budget (", source.IndexID)
+	if !strings.Contains(out, want) {
+		t.Errorf("show of the referenced task missing %q:\n%s", want, out)
+	}
+	if out := show(source.IndexID); strings.Contains(out, "Referenced by:") {
+		t.Errorf("show of the referencing task lists inbound links:\n%s", out)
+	}
+}
+
+func TestTaskBatchUpdateReportsFailures(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+	newTestTask(t, dir, "Mow lawn", nil)
+	newTestTask(t, dir, "Trim hedge", nil)
+
+	// Project 999 doesn't exist, so every matching task fails
+	args := []string{"--where", "status:open", "--project", "999"}
+	captureStdout(t, func() {
+		err := taskBatchUpdateCommand(cfg).Execute(args)
+		if err == nil || !strings.Contains(err.Error(), "2 of 2") {
+			t.Errorf("batch-update with a missing project error = %v, want 2 of 2 failed", err)
+		}
+		if err := taskBatchUpdateCommand(cfg).Execute(append(args, "--ignore-errors")); err != nil {
+			t.Errorf("batch-update --ignore-errors error = %v, want nil", err)
+		}
+	})
+}
+
+func TestBatchCommandsRefuseDuplicateIndexID(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	first := newTestTask(t, dir, "Send invoice", nil)
+	second := newTestTask(t, dir, "Send invoice copy", func(tk *denote.Task) {
+		tk.IndexID = first.IndexID
+	})
+	id := strconv.Itoa(first.IndexID)
+
+	for _, tc := range []struct {
+		name string
+		cmd  *Command
+		args []string
+	}{
+		{"done", taskDoneCommand(cfg), []string{id}},
+		{"update", taskUpdateCommand(cfg), []string{"--priority", "p1", id}},
+	} {
+		err := tc.cmd.Execute(tc.args)
+		if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+			t.Errorf("%s on a duplicate index_id error = %v, want ambiguity error", tc.name, err)
+		}
+	}
+
+	for _, path := range []string{first.FilePath, second.FilePath} {
+		got, err := denote.ParseTaskFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.TaskMetadata.Status == denote.TaskStatusDone || got.TaskMetadata.Priority != "" {
+			t.Errorf("%s was written: status %q, priority %q", filepath.Base(path), got.TaskMetadata.Status, got.TaskMetadata.Priority)
+		}
+	}
+}
+
+// captureStdout runs fn and returns what it wrote to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+// newTestTask creates a task titled title in dir and, when mutate is set,
+// applies it to the task and writes the result back.
+func newTestTask(t *testing.T, dir, title string, mutate func(*denote.Task)) *denote.Task {
+	t.Helper()
+	created, err := task.CreateTask(dir, title, "", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mutate != nil {
+		mutate(created)
+		if err := task.UpdateTaskFile(created.FilePath, created); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return created
+}
+
+func TestTaskReopenMultipleIDsJSON(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	var ids []string
+	for _, title := range []string{"Send invoice", "File receipts", "Book venue"} {
+		created := newTestTask(t, dir, title, func(tk *denote.Task) {
+			tk.TaskMetadata.Status = denote.TaskStatusDone
+			tk.TaskMetadata.TodayDate = "2026-01-05"
+		})
+		ids = append(ids, strconv.Itoa(created.IndexID))
+	}
+
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+
+	// Reopen the first two by index_id list, leaving the third done
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = taskReopenCommand(cfg).Execute([]string{ids[0] + "," + ids[1]})
+	})
+	if runErr != nil {
+		t.Fatalf("reopen error = %v", runErr)
+	}
+
+	var reopened []denote.Task
+	if err := json.Unmarshal([]byte(out), &reopened); err != nil {
+		t.Fatalf("reopen --json output is not a task array: %v\n%s", err, out)
+	}
+	if len(reopened) != 2 {
+		t.Fatalf("reopen --json returned %d tasks, want 2", len(reopened))
+	}
+	for _, r := range reopened {
+		if r.TaskMetadata.Status != denote.TaskStatusOpen || r.TaskMetadata.TodayDate != "" {
+			t.Errorf("task %d: status %q, today_date %q; want open and cleared", r.IndexID, r.TaskMetadata.Status, r.TaskMetadata.TodayDate)
+		}
+	}
+
+	tasks, err := denote.NewScanner(dir).FindTasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range tasks {
+		wantStatus := denote.TaskStatusOpen
+		if strconv.Itoa(tk.IndexID) == ids[2] {
+			wantStatus = denote.TaskStatusDone
+		}
+		if tk.TaskMetadata.Status != wantStatus {
+			t.Errorf("task %d on disk: status %q, want %q", tk.IndexID, tk.TaskMetadata.Status, wantStatus)
+		}
+	}
+
+	// A missing ID fails the batch after reopening the rest
+	err = nil
+	captureStdout(t, func() {
+		err = taskReopenCommand(cfg).Execute([]string{ids[2] + ",999"})
+	})
+	if err == nil {
+		t.Error("reopen with a missing ID succeeded, want error")
+	}
+}
+
+func TestTaskListFormatCSVQuotesTitles(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	created := newTestTask(t, dir, "Buy milk, eggs, and bread", func(tk *denote.Task) {
+		tk.TaskMetadata.Priority = "p1"
+		tk.TaskMetadata.Area = "home"
+	})
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = taskListCommand(cfg).Execute([]string{"--format", "csv"})
+	})
+	if runErr != nil {
+		t.Fatalf("list --format csv error = %v", runErr)
+	}
+
+	want := "index_id,status,priority,due_date,area,project,title\n" +
+		strconv.Itoa(created.IndexID) + ",open,p1,,home,,\"Buy milk, eggs, and bread\"\n"
+	if out != want {
+		t.Errorf("list --format csv output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestTaskDoneRecordsCompletedDateAndReopenClearsIt(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	created := newTestTask(t, dir, "Submit expenses", nil)
+	id := strconv.Itoa(created.IndexID)
+
+	completedDate := func() string {
+		t.Helper()
+		found, err := task.FindTaskByID(dir, created.IndexID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return found.TaskMetadata.CompletedDate
+	}
+
+	captureStdout(t, func() {
+		if err := taskDoneCommand(cfg).Execute([]string{id}); err != nil {
+			t.Errorf("done error = %v", err)
+		}
+	})
+	if got, want := completedDate(), time.Now().Format("2006-01-02"); got != want {
+		t.Errorf("completed_date after done = %q, want %q", got, want)
+	}
+
+	captureStdout(t, func() {
+		if err := taskReopenCommand(cfg).Execute([]string{id}); err != nil {
+			t.Errorf("reopen error = %v", err)
+		}
+	})
+	if got := completedDate(); got != "" {
+		t.Errorf("completed_date after reopen = %q, want empty", got)
+	}
+}
+
+func TestParseTaskIdentifiersProjectScoped(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantInts []int
+		wantEIDs []string
+		wantRefs []projectTaskRef
+		wantErr  bool
+	}{
+		{args: []string{"3,1-2"}, wantInts: []int{1, 2, 3}},
+		{args: []string{"@12/1-3"}, wantRefs: []projectTaskRef{{"12", 1}, {"12", 2}, {"12", 3}}},
+		{args: []string{"@01JABCDEF/2"}, wantRefs: []projectTaskRef{{"01JABCDEF", 2}}},
+		// A bare number after a scoped reference stays a global index_id
+		{args: []string{"@12/1,3"}, wantInts: []int{3}, wantRefs: []projectTaskRef{{"12", 1}}},
+		// The same global and scoped number name different things
+		{args: []string{"2", "@12/2", "@12/2"}, wantInts: []int{2}, wantRefs: []projectTaskRef{{"12", 2}}},
+		// Without the @ a slash form is not scoped
+		{args: []string{"12/1"}, wantEIDs: []string{"12/1"}},
+		{args: []string{"@12"}, wantErr: true},
+		{args: []string{"@12/"}, wantErr: true},
+		{args: []string{"@/1"}, wantErr: true},
+		{args: []string{"@12/0"}, wantErr: true},
+		{args: []string{"@12/3-1"}, wantErr: true},
+		{args: []string{"@12/x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		ints, eids, refs, err := parseTaskIdentifiers(tt.args)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseTaskIdentifiers(%q) succeeded, want error", tt.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTaskIdentifiers(%q) error = %v", tt.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(ints, tt.wantInts) || !reflect.DeepEqual(eids, tt.wantEIDs) || !reflect.DeepEqual(refs, tt.wantRefs) {
+			t.Errorf("parseTaskIdentifiers(%q) = %v, %v, %v; want %v, %v, %v", tt.args, ints, eids, refs, tt.wantInts, tt.wantEIDs, tt.wantRefs)
+		}
+	}
+}
+
+func TestResolveTasksProjectScoped(t *testing.T) {
+	dir := t.TempDir()
+
+	project, err := task.CreateProject(dir, "Move house", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := newTestTask(t, dir, "Unrelated", nil)
+	var inProject []*denote.Task
+	for _, title := range []string{"Book van", "Pack books", "Forward mail"} {
+		created := newTestTask(t, dir, title, func(tk *denote.Task) {
+			tk.TaskMetadata.ProjectID = strconv.Itoa(project.IndexID)
+		})
+		inProject = append(inProject, created)
+	}
+
+	scoped := "@" + strconv.Itoa(project.IndexID)
+	titles := func(tasks []*denote.Task) []string {
+		var out []string
+		for _, tk := range tasks {
+			out = append(out, tk.Title)
+		}
+		return out
+	}
+
+	found, missing, err := resolveTasks(dir, []string{scoped + "/2-3"})
+	if err != nil || missing != 0 {
+		t.Fatalf("resolveTasks(%s/2-3) = missing %d, err %v", scoped, missing, err)
+	}
+	if got, want := titles(found), []string{"Pack books", "Forward mail"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveTasks(%s/2-3) = %v, want %v", scoped, got, want)
+	}
+
+	// Scoped by ULID, mixed with the global index_id of a task outside the
+	// project and a duplicate of the same task by its own index_id
+	args := []string{"@" + project.ID + "/1," + strconv.Itoa(other.IndexID) + "," + strconv.Itoa(inProject[0].IndexID)}
+	found, missing, err = resolveTasks(dir, args)
+	if err != nil || missing != 0 {
+		t.Fatalf("resolveTasks(%v) = missing %d, err %v", args, missing, err)
+	}
+	if len(found) != 2 {
+		t.Errorf("resolveTasks(%v) = %v, want Unrelated and Book van once each", args, titles(found))
+	}
+
+	// Positions past the end and unknown projects count as missing
+	found, missing, err = resolveTasks(dir, []string{scoped + "/3-4", "@999/1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing != 2 || len(found) != 1 {
+		t.Errorf("resolveTasks(%s/3-4, @999/1) = %v with %d missing, want 1 found and 2 missing", scoped, titles(found), missing)
+	}
+}
+
+func TestTaskNewTemplatePrecedence(t *testing.T) {
+	writeTemplate(t, "weekly-review", `title: Weekly review
+priority: p2
+estimate: 3
+due: +2d
+area: home
+tags: [review, routine]
+body: |
+  - [ ] Empty inbox
+  - [ ] Review projects
+`)
+
+	newTask := func(t *testing.T, args ...string) *denote.Task {
+		t.Helper()
+		dir := t.TempDir()
+		captureStdout(t, func() {
+			if err := taskNewCommand(&config.Config{NotesDirectory: dir}).Execute(args); err != nil {
+				t.Errorf("new %v error = %v", args, err)
+			}
+		})
+		tasks, err := denote.NewScanner(dir).FindTasks()
+		if err != nil || len(tasks) != 1 {
+			t.Fatalf("new %v created %d tasks, err %v; want 1", args, len(tasks), err)
+		}
+		return tasks[0]
+	}
+
+	// Template values alone, including its title and body checklist
+	got := newTask(t, "--template", "weekly-review")
+	if got.Title != "Weekly review" || got.TaskMetadata.Priority != "p2" || got.TaskMetadata.Estimate != 3 || got.TaskMetadata.Area != "home" {
+		t.Errorf("from template: title %q, priority %q, estimate %d, area %q", got.Title, got.TaskMetadata.Priority, got.TaskMetadata.Estimate, got.TaskMetadata.Area)
+	}
+	if want := time.Now().AddDate(0, 0, 2).Format("2006-01-02"); got.TaskMetadata.DueDate != want {
+		t.Errorf("from template: due %q, want %q", got.TaskMetadata.DueDate, want)
+	}
+	if !got.HasTag("review") || !got.HasTag("routine") {
+		t.Errorf("from template: tags %v, want review and routine", got.Tags)
+	}
+	if !strings.Contains(got.Content, "- [ ] Review projects") {
+		t.Errorf("from template: body %q, want the checklist", got.Content)
+	}
+
+	// Explicit flags and title win; unset fields still come from the template
+	got = newTask(t, "--template", "weekly-review", "-p", "p1", "--due", "2026-12-01", "--tags", "focus", "Monthly review")
+	if got.Title != "Monthly review" || got.TaskMetadata.Priority != "p1" || got.TaskMetadata.DueDate != "2026-12-01" {
+		t.Errorf("with flags: title %q, priority %q, due %q; want flags to win", got.Title, got.TaskMetadata.Priority, got.TaskMetadata.DueDate)
+	}
+	if got.HasTag("review") || !got.HasTag("focus") {
+		t.Errorf("with flags: tags %v, want --tags to replace the template's", got.Tags)
+	}
+	if got.TaskMetadata.Estimate != 3 || got.TaskMetadata.Area != "home" || !strings.Contains(got.Content, "Empty inbox") {
+		t.Errorf("with flags: estimate %d, area %q, body %q; want template values", got.TaskMetadata.Estimate, got.TaskMetadata.Area, got.Content)
+	}
+}
+
+func TestTaskNewMissingTemplate(t *testing.T) {
+	writeTemplate(t, "weekly-review", "title: Weekly review\n")
+	dir := t.TempDir()
+
+	err := taskNewCommand(&config.Config{NotesDirectory: dir}).Execute([]string{"--template", "daily", "Plan day"})
+	if err == nil || !strings.Contains(err.Error(), `template "daily" not found`) {
+		t.Fatalf("new --template daily error = %v, want template not found", err)
+	}
+}
+
+func TestTaskQueryFormatJSONLOneTaskPerLine(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	project, err := task.CreateProject(dir, "Garden", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, title := range []string{"Plant bulbs", "Mulch beds"} {
+		newTestTask(t, dir, title, func(tk *denote.Task) {
+			tk.TaskMetadata.Area = "home"
+			tk.TaskMetadata.ProjectID = strconv.Itoa(project.IndexID)
+		})
+	}
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = taskQueryCommand(cfg).Execute([]string{"--format", "jsonl", "area:home"})
+	})
+	if runErr != nil {
+		t.Fatalf("query --format jsonl error = %v", runErr)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("query --format jsonl wrote %d lines, want one per task:\n%s", len(lines), out)
+	}
+	for _, line := range lines {
+		var got struct {
+			denote.Task
+			ProjectName string `json:"project_name"`
+		}
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line is not a JSON object: %v\n%s", err, line)
+		}
+		if got.Title == "" || got.ProjectName != "Garden" {
+			t.Errorf("line %s: title %q, project_name %q; want a task in Garden", line, got.Title, got.ProjectName)
+		}
+	}
+}
+
+func TestTaskPlanSetsAndClearsPlannedFor(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	var ids []string
+	for _, title := range []string{"Draft agenda", "Book room"} {
+		created := newTestTask(t, dir, title, nil)
+		ids = append(ids, strconv.Itoa(created.IndexID))
+	}
+	both := ids[0] + "," + ids[1]
+
+	plannedFor := func() map[string]string {
+		t.Helper()
+		tasks, err := denote.NewScanner(dir).FindTasks()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]string)
+		for _, tk := range tasks {
+			got[strconv.Itoa(tk.IndexID)] = tk.PlannedFor
+		}
+		return got
+	}
+
+	// No date plans for today
+	captureStdout(t, func() {
+		if err := taskPlanCommand(cfg).Execute([]string{both}); err != nil {
+			t.Errorf("plan error = %v", err)
+		}
+	})
+	today := time.Now().Format("2006-01-02")
+	for id, got := range plannedFor() {
+		if got != today {
+			t.Errorf("task %s planned_for = %q after plan, want %q", id, got, today)
+		}
+	}
+
+	// A trailing date applies to every ID, and --json lists the tasks
+	globalFlags.JSON = true
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = taskPlanCommand(cfg).Execute([]string{ids[0], ids[1], "2026-12-24"})
+	})
+	globalFlags.JSON = false
+	if runErr != nil {
+		t.Fatalf("plan with date error = %v", runErr)
+	}
+	var planned []denote.Task
+	if err := json.Unmarshal([]byte(out), &planned); err != nil || len(planned) != 2 {
+		t.Fatalf("plan --json output = %d tasks, err %v; want 2\n%s", len(planned), err, out)
+	}
+	for _, p := range planned {
+		if p.PlannedFor != "2026-12-24" {
+			t.Errorf("plan --json task %d planned_for = %q, want 2026-12-24", p.IndexID, p.PlannedFor)
+		}
+	}
+
+	captureStdout(t, func() {
+		if err := taskPlanCommand(cfg).Execute([]string{"--clear", ids[0]}); err != nil {
+			t.Errorf("plan --clear error = %v", err)
+		}
+	})
+	got := plannedFor()
+	if got[ids[0]] != "" || got[ids[1]] != "2026-12-24" {
+		t.Errorf("after plan --clear %s: planned_for %v, want only task %s cleared", ids[0], got, ids[0])
+	}
+}
+
+func TestTaskListJSONComputedReadiness(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	blocker := newTestTask(t, dir, "Get permit", nil)
+	finished := newTestTask(t, dir, "Measure wall", func(tk *denote.Task) {
+		tk.TaskMetadata.Status = denote.TaskStatusDone
+	})
+	newTestTask(t, dir, "Build shed", func(tk *denote.Task) {
+		tk.TaskMetadata.BlockedBy = []string{blocker.ID, finished.ID}
+	})
+
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+	type listed struct {
+		Title        string   `json:"title"`
+		BlockedBy    []string `json:"blocked_by"`
+		Blocked      *bool    `json:"blocked"`
+		OpenBlockers []string `json:"open_blockers"`
+	}
+	list := func(args ...string) map[string]listed {
+		out := captureStdout(t, func() {
+			if err := taskListCommand(cfg).Execute(args); err != nil {
+				t.Errorf("list %v error = %v", args, err)
+			}
+		})
+		var doc struct {
+			Tasks []listed `json:"tasks"`
+		}
+		if err := json.Unmarshal([]byte(out), &doc); err != nil {
+			t.Fatalf("list --json output is not JSON: %v\n%s", err, out)
+		}
+		byTitle := make(map[string]listed)
+		for _, l := range doc.Tasks {
+			byTitle[l.Title] = l
+		}
+		return byTitle
+	}
+
+	// Without the flag only the stored blocked_by is emitted
+	plain := list()
+	if got := plain["Build shed"]; got.Blocked != nil || got.OpenBlockers != nil || len(got.BlockedBy) != 2 {
+		t.Errorf("list --json = %+v, want blocked_by only", got)
+	}
+
+	computed := list("--json-computed")
+	if got := computed["Build shed"]; got.Blocked == nil || !*got.Blocked ||
+		len(got.OpenBlockers) != 1 || got.OpenBlockers[0] != blocker.ID {
+		t.Errorf("--json-computed waiting task = %+v, want blocked with open_blockers [%s]", got, blocker.ID)
+	}
+	if got := computed["Get permit"]; got.Blocked == nil || *got.Blocked || got.OpenBlockers != nil {
+		t.Errorf("--json-computed ready task = %+v, want blocked false and no open_blockers", got)
+	}
+}
+
+func TestTaskUpdateAddAndRemoveBlocker(t *testing.T) {
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	blocker := newTestTask(t, dir, "Order parts", nil)
+	waiting := newTestTask(t, dir, "Fix bike", nil)
+	id := strconv.Itoa(waiting.IndexID)
+	blockedBy := func() []string {
+		t.Helper()
+		got, err := denote.ParseTaskFile(waiting.FilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got.TaskMetadata.BlockedBy
+	}
+
+	if err := taskUpdateCommand(cfg).Execute([]string{"--add-blocker", blocker.ID, id}); err != nil {
+		t.Fatalf("update --add-blocker error = %v", err)
+	}
+	if got := blockedBy(); !reflect.DeepEqual(got, []string{blocker.ID}) {
+		t.Errorf("blocked_by after --add-blocker = %v, want [%s]", got, blocker.ID)
+	}
+
+	if err := taskUpdateCommand(cfg).Execute([]string{"--add-blocker", "01JZZZZZZZZZZZZZZZZZZZZZZZ", id}); err == nil {
+		t.Error("update --add-blocker with an unknown task succeeded, want error")
+	}
+	if err := taskUpdateCommand(cfg).Execute([]string{"--add-blocker", waiting.ID, id}); err == nil {
+		t.Error("update --add-blocker with the task itself succeeded, want error")
+	}
+
+	if err := taskUpdateCommand(cfg).Execute([]string{"--remove-blocker", blocker.ID, id}); err != nil {
+		t.Fatalf("update --remove-blocker error = %v", err)
+	}
+	if got := blockedBy(); len(got) != 0 {
+		t.Errorf("blocked_by after --remove-blocker = %v, want empty", got)
+	}
+}
+
+func TestTaskListTagMatchesLikeQuery(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	if _, err := task.CreateTask(dir, "Send proposal", "", []string{"Client/Acme", "Urgent"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := task.CreateTask(dir, "Water plants", "", []string{"home"}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		args  []string
+		query string
+		want  string
+	}{
+		{[]string{"--tag", "urgent"}, "tag:urgent", "1\n"},
+		{[]string{"--tag", "CLIENT/*", "--tag", "Urgent"}, "tag:client/* AND tag:urgent", "1\n"},
+		{[]string{"--tag", "task"}, "tag:task", "0\n"},
+	} {
+		list := captureStdout(t, func() {
+			if err := taskListCommand(cfg).Execute(append([]string{"--count"}, tc.args...)); err != nil {
+				t.Errorf("list %v error = %v", tc.args, err)
+			}
+		})
+		query := captureStdout(t, func() {
+			if err := taskQueryCommand(cfg).Execute([]string{"--count", tc.query}); err != nil {
+				t.Errorf("query %q error = %v", tc.query, err)
+			}
+		})
+		if list != tc.want || query != tc.want {
+			t.Errorf("list %v = %q, query %q = %q; want %q for both", tc.args, list, tc.query, query, tc.want)
+		}
+	}
+}
+
+func TestTaskListAndQueryCount(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	for _, tc := range []struct{ title, priority string }{
+		{"Renew lease", "p1"},
+		{"Call plumber", "p1"},
+		{"Sort photos", "p3"},
+	} {
+		newTestTask(t, dir, tc.title, func(tk *denote.Task) {
+			tk.TaskMetadata.Priority = tc.priority
+		})
+	}
+
+	// Filters apply; --limit doesn't cap the count
+	out := captureStdout(t, func() {
+		if err := taskListCommand(cfg).Execute([]string{"--count", "-p", "p1", "--limit", "1"}); err != nil {
+			t.Errorf("list --count error = %v", err)
+		}
+	})
+	if out != "2\n" {
+		t.Errorf("list --count -p p1 output = %q, want \"2\\n\"", out)
+	}
+
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+	out = captureStdout(t, func() {
+		if err := taskQueryCommand(cfg).Execute([]string{"--count", "priority:p3 OR priority:p1"}); err != nil {
+			t.Errorf("query --count error = %v", err)
+		}
+	})
+	if out != "{\"count\":3}\n" {
+		t.Errorf("query --json --count output = %q, want {\"count\":3}", out)
+	}
+}
+
+func TestSoonDaysOverridesHorizon(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir, SoonHorizon: 7}
+
+	for _, tc := range []struct {
+		title string
+		days  int
+	}{
+		{"Book flights", 3},
+		{"Renew insurance", 20},
+	} {
+		newTestTask(t, dir, tc.title, func(tk *denote.Task) {
+			tk.TaskMetadata.DueDate = time.Now().AddDate(0, 0, tc.days).Format("2006-01-02")
+		})
+	}
+
+	for _, tc := range []struct {
+		name string
+		run  func() error
+		want string
+	}{
+		{"list --soon", func() error { return taskListCommand(cfg).Execute([]string{"--soon", "--count"}) }, "1\n"},
+		{"list --soon --soon-days 30", func() error {
+			return taskListCommand(cfg).Execute([]string{"--soon", "--soon-days", "30", "--count"})
+		}, "2\n"},
+		{"query due:soon", func() error { return taskQueryCommand(cfg).Execute([]string{"--count", "due:soon"}) }, "1\n"},
+		{"query due:soon --soon-days 30", func() error {
+			return taskQueryCommand(cfg).Execute([]string{"--soon-days", "30", "--count", "due:soon"})
+		}, "2\n"},
+	} {
+		out := captureStdout(t, func() {
+			if err := tc.run(); err != nil {
+				t.Errorf("%s error = %v", tc.name, err)
+			}
+		})
+		if out != tc.want {
+			t.Errorf("%s output = %q, want %q", tc.name, out, tc.want)
+		}
+	}
+	if cfg.SoonHorizon != 7 {
+		t.Errorf("--soon-days changed the shared config horizon to %d", cfg.SoonHorizon)
+	}
+
+	if err := taskListCommand(cfg).Execute([]string{"--soon", "--soon-days", "-1"}); err == nil {
+		t.Error("list --soon-days -1 succeeded, want error")
+	}
+}
+
+func TestTaskNewAppliesAreaDefaults(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{
+		NotesDirectory: dir,
+		AreaDefaults:   map[string]config.AreaDefaults{"work": {Priority: "p2", Estimate: 3}},
+	}
+
+	newTask := func(args ...string) *denote.Task {
+		t.Helper()
+		var created *denote.Task
+		globalFlags.JSON = true
+		defer func() { globalFlags.JSON = false }()
+		out := captureStdout(t, func() {
+			if err := taskNewCommand(cfg).Execute(args); err != nil {
+				t.Errorf("new %v error = %v", args, err)
+			}
+		})
+		if err := json.Unmarshal([]byte(out), &created); err != nil {
+			t.Fatalf("new %v --json output: %v\n%s", args, err, out)
+		}
+		return created
+	}
+
+	got := newTask("--area", "work", "Prep standup")
+	if got.TaskMetadata.Priority != "p2" || got.TaskMetadata.Estimate != 3 {
+		t.Errorf("work task: priority %q, estimate %d; want area defaults p2 and 3", got.TaskMetadata.Priority, got.TaskMetadata.Estimate)
+	}
+
+	got = newTask("--area", "home", "Fix fence")
+	if got.TaskMetadata.Priority != "" || got.TaskMetadata.Estimate != 0 {
+		t.Errorf("home task: priority %q, estimate %d; want none", got.TaskMetadata.Priority, got.TaskMetadata.Estimate)
+	}
+
+	// Explicit flags win over the area's defaults, field by field
+	got = newTask("--area", "work", "-p", "p1", "Ship hotfix")
+	if got.TaskMetadata.Priority != "p1" || got.TaskMetadata.Estimate != 3 {
+		t.Errorf("work task with -p p1: priority %q, estimate %d; want p1 and 3", got.TaskMetadata.Priority, got.TaskMetadata.Estimate)
+	}
+}
+
+func TestTaskCommandsRejectInvalidPriorityAndStatus(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	err := taskNewCommand(cfg).Execute([]string{"-p", "p9", "Write report"})
+	if err == nil || !strings.Contains(err.Error(), "invalid priority: p9") {
+		t.Fatalf("new -p p9 error = %v, want invalid priority", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("new with invalid priority wrote %d files, want none", len(entries))
+	}
+
+	created := newTestTask(t, dir, "Water plants", nil)
+	before, err := os.ReadFile(created.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := strconv.Itoa(created.IndexID)
+
+	tests := []struct {
+		name string
+		cmd  *Command
+		args []string
+		want string
+	}{
+		{"update --status", taskUpdateCommand(cfg), []string{"--status", "finished", id}, "invalid status: finished"},
+		{"update -p", taskUpdateCommand(cfg), []string{"-p", "high", id}, "invalid priority: high"},
+		{"batch-update --status", taskBatchUpdateCommand(cfg), []string{"--where", "status:open", "--status", "closed"}, "invalid status: closed"},
+		{"batch-update --priority", taskBatchUpdateCommand(cfg), []string{"--where", "status:open", "--priority", "p0"}, "invalid priority: p0"},
+	}
+	for _, tt := range tests {
+		var err error
+		captureStdout(t, func() { err = tt.cmd.Execute(tt.args) })
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s error = %v, want %q", tt.name, err, tt.want)
+		}
+		after, readErr := os.ReadFile(created.FilePath)
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		if string(after) != string(before) {
+			t.Errorf("%s with an invalid value rewrote the task file", tt.name)
+		}
+	}
+}
+
+func TestTaskUpdateAndBatchUpdateValidateEstimate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	created := newTestTask(t, dir, "Plan offsite", func(tk *denote.Task) {
+		tk.TaskMetadata.Estimate = 5
+	})
+	id := strconv.Itoa(created.IndexID)
+
+	estimate := func() int {
+		t.Helper()
+		found, err := task.FindTaskByID(dir, created.IndexID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return found.TaskMetadata.Estimate
+	}
+
+	for _, tt := range []struct {
+		name string
+		cmd  *Command
+		args []string
+	}{
+		{"update", taskUpdateCommand(cfg), []string{"--estimate", "4", id}},
+		{"batch-update", taskBatchUpdateCommand(cfg), []string{"--where", "status:open", "--estimate", "7"}},
+	} {
+		var err error
+		captureStdout(t, func() { err = tt.cmd.Execute(tt.args) })
+		if err == nil || !strings.Contains(err.Error(), "invalid estimate") {
+			t.Errorf("%s %v error = %v, want invalid estimate", tt.name, tt.args, err)
+		}
+		if got := estimate(); got != 5 {
+			t.Errorf("after rejected %s, estimate = %d, want 5", tt.name, got)
+		}
+	}
+
+	// 0 clears the estimate
+	captureStdout(t, func() {
+		if err := taskUpdateCommand(cfg).Execute([]string{"--estimate", "0", id}); err != nil {
+			t.Errorf("update --estimate 0 error = %v", err)
+		}
+	})
+	if got := estimate(); got != 0 {
+		t.Errorf("after --estimate 0, estimate = %d, want 0", got)
+	}
+}
+
+func TestTaskOutputTemplate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	inThreeDays := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	var ids []int
+	for _, tc := range []struct{ title, priority, due string }{
+		{"Renew lease", "p1", yesterday},
+		{"Sort photos", "p3", inThreeDays},
+	} {
+		created := newTestTask(t, dir, tc.title, func(tk *denote.Task) {
+			tk.TaskMetadata.Priority = tc.priority
+			tk.TaskMetadata.DueDate = tc.due
+		})
+		ids = append(ids, created.IndexID)
+	}
+
+	out := captureStdout(t, func() {
+		if err := taskListCommand(cfg).Execute([]string{"--sort", "priority", "--output-template", "{{.IndexID}} {{.Title}} [{{.Priority}}]"}); err != nil {
+			t.Errorf("list --output-template error = %v", err)
+		}
+	})
+	want := fmt.Sprintf("%d Renew lease [p1]\n%d Sort photos [p3]\n", ids[0], ids[1])
+	if out != want {
+		t.Errorf("list --output-template output = %q, want %q", out, want)
+	}
+
+	// Helper funcs; the template wins over --json
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+	out = captureStdout(t, func() {
+		if err := taskQueryCommand(cfg).Execute([]string{"--sort", "due", "--output-template",
+			"{{.Title}}: {{if overdue .DueDate}}overdue{{else}}in {{daysUntil .DueDate}}d{{end}}", "status:open"}); err != nil {
+			t.Errorf("query --output-template error = %v", err)
+		}
+	})
+	if want := "Renew lease: overdue\nSort photos: in 3d\n"; out != want {
+		t.Errorf("query --output-template output = %q, want %q", out, want)
+	}
+
+	out = captureStdout(t, func() {
+		if err := taskShowCommand(cfg).Execute([]string{strconv.Itoa(ids[1]), "--output-template", "{{.Title}} due {{.DueDate}}"}); err != nil {
+			t.Errorf("show --output-template error = %v", err)
+		}
+	})
+	if want := "Sort photos due " + inThreeDays + "\n"; out != want {
+		t.Errorf("show --output-template output = %q, want %q", out, want)
+	}
+
+	// Bad templates fail without printing anything
+	for _, bad := range []string{"{{.Title", "{{.NoSuchField}}", "{{nosuchfunc .Title}}"} {
+		var runErr error
+		out = captureStdout(t, func() {
+			runErr = taskListCommand(cfg).Execute([]string{"--output-template", bad})
+		})
+		if runErr == nil || out != "" {
+			t.Errorf("list --output-template %q = output %q, err %v; want error and no output", bad, out, runErr)
+		}
+	}
+}
+
+func TestColumnHelpersHandleWideRunes(t *testing.T) {
+	for _, s := range []string{
+		"日本語のタイトルはとても長いのでここで切られます",
+		"🎉🎉 Plan the launch party with everyone 🎉🎉",
+		"Ünïcödé àccents stay intact in the truncated column",
+		"short",
+	} {
+		got := truncateColumn(s, 20)
+		if !utf8.ValidString(got) {
+			t.Errorf("truncateColumn(%q) = %q, broken UTF-8", s, got)
+		}
+		if w := runewidth.StringWidth(got); w > 20 {
+			t.Errorf("truncateColumn(%q) = %q, width %d > 20", s, got, w)
+		}
+		if padded := padColumn(s, 20); runewidth.StringWidth(padded) != 20 {
+			t.Errorf("padColumn(%q) = %q, width %d, want 20", s, padded, runewidth.StringWidth(padded))
+		}
+	}
+}
+
+func TestTaskListAlignsWideTitles(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	for _, title := range []string{"Renew lease", "会議の資料を準備する", "🎉 Party planning", "請求書を送る前に全ての明細を確認して担当者に連絡する必要があります"} {
+		newTestTask(t, dir, title, func(tk *denote.Task) { tk.TaskMetadata.Area = "home" })
+	}
+
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+	out := captureStdout(t, func() {
+		if err := taskListCommand(cfg).Execute(nil); err != nil {
+			t.Errorf("list error = %v", err)
+		}
+	})
+
+	// The area column starts at the same display column on every line
+	areaCol := -1
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		if !utf8.ValidString(line) {
+			t.Errorf("line %q is not valid UTF-8", line)
+		}
+		i := strings.LastIndex(line, " home")
+		if i < 0 {
+			t.Fatalf("line %q has no area column", line)
+		}
+		col := runewidth.StringWidth(line[:i])
+		if areaCol == -1 {
+			areaCol = col
+		} else if col != areaCol {
+			t.Errorf("area column at %d in %q, want %d", col, line, areaCol)
+		}
+	}
+}
+
+func TestTaskListGroupByProject(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	website, err := task.CreateProject(dir, "Website", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	garden, err := task.CreateProject(dir, "Garden", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		title, priority string
+		project         *denote.Project
+	}{
+		{"Write copy", "p2", website},
+		{"Pay rent", "p1", nil},
+		{"Deploy site", "p1", website},
+		{"Plant bulbs", "p3", garden},
+	} {
+		newTestTask(t, dir, tc.title, func(tk *denote.Task) {
+			tk.TaskMetadata.Priority = tc.priority
+			if tc.project != nil {
+				tk.TaskMetadata.ProjectID = strconv.Itoa(tc.project.IndexID)
+			}
+		})
+	}
+
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+
+	// Groups sorted by name with (none) last; --sort applies within groups
+	out := captureStdout(t, func() {
+		if err := taskListCommand(cfg).Execute([]string{"--group-by", "project", "--sort", "priority"}); err != nil {
+			t.Errorf("list --group-by error = %v", err)
+		}
+	})
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if strings.HasSuffix(line, "):") {
+			got = append(got, line)
+		} else {
+			for _, title := range []string{"Write copy", "Pay rent", "Deploy site", "Plant bulbs"} {
+				if strings.Contains(line, title) {
+					got = append(got, "  "+title)
+				}
+			}
+		}
+	}
+	want := []string{"Garden (1):", "  Plant bulbs", "Website (2):", "  Deploy site", "  Write copy", "(none) (1):", "  Pay rent"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("list --group-by project =\n%q\nwant\n%q", got, want)
+	}
+
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+	out = captureStdout(t, func() {
+		if err := taskListCommand(cfg).Execute([]string{"--group-by", "project"}); err != nil {
+			t.Errorf("list --group-by --json error = %v", err)
+		}
+	})
+	var result struct {
+		SchemaVersion int `json:"schema_version"`
+		Tasks         []struct {
+			Title string `json:"title"`
+		} `json:"tasks"`
+		GroupBy string `json:"group_by"`
+		Groups  map[string][]struct {
+			Title string `json:"title"`
+		} `json:"groups"`
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	// The schema_version 1 fields stay, with groups added next to them
+	if result.SchemaVersion != jsonSchemaVersion || len(result.Tasks) != 4 {
+		t.Errorf("list --group-by --json = schema_version %d with %d tasks, want %d with all 4",
+			result.SchemaVersion, len(result.Tasks), jsonSchemaVersion)
+	}
+	if result.GroupBy != "project" || result.Count != 4 || len(result.Groups) != 3 ||
+		len(result.Groups["Website"]) != 2 || len(result.Groups["Garden"]) != 1 ||
+		len(result.Groups["(none)"]) != 1 || result.Groups["(none)"][0].Title != "Pay rent" {
+		t.Errorf("list --group-by project --json = %+v", result)
+	}
+
+	if err := taskListCommand(cfg).Execute([]string{"--group-by", "tag"}); err == nil {
+		t.Error("list --group-by tag succeeded, want error")
+	}
+}
+
+func TestTaskLogListAndDeleteRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	created := newTestTask(t, dir, "Fix fence", nil)
+	id := strconv.Itoa(created.IndexID)
+
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+	for _, msg := range []string{"Bought posts", "Called nieghbour", "Dug holes"} {
+		if err := taskLogCommand(cfg).Execute([]string{id, msg}); err != nil {
+			t.Fatalf("log %q error = %v", msg, err)
+		}
+	}
+
+	list := func() []string {
+		t.Helper()
+		globalFlags.JSON = true
+		defer func() { globalFlags.JSON = false }()
+		var runErr error
+		out := captureStdout(t, func() {
+			runErr = taskLogCommand(cfg).Execute([]string{id, "--list"})
+		})
+		if runErr != nil {
+			t.Fatalf("log --list error = %v", runErr)
+		}
+		var entries []struct {
+			Index   int    `json:"index"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal([]byte(out), &entries); err != nil {
+			t.Fatalf("log --list output is not JSON: %v\n%s", err, out)
+		}
+		var messages []string
+		for i, e := range entries {
+			if e.Index != i+1 {
+				t.Errorf("entry %d has index %d", i, e.Index)
+			}
+			messages = append(messages, e.Message)
+		}
+		return messages
+	}
+
+	// Newest first, as in the file
+	if got, want := list(), []string{"Dug holes", "Called nieghbour", "Bought posts"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("log --list = %q, want %q", got, want)
+	}
+
+	// Delete the typo by its number, then another by exact line
+	if err := taskLogCommand(cfg).Execute([]string{id, "--delete-index", "2"}); err != nil {
+		t.Fatalf("log --delete-index 2 error = %v", err)
+	}
+	if got, want := list(), []string{"Dug holes", "Bought posts"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after --delete-index 2, log --list = %q, want %q", got, want)
+	}
+
+	reloaded, err := lookupTask(dir, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := denote.ParseLogEntries(reloaded.Content)[0].Line()
+	if err := taskLogCommand(cfg).Execute([]string{id, "--delete", line}); err != nil {
+		t.Fatalf("log --delete %q error = %v", line, err)
+	}
+	if got, want := list(), []string{"Bought posts"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after deleting by line, log --list = %q, want %q", got, want)
+	}
+
+	// --delete only ever matches the exact line, never a number
+	if err := taskLogCommand(cfg).Execute([]string{id, "--delete", "1"}); err == nil {
+		t.Error("log --delete 1 succeeded, want no entry to match")
+	}
+	if got, want := list(), []string{"Bought posts"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after --delete 1, log --list = %q, want %q", got, want)
+	}
+
+	if err := taskLogCommand(cfg).Execute([]string{id, "--delete-index", "5"}); err == nil {
+		t.Error("log --delete-index 5 on a one-entry log succeeded, want error")
+	}
+}
+
+func TestTaskBumpOffsetsAndMissingDue(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	ids := make(map[string]string)
+	for _, tc := range []struct{ title, due string }{
+		{"Forward", "2026-03-10"},
+		{"Back", "2026-03-10"},
+		{"Undated", ""},
+	} {
+		created := newTestTask(t, dir, tc.title, func(tk *denote.Task) {
+			tk.TaskMetadata.DueDate = tc.due
+		})
+		ids[tc.title] = strconv.Itoa(created.IndexID)
+	}
+	dueOf := func(title string) string {
+		t.Helper()
+		got, err := lookupTask(dir, ids[title])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got.TaskMetadata.DueDate
+	}
+
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+
+	if err := taskBumpCommand(cfg).Execute([]string{ids["Forward"], "+3d"}); err != nil {
+		t.Fatalf("bump +3d error = %v", err)
+	}
+	if got := dueOf("Forward"); got != "2026-03-13" {
+		t.Errorf("bump +3d due = %s, want 2026-03-13", got)
+	}
+
+	if err := taskBumpCommand(cfg).Execute([]string{ids["Back"], "-1w"}); err != nil {
+		t.Fatalf("bump -1w error = %v", err)
+	}
+	if got := dueOf("Back"); got != "2026-03-03" {
+		t.Errorf("bump -1w due = %s, want 2026-03-03", got)
+	}
+
+	// Without a due date the task fails unless --from-today
+	if err := taskBumpCommand(cfg).Execute([]string{ids["Undated"], "+2d"}); err == nil {
+		t.Error("bump of a task with no due date succeeded, want error")
+	}
+	if got := dueOf("Undated"); got != "" {
+		t.Errorf("failed bump set due = %s", got)
+	}
+	if err := taskBumpCommand(cfg).Execute([]string{ids["Undated"], "+2d", "--from-today"}); err != nil {
+		t.Fatalf("bump --from-today error = %v", err)
+	}
+	if got, want := dueOf("Undated"), time.Now().AddDate(0, 0, 2).Format("2006-01-02"); got != want {
+		t.Errorf("bump --from-today due = %s, want %s", got, want)
+	}
+}
+
+// mergeFixture is a source task merged into a target, plus a task that
+// blocks on and relates to the source.
+type mergeFixture struct {
+	source, target, waiting *denote.Task
+}
+
+const (
+	mergePersonA = "01JKR7SPERS0NA000000000001"
+	mergePersonB = "01JKR7SPERS0NB000000000002"
+	mergeIdea    = "01JKR7S1DEA00000000000000A"
+)
+
+func newMergeFixture(t *testing.T, dir string) mergeFixture {
+	t.Helper()
+	var f mergeFixture
+	var err error
+	if f.target, err = task.CreateTask(dir, "Book flights", "Window seats", []string{"travel"}, "home"); err != nil {
+		t.Fatal(err)
+	}
+	f.target.TaskMetadata.Priority = denote.PriorityP2
+	f.target.RelatedPeople = []string{mergePersonA}
+	if err := task.UpdateTaskFile(f.target.FilePath, f.target); err != nil {
+		t.Fatal(err)
+	}
+	if f.source, err = task.CreateTask(dir, "Book flight", "Check baggage limits", []string{"travel", "urgent"}, "work"); err != nil {
+		t.Fatal(err)
+	}
+	f.source.TaskMetadata.Priority = denote.PriorityP1
+	f.source.RelatedPeople = []string{mergePersonA, mergePersonB}
+	f.source.RelatedIdeas = []string{mergeIdea}
+	f.source.RelatedTasks = []string{f.target.ID}
+	if err := task.UpdateTaskFile(f.source.FilePath, f.source); err != nil {
+		t.Fatal(err)
+	}
+	f.waiting = newTestTask(t, dir, "Pack bags", func(tk *denote.Task) {
+		tk.TaskMetadata.BlockedBy = []string{f.source.ID}
+		tk.RelatedTasks = []string{f.source.ID, f.target.ID}
+	})
+	return f
+}
+
+func TestTaskMergeKeepsTargetFieldsAndRedirectsReferences(t *testing.T) {
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+	// Keep relation back-links away from the real vaults
+	t.Setenv("HOME", t.TempDir())
+
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+	f := newMergeFixture(t, dir)
+
+	args := []string{strconv.Itoa(f.source.IndexID), strconv.Itoa(f.target.IndexID)}
+	if err := taskMergeCommand(cfg).Execute(args); err != nil {
+		t.Fatalf("merge error = %v", err)
+	}
+
+	target, err := denote.ParseTaskFile(f.target.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.TaskMetadata.Priority != denote.PriorityP2 || target.TaskMetadata.Area != "home" || target.Title != "Book flights" {
+		t.Errorf("merged target = (%s, %s, %q), want the target's own priority, area and title",
+			target.TaskMetadata.Priority, target.TaskMetadata.Area, target.Title)
+	}
+	if want := []string{"travel", "urgent"}; !reflect.DeepEqual(target.Tags, want) {
+		t.Errorf("tags = %v, want %v", target.Tags, want)
+	}
+	if want := []string{mergePersonA, mergePersonB}; !reflect.DeepEqual(target.RelatedPeople, want) {
+		t.Errorf("related_people = %v, want %v", target.RelatedPeople, want)
+	}
+	if want := []string{mergeIdea}; !reflect.DeepEqual(target.RelatedIdeas, want) {
+		t.Errorf("related_ideas = %v, want %v", target.RelatedIdeas, want)
+	}
+	if len(target.RelatedTasks) != 0 {
+		t.Errorf("related_tasks = %v, want the link to itself dropped", target.RelatedTasks)
+	}
+	if !strings.Contains(target.Content, "Window seats") || !strings.Contains(target.Content, "Check baggage limits") {
+		t.Errorf("merged body = %q, want both bodies", target.Content)
+	}
+
+	waiting, err := denote.ParseTaskFile(f.waiting.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{f.target.ID}; !reflect.DeepEqual(waiting.TaskMetadata.BlockedBy, want) {
+		t.Errorf("blocked_by = %v, want %v", waiting.TaskMetadata.BlockedBy, want)
+	}
+	if want := []string{f.target.ID}; !reflect.DeepEqual(waiting.RelatedTasks, want) {
+		t.Errorf("related_tasks = %v, want the source redirected and deduplicated to %v", waiting.RelatedTasks, want)
+	}
+
+	// The source is dropped by default, not deleted
+	source, err := denote.ParseTaskFile(f.source.FilePath)
+	if err != nil {
+		t.Fatalf("source task after merge: %v", err)
+	}
+	if source.TaskMetadata.Status != denote.TaskStatusDropped {
+		t.Errorf("source status = %s, want dropped", source.TaskMetadata.Status)
+	}
+}
+
+func TestTaskMergeDeleteRemovesSource(t *testing.T) {
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+	t.Setenv("HOME", t.TempDir())
+
+	dir := t.TempDir()
+	f := newMergeFixture(t, dir)
+
+	args := []string{"--delete", strconv.Itoa(f.source.IndexID), strconv.Itoa(f.target.IndexID)}
+	if err := taskMergeCommand(&config.Config{NotesDirectory: dir}).Execute(args); err != nil {
+		t.Fatalf("merge --delete error = %v", err)
+	}
+	if _, err := os.Stat(f.source.FilePath); !os.IsNotExist(err) {
+		t.Errorf("source file after merge --delete: %v, want it removed", err)
+	}
+}
+
+func TestTaskMergeDryRunShowsChanges(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+	f := newMergeFixture(t, dir)
+	before, err := os.ReadFile(f.target.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"--dry-run", strconv.Itoa(f.source.IndexID), strconv.Itoa(f.target.IndexID)}
+	out := captureStdout(t, func() {
+		if err := taskMergeCommand(cfg).Execute(args); err != nil {
+			t.Errorf("merge --dry-run error = %v", err)
+		}
+	})
+	for _, want := range []string{
+		"  add tag: urgent\n",
+		"  add related person: " + mergePersonB + "\n",
+		"  add related idea: " + mergeIdea + "\n",
+		"  append body and log from #" + strconv.Itoa(f.source.IndexID) + "\n",
+		fmt.Sprintf("  point task #%d (Pack bags) at #%d\n", f.waiting.IndexID, f.target.IndexID),
+		fmt.Sprintf("  drop task #%d\n", f.source.IndexID),
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("merge --dry-run output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "add tag: travel") || strings.Contains(out, "add related person: "+mergePersonA) {
+		t.Errorf("merge --dry-run lists values the target already has:\n%s", out)
+	}
+
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+	out = captureStdout(t, func() {
+		if err := taskMergeCommand(cfg).Execute(args); err != nil {
+			t.Errorf("merge --dry-run --json error = %v", err)
+		}
+	})
+	var result struct {
+		Added      mergeAdditions `json:"added"`
+		Redirected []int          `json:"redirected"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("merge --dry-run --json output is not JSON: %v\n%s", err, out)
+	}
+	if !reflect.DeepEqual(result.Added.Tags, []string{"urgent"}) || !reflect.DeepEqual(result.Redirected, []int{f.waiting.IndexID}) {
+		t.Errorf("merge --dry-run --json = %+v, want tag urgent added and #%d redirected", result, f.waiting.IndexID)
+	}
+
+	if after, _ := os.ReadFile(f.target.FilePath); string(after) != string(before) {
+		t.Error("merge --dry-run changed the target file")
+	}
+	if waiting, _ := denote.ParseTaskFile(f.waiting.FilePath); !reflect.DeepEqual(waiting.TaskMetadata.BlockedBy, []string{f.source.ID}) {
+		t.Error("merge --dry-run rewrote references to the source")
+	}
+}
+
+func TestASCIIFlagKeepsOutputASCII(t *testing.T) {
+	globalFlags.ASCII = true
+	t.Cleanup(func() { globalFlags.ASCII = false })
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+	cfg.Tasks.EstimateMinutes = 30
+
+	project, err := task.CreateProject(dir, "Garden", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hose := newTestTask(t, dir, "Buy hose", func(tk *denote.Task) {
+		tk.TaskMetadata.Status = denote.TaskStatusDelegated
+		tk.TaskMetadata.Assignee = "sam"
+	})
+	water := newTestTask(t, dir, "Water plants", func(tk *denote.Task) {
+		tk.TaskMetadata.Priority = denote.PriorityP1
+		tk.TaskMetadata.Estimate = 5
+		tk.TaskMetadata.DueDate = time.Now().Format("2006-01-02")
+		tk.TaskMetadata.ProjectID = strconv.Itoa(project.IndexID)
+		tk.TaskMetadata.BlockedBy = []string{hose.ID}
+	})
+	if err := taskUpdateCommand(cfg).Execute([]string{"--recur", "daily", strconv.Itoa(water.IndexID)}); err != nil {
+		t.Fatal(err)
+	}
+	waterID, hoseID := strconv.Itoa(water.IndexID), strconv.Itoa(hose.IndexID)
+
+	for _, run := range []struct {
+		name string
+		cmd  *Command
+		args []string
+	}{
+		{"list", taskListCommand(cfg), []string{"--all"}},
+		{"show", taskShowCommand(cfg), []string{waterID}},
+		{"query", taskQueryCommand(cfg), []string{"status:open"}},
+		{"today", TodayCommand(cfg), nil},
+		{"promote", taskPromoteCommand(cfg), []string{hoseID}},
+		{"bump", taskBumpCommand(cfg), []string{waterID, "+1d"}},
+		{"move", taskMoveCommand(cfg), []string{"--to-area", "home", hoseID}},
+		{"batch-update", taskBatchUpdateCommand(cfg), []string{"--where", "status:open", "--priority", "p2", "--preview"}},
+		{"done --dry-run", taskDoneCommand(cfg), []string{"--dry-run", waterID}},
+		{"done", taskDoneCommand(cfg), []string{waterID}},
+		{"reopen", taskReopenCommand(cfg), []string{waterID}},
+	} {
+		out := captureStdout(t, func() {
+			if err := run.cmd.Execute(run.args); err != nil {
+				t.Errorf("%s error = %v", run.name, err)
+			}
+		})
+		for _, r := range out {
+			if r > unicode.MaxASCII {
+				t.Errorf("%s --ascii printed %q:\n%s", run.name, r, out)
+				break
+			}
+		}
+	}
+}
+
+func TestTaskBumpKeepWeekday(t *testing.T) {
+	globalFlags.Quiet = true
+	t.Cleanup(func() { globalFlags.Quiet = false })
+	dir := t.TempDir()
+	cfg := &config.Config{NotesDirectory: dir}
+
+	for _, tc := range []struct {
+		due, offset, want string
+	}{
+		// 2026-03-09 is a Monday; snapping moves on in the bump's direction
+		{"2026-03-09", "+3d", "2026-03-16"},
+		{"2026-03-09", "+1w", "2026-03-16"},
+		{"2026-03-09", "+1m", "2026-04-13"},
+		{"2026-03-09", "-3d", "2026-03-02"},
+		// Saturday Jan 31 +1m clamps to Saturday Feb 28 and stays there
+		{"2026-01-31", "+1m", "2026-02-28"},
+	} {
+		created := newTestTask(t, dir, "Standing meeting", func(tk *denote.Task) {
+			tk.TaskMetadata.DueDate = tc.due
+		})
+		if err := taskBumpCommand(cfg).Execute([]string{strconv.Itoa(created.IndexID), tc.offset, "--keep-weekday"}); err != nil {
+			t.Fatalf("bump %s --keep-weekday error = %v", tc.offset, err)
+		}
+		got, err := denote.ParseTaskFile(created.FilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.TaskMetadata.DueDate != tc.want {
+			t.Errorf("bump %s %s --keep-weekday due = %s, want %s", tc.due, tc.offset, got.TaskMetadata.DueDate, tc.want)
+		}
+	}
+}
+
+func TestOverdueAfterGraceCountsCalendarDays(t *testing.T) {
+	noon := time.Date(2026, 6, 10, 12, 0, 0, 0, time.Local)
+	for _, tc := range []struct {
+		due   string
+		grace int
+		want  bool
+	}{
+		{"2026-06-10", 0, false},
+		{"2026-06-09", 0, true},
+		{"2026-06-09", 1, false},
+		{"2026-06-08", 1, true},
+		{"2026-06-03", 7, false},
+		{"2026-06-02", 7, true},
+		{"2026-06-11", 0, false},
+		{"", 0, false},
+	} {
+		if got := overdueAfterGraceAt(tc.due, tc.grace, noon); got != tc.want {
+			t.Errorf("overdueAfterGraceAt(%q, %d) = %v, want %v", tc.due, tc.grace, got, tc.want)
+		}
+	}
+
+	// Two calendar days that span the spring-forward change are only 47 hours
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no tz database: %v", err)
+	}
+	justAfterMidnight := time.Date(2026, 3, 9, 0, 30, 0, 0, ny)
+	if !overdueAfterGraceAt("2026-03-07", 1, justAfterMidnight) {
+		t.Error("due two days ago across DST with grace 1 is not overdue, want overdue")
+	}
+	if overdueAfterGraceAt("2026-03-08", 1, justAfterMidnight) {
+		t.Error("due yesterday with grace 1 is overdue, want within grace")
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+// taskTemplateFuncs are the helpers available to --output-template, on top
+// of the text/template builtins.
+var taskTemplateFuncs = template.FuncMap{
+	// overdue reports whether a YYYY-MM-DD date is before today
+	"overdue": denote.IsOverdue,
+	// daysUntil returns whole days from today to a YYYY-MM-DD date,
+	// negative when past and 0 when empty or unparseable
+	"daysUntil": denote.DaysUntilDue,
+	"join":      strings.Join,
+}
+
+// parseTaskTemplate parses an --output-template. Each task is rendered
+// with the denote.Task as data, so fields like {{.IndexID}}, {{.Title}},
+// and {{.Priority}} are available.
+func parseTaskTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("output").Funcs(taskTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --output-template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// writeTasksTemplate renders each task through tmpl, one per line. All
+// tasks are rendered before anything is written, so a template that fails
+// on some task (e.g. an unknown field) produces an error and no output.
+func writeTasksTemplate(w io.Writer, tmpl *template.Template, tasks []denote.Task) error {
+	var buf bytes.Buffer
+	for i := range tasks {
+		if err := tmpl.Execute(&buf, &tasks[i]); err != nil {
+			return fmt.Errorf("invalid --output-template: %w", err)
+		}
+		if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// outputTemplate parses --output-template when it is set, returning nil
+// otherwise. A template replaces the output format, so it cannot be
+// combined with an explicit --format; it does take precedence over --json.
+func outputTemplate(text, format string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	if format != "" {
+		return nil, fmt.Errorf("--output-template cannot be combined with --format")
+	}
+	return parseTaskTemplate(text)
+}
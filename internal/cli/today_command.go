@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+// todayView groups the open tasks that need attention today. A task is
+// listed once, in the first section it qualifies for.
+type todayView struct {
+	Overdue  []denote.Task `json:"overdue"`
+	DueToday []denote.Task `json:"due_today"`
+	Planned  []denote.Task `json:"planned"`
+}
+
+// buildTodayView sorts open tasks in area (all areas if empty) into the
+// overdue, due-today, and planned-for-today sections.
+func buildTodayView(tasks []*denote.Task, area string, projectNames map[string]string) todayView {
+	today := time.Now().Format("2006-01-02")
+	view := todayView{
+		Overdue:  []denote.Task{},
+		DueToday: []denote.Task{},
+		Planned:  []denote.Task{},
+	}
+	for _, t := range tasks {
+		if !denote.IsOpenTaskStatus(t.TaskMetadata.Status) {
+			continue
+		}
+		if area != "" && t.TaskMetadata.Area != area {
+			continue
+		}
+
+		due := t.TaskMetadata.DueDate
+		switch {
+		case denote.IsOverdue(due):
+			view.Overdue = append(view.Overdue, *t)
+		case due != "" && denote.DaysUntilDue(due) == 0:
+			view.DueToday = append(view.DueToday, *t)
+		case t.PlannedFor == today:
+			view.Planned = append(view.Planned, *t)
+		}
+	}
+
+	sortTasks(view.Overdue, "due", false, projectNames)
+	sortTasks(view.DueToday, "priority", false, projectNames)
+	sortTasks(view.Planned, "priority", false, projectNames)
+	return view
+}
+
+// TodayCommand returns the today command, a morning view of overdue,
+// due-today, and planned tasks
+func TodayCommand(cfg *config.Config) *Command {
+	var area string
+
+	cmd := &Command{
+		Name:        "today",
+		Usage:       "atask today [--area area]",
+		Description: "Show overdue, due-today, and planned-for-today tasks",
+		Flags:       flag.NewFlagSet("today", flag.ExitOnError),
+	}
+
+	cmd.Flags.StringVar(&area, "area", "", "Only show tasks in this area")
+
+	cmd.Run = func(c *Command, args []string) error {
+		if area == "" {
+			area = globalFlags.Area
+		}
+
+		scanner := denote.NewScanner(cfg.NotesDirectory)
+		tasks, err := scanner.FindTasks()
+		if err != nil {
+			return fmt.Errorf("failed to scan directory: %v", err)
+		}
+		projects, _ := scanner.FindProjects()
+		projectNames := make(map[string]string)
+		for _, p := range projects {
+			projectNames[strconv.Itoa(p.IndexID)] = p.Title
+		}
+		warnParseErrors(scanner)
+
+		view := buildTodayView(tasks, area, projectNames)
+
+		if globalFlags.JSON {
+			data, err := json.MarshalIndent(view, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal JSON: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if globalFlags.NoColor || color.NoColor {
+			color.NoColor = true
+		}
+
+		if len(view.Overdue)+len(view.DueToday)+len(view.Planned) == 0 {
+			if !globalFlags.Quiet {
+				fmt.Println("Nothing overdue, due, or planned today")
+			}
+			return nil
+		}
+
+		ic := icons(cfg)
+		printTodaySection(ic, "Overdue", view.Overdue, projectNames, color.New(color.FgRed, color.Bold))
+		printTodaySection(ic, "Due today", view.DueToday, projectNames, color.New(color.FgYellow))
+		printTodaySection(ic, "Planned", view.Planned, projectNames, color.New(color.FgCyan))
+		return nil
+	}
+
+	return cmd
+}
+
+// printTodaySection prints one section of the today view, skipping it when
+// empty.
+func printTodaySection(ic iconSet, heading string, tasks []denote.Task, projectNames map[string]string, headingColor *color.Color) {
+	if len(tasks) == 0 {
+		return
+	}
+	fmt.Println(headingColor.Sprintf("%s (%d):", heading, len(tasks)))
+	for _, t := range tasks {
+		dueStr := "            "
+		if t.TaskMetadata.DueDate != "" {
+			dueStr = fmt.Sprintf("[%s]", t.TaskMetadata.DueDate)
+		}
+		projectName := ""
+		if id := t.TaskMetadata.ProjectID; id != "" {
+			if name := projectNames[id]; name != "" {
+				projectName = ic.arrow + " " + name
+			} else {
+				projectName = ic.arrow + " " + id
+			}
+		}
+		fmt.Printf("%3d %s %s %s  %s %s\n",
+			t.IndexID,
+			ic.taskStatus(t.TaskMetadata.Status),
+			ic.priorityMarker(t.TaskMetadata.Priority),
+			dueStr,
+			padColumn(t.Title, 50),
+			projectName,
+		)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+func TestBuildTodayViewGroupsAndDeduplicates(t *testing.T) {
+	day := func(offset int) string { return time.Now().AddDate(0, 0, offset).Format("2006-01-02") }
+	newTask := func(title, status, due, planned, area string) *denote.Task {
+		tk := &denote.Task{}
+		tk.Title = title
+		tk.TaskMetadata.Status = status
+		tk.TaskMetadata.DueDate = due
+		tk.TaskMetadata.Area = area
+		tk.PlannedFor = planned
+		return tk
+	}
+
+	tasks := []*denote.Task{
+		// Overdue and planned for today: listed once, as overdue
+		newTask("Pay rent", denote.TaskStatusOpen, day(-2), day(0), "home"),
+		newTask("Call bank", denote.TaskStatusOpen, day(0), "", "home"),
+		newTask("Review PR", denote.TaskStatusInProgress, "", day(0), "work"),
+		newTask("Planned yesterday", denote.TaskStatusOpen, "", day(-1), "home"),
+		newTask("Due next week", denote.TaskStatusOpen, day(7), "", "home"),
+		newTask("Already done", denote.TaskStatusDone, day(-1), day(0), "home"),
+	}
+
+	titles := func(ts []denote.Task) []string {
+		var out []string
+		for _, tk := range ts {
+			out = append(out, tk.Title)
+		}
+		return out
+	}
+
+	view := buildTodayView(tasks, "", nil)
+	if got := titles(view.Overdue); len(got) != 1 || got[0] != "Pay rent" {
+		t.Errorf("overdue = %v, want [Pay rent]", got)
+	}
+	if got := titles(view.DueToday); len(got) != 1 || got[0] != "Call bank" {
+		t.Errorf("due today = %v, want [Call bank]", got)
+	}
+	if got := titles(view.Planned); len(got) != 1 || got[0] != "Review PR" {
+		t.Errorf("planned = %v, want [Review PR]", got)
+	}
+
+	view = buildTodayView(tasks, "work", nil)
+	if len(view.Overdue) != 0 || len(view.DueToday) != 0 || len(view.Planned) != 1 {
+		t.Errorf("area work: %v / %v / %v, want only Review PR planned", titles(view.Overdue), titles(view.DueToday), titles(view.Planned))
+	}
+}
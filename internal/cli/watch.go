@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long the notes directory must stay quiet before a
+// redraw, so a burst of writes (an editor save, a batch update) redraws once.
+const watchDebounce = 300 * time.Millisecond
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// watchTaskList runs render, then reruns it whenever a task file in dir
+// changes, until interrupted with Ctrl-C.
+func watchTaskList(dir string, render func() error) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start watcher: %w", err)
+	}
+	defer watcher.Close()
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	redraw := func() {
+		fmt.Print(clearScreen)
+		if err := render(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		if !globalFlags.Quiet {
+			fmt.Printf("\nWatching %s (Ctrl-C to exit)\n", dir)
+		}
+	}
+
+	redraw()
+	watchLoop(ctx, watcher.Events, watcher.Errors, watchDebounce, redraw)
+	return nil
+}
+
+// watchLoop calls redraw once events for note files have stopped arriving
+// for delay, and returns when ctx is done or the event channel closes.
+func watchLoop(ctx context.Context, events <-chan fsnotify.Event, errs <-chan error, delay time.Duration, redraw func()) {
+	timer := time.NewTimer(delay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if isWatchedNote(ev.Name) {
+				timer.Reset(delay)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Watch error: %v\n", err)
+		case <-timer.C:
+			redraw()
+		}
+	}
+}
+
+// isWatchedNote reports whether a changed path is a note file. Hidden files
+// are skipped, which covers the temp copies atomic task writes rename.
+func isWatchedNote(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasSuffix(name, ".md") && !strings.HasPrefix(name, ".")
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/mph-llm-experiments/atask/internal/config"
+)
+
+func TestWatchLoopDebouncesNoteChanges(t *testing.T) {
+	events := make(chan fsnotify.Event)
+	errs := make(chan error)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var redraws atomic.Int32
+	done := make(chan struct{})
+	go func() {
+		watchLoop(ctx, events, errs, 50*time.Millisecond, func() { redraws.Add(1) })
+		close(done)
+	}()
+
+	// A burst of writes redraws once
+	for _, name := range []string{"20260101T000000--a__task.md", "20260101T000000--a__task.md", "20260102T000000--b__task.md"} {
+		events <- fsnotify.Event{Name: "/notes/" + name, Op: fsnotify.Write}
+	}
+	time.Sleep(200 * time.Millisecond)
+	if got := redraws.Load(); got != 1 {
+		t.Errorf("redraws after a burst = %d, want 1", got)
+	}
+
+	// Temp copies and other files are ignored
+	events <- fsnotify.Event{Name: "/notes/.20260101T000000--a__task.md.123.tmp", Op: fsnotify.Create}
+	events <- fsnotify.Event{Name: "/notes/config.toml", Op: fsnotify.Write}
+	time.Sleep(200 * time.Millisecond)
+	if got := redraws.Load(); got != 1 {
+		t.Errorf("redraws after non-note changes = %d, want 1", got)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watchLoop did not return after cancel")
+	}
+}
+
+func TestTaskListWatchRejectsJSON(t *testing.T) {
+	cfg := &config.Config{NotesDirectory: t.TempDir()}
+	globalFlags.JSON = true
+	t.Cleanup(func() { globalFlags.JSON = false })
+
+	if err := taskListCommand(cfg).Execute([]string{"--watch"}); err == nil {
+		t.Error("list --watch --json succeeded, want error")
+	}
+}
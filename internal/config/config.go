@@ -10,12 +10,18 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	NotesDirectory string       `toml:"notes_directory"` // Keep name for backward compatibility
-	Editor         string       `toml:"editor"`
-	DefaultArea    string       `toml:"default_area"`
-	SoonHorizon    int          `toml:"soon_horizon"`  // Days for "soon" filter, default 3
-	TUI            TUIConfig    `toml:"tui"`
-	Tasks          TasksConfig  `toml:"tasks"`
+	NotesDirectory string                  `toml:"notes_directory"` // Keep name for backward compatibility
+	Editor         string                  `toml:"editor"`
+	DefaultArea    string                  `toml:"default_area"`
+	SoonHorizon    int                     `toml:"soon_horizon"`  // Days for "soon" filter, default 3
+	OverdueGrace   int                     `toml:"overdue_grace"` // Days past due before `list` treats a task as overdue, default 0
+	TUI            TUIConfig               `toml:"tui"`
+	Tasks          TasksConfig             `toml:"tasks"`
+	Filenames      FilenamesConfig         `toml:"filenames"`
+	Icons          IconsConfig             `toml:"icons"`
+	Sync           SyncConfig              `toml:"sync"`
+	Actions        ActionsConfig           `toml:"actions"`
+	AreaDefaults   map[string]AreaDefaults `toml:"area_defaults"` // Keyed by area name
 }
 
 // TUIConfig represents TUI-specific settings
@@ -27,7 +33,49 @@ type TUIConfig struct {
 type TasksConfig struct {
 	SortBy             string `toml:"sort_by"`              // due, priority, project, estimate, title, created, modified
 	SortOrder          string `toml:"sort_order"`           // normal, reverse
-	DefaultStateFilter string `toml:"default_state_filter"` // incomplete, active, open, paused, done, delegated, dropped, or "" for none
+	DefaultStateFilter string `toml:"default_state_filter"` // incomplete, active, open, in_progress, paused, done, delegated, dropped, or "" for none
+	EstimateMinutes    int    `toml:"estimate_minutes"`     // Minutes per estimate point for time approximations, 0 to hide
+}
+
+// FilenamesConfig controls how new filenames are built from titles
+type FilenamesConfig struct {
+	SlugMaxLength int  `toml:"slug_max_length"` // Max slug characters, 0 for unlimited
+	Transliterate bool `toml:"transliterate"`   // Convert accented letters to ASCII in slugs
+}
+
+// IconsConfig overrides the status icons and priority marker in text
+// output. Empty fields keep the default (or ASCII) icon.
+type IconsConfig struct {
+	ASCII      bool   `toml:"ascii"` // Use the ASCII-only icon set as the base
+	Open       string `toml:"open"`
+	InProgress string `toml:"in_progress"`
+	Done       string `toml:"done"`
+	Paused     string `toml:"paused"` // Tasks and projects
+	Delegated  string `toml:"delegated"`
+	Dropped    string `toml:"dropped"`
+	Active     string `toml:"active"` // Projects only, like completed and cancelled
+	Completed  string `toml:"completed"`
+	Cancelled  string `toml:"cancelled"`
+	Blocked    string `toml:"blocked"`  // Prefix for tasks waiting on a blocker
+	Priority   string `toml:"priority"` // Marker template, e.g. "[{priority}]"
+}
+
+// SyncConfig controls how R2 syncs coordinate with each other
+type SyncConfig struct {
+	LockMode    string `toml:"lock_mode"`    // wait or skip when another sync holds the lock
+	LockTimeout int    `toml:"lock_timeout"` // Seconds to wait for the lock in wait mode
+}
+
+// ActionsConfig controls how approved actions run
+type ActionsConfig struct {
+	PluginTimeout int `toml:"plugin_timeout"` // Seconds before a plugin is killed, default 30
+}
+
+// AreaDefaults fills in metadata for new tasks in an area when the
+// corresponding flag is not given. Zero values set nothing.
+type AreaDefaults struct {
+	Priority string `toml:"priority"` // p1, p2, p3
+	Estimate int    `toml:"estimate"` // 1, 2, 3, 5, 8, or 13
 }
 
 // DefaultConfig returns default configuration
@@ -46,6 +94,13 @@ func DefaultConfig() *Config {
 			SortOrder:          "normal", // Closest due dates first
 			DefaultStateFilter: "incomplete",
 		},
+		Sync: SyncConfig{
+			LockMode:    "wait",
+			LockTimeout: 30,
+		},
+		Actions: ActionsConfig{
+			PluginTimeout: 30,
+		},
 	}
 }
 
@@ -80,7 +135,7 @@ func Load(path string) (*Config, error) {
 	}
 
 	// Expand home directory in paths
-	cfg.NotesDirectory = expandHome(cfg.NotesDirectory)
+	cfg.NotesDirectory = ExpandHome(cfg.NotesDirectory)
 	
 	// Ensure SoonHorizon has a sensible default if not set
 	if cfg.SoonHorizon <= 0 {
@@ -126,6 +181,41 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("notes_directory cannot be empty")
 	}
 
+	if c.Tasks.EstimateMinutes < 0 {
+		return fmt.Errorf("invalid tasks.estimate_minutes: %d (must be 0 or positive)", c.Tasks.EstimateMinutes)
+	}
+
+	if c.OverdueGrace < 0 {
+		return fmt.Errorf("invalid overdue_grace: %d (must be 0 or positive)", c.OverdueGrace)
+	}
+
+	if c.Filenames.SlugMaxLength < 0 {
+		return fmt.Errorf("invalid filenames slug_max_length: %d (must be 0 or positive)", c.Filenames.SlugMaxLength)
+	}
+
+	if c.Sync.LockMode != "" && c.Sync.LockMode != "wait" && c.Sync.LockMode != "skip" {
+		return fmt.Errorf("invalid sync lock_mode: %s (valid: wait, skip)", c.Sync.LockMode)
+	}
+
+	if c.Sync.LockTimeout < 0 {
+		return fmt.Errorf("invalid sync lock_timeout: %d (must be 0 or positive)", c.Sync.LockTimeout)
+	}
+
+	if c.Actions.PluginTimeout < 0 {
+		return fmt.Errorf("invalid actions plugin_timeout: %d (must be 0 or positive)", c.Actions.PluginTimeout)
+	}
+
+	for area, d := range c.AreaDefaults {
+		if d.Priority != "" && d.Priority != "p1" && d.Priority != "p2" && d.Priority != "p3" {
+			return fmt.Errorf("invalid area_defaults.%s priority: %s (valid: p1, p2, p3)", area, d.Priority)
+		}
+		switch d.Estimate {
+		case 0, 1, 2, 3, 5, 8, 13:
+		default:
+			return fmt.Errorf("invalid area_defaults.%s estimate: %d (must be 0, 1, 2, 3, 5, 8, or 13)", area, d.Estimate)
+		}
+	}
+
 	// Check if notes directory exists
 	if info, err := os.Stat(c.NotesDirectory); err != nil {
 		if os.IsNotExist(err) {
@@ -151,7 +241,7 @@ func (c *Config) Validate() error {
 
 	// Validate tasks sort options
 	if c.Tasks.SortBy != "" {
-		validTaskSorts := []string{"due", "priority", "project", "estimate", "title", "created", "modified"}
+		validTaskSorts := []string{"due", "priority", "project", "estimate", "title", "created", "age", "modified"}
 		valid := false
 		for _, sort := range validTaskSorts {
 			if c.Tasks.SortBy == sort {
@@ -160,7 +250,7 @@ func (c *Config) Validate() error {
 			}
 		}
 		if !valid {
-			return fmt.Errorf("invalid tasks sort_by: %s (valid: due, priority, project, estimate, title, created, modified)", c.Tasks.SortBy)
+			return fmt.Errorf("invalid tasks sort_by: %s (valid: due, priority, project, estimate, title, created, age, modified)", c.Tasks.SortBy)
 		}
 	}
 	
@@ -169,7 +259,7 @@ func (c *Config) Validate() error {
 	}
 
 	if c.Tasks.DefaultStateFilter != "" {
-		validStateFilters := []string{"incomplete", "active", "open", "paused", "done", "delegated", "dropped"}
+		validStateFilters := []string{"incomplete", "active", "open", "in_progress", "paused", "done", "delegated", "dropped"}
 		valid := false
 		for _, f := range validStateFilters {
 			if c.Tasks.DefaultStateFilter == f {
@@ -178,7 +268,7 @@ func (c *Config) Validate() error {
 			}
 		}
 		if !valid {
-			return fmt.Errorf("invalid tasks default_state_filter: %s (valid: incomplete, active, open, paused, done, delegated, dropped)", c.Tasks.DefaultStateFilter)
+			return fmt.Errorf("invalid tasks default_state_filter: %s (valid: incomplete, active, open, in_progress, paused, done, delegated, dropped)", c.Tasks.DefaultStateFilter)
 		}
 	}
 
@@ -206,8 +296,14 @@ func findConfigFile() string {
 	return ""
 }
 
-// expandHome expands ~ to home directory
-func expandHome(path string) string {
+// ActivePath returns the config file Load would read when no explicit path
+// is given, or "" if none of the standard locations has one.
+func ActivePath() string {
+	return findConfigFile()
+}
+
+// ExpandHome expands ~ to home directory
+func ExpandHome(path string) string {
 	if path == "" {
 		return path
 	}
@@ -235,4 +331,14 @@ func ConfigPath() string {
 	}
 
 	return filepath.Join(homeDir, ".config", "atask", "config.toml")
-}
\ No newline at end of file
+}
+
+// TemplatesDir returns the directory holding project and task templates,
+// next to the default config file (~/.config/atask/templates).
+func TemplatesDir() string {
+	path := ConfigPath()
+	if path == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(path), "templates")
+}
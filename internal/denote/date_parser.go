@@ -1,9 +1,152 @@
 package denote
 
-import "github.com/mph-llm-experiments/acore"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mph-llm-experiments/acore"
+)
+
+var (
+	// w+2: two weeks from today
+	weeksOutPattern = regexp.MustCompile(`^w\+(\d+)$`)
+	// mon+1w: Monday of the week N weeks from now
+	weekdayOffsetPattern = regexp.MustCompile(`^([a-z]{3})\+(\d+)w$`)
+	// +3d, -1w, +2m, +1y: signed offset relative to an existing date
+	relativeOffsetPattern = regexp.MustCompile(`^([+-])(\d+)([dwmy])$`)
+)
+
+// weekdayOffsets maps a weekday abbreviation to its offset from Monday.
+var weekdayOffsets = map[string]int{
+	"mon": 0, "tue": 1, "wed": 2, "thu": 3, "fri": 4, "sat": 5, "sun": 6,
+}
 
 // ParseNaturalDate parses natural language dates into YYYY-MM-DD format.
-// Delegates to acore.ParseNaturalDate.
+// Planning shortcuts are handled here; everything else delegates to
+// acore.ParseNaturalDate.
+//
+// Planning shortcuts:
+//
+//	w+N     N weeks from today (w+2)
+//	q-end   last day of the current quarter
+//	DDD+Nw  weekday DDD of the week N weeks from now (mon+1w is next week's Monday)
 func ParseNaturalDate(input string) (string, error) {
+	if date, ok := parsePlanningShortcut(input, time.Now()); ok {
+		return date.Format("2006-01-02"), nil
+	}
 	return acore.ParseNaturalDate(input)
 }
+
+// ParseRelativeDate is ParseNaturalDate anchored on base instead of today:
+// signed offsets (+3d, -1w, +2m, +1y) and planning shortcuts shift base,
+// while other natural language ("next friday", "tomorrow") still resolves
+// from today. The result is YYYY-MM-DD.
+func ParseRelativeDate(base time.Time, input string) (string, error) {
+	if s := strings.TrimSpace(input); strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		offset, err := ParseRelativeOffset(input)
+		if err != nil {
+			return "", err
+		}
+		return offset.Apply(base).Format("2006-01-02"), nil
+	}
+	if date, ok := parsePlanningShortcut(input, base); ok {
+		return date.Format("2006-01-02"), nil
+	}
+	return acore.ParseNaturalDate(input)
+}
+
+// parsePlanningShortcut resolves the week/quarter shortcuts relative to now.
+func parsePlanningShortcut(input string, now time.Time) (time.Time, bool) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if s == "q-end" {
+		quarterEndMonth := time.Month((int(today.Month())-1)/3*3 + 3)
+		// Day 0 of the following month is the last day of quarterEndMonth
+		return time.Date(today.Year(), quarterEndMonth+1, 0, 0, 0, 0, 0, today.Location()), true
+	}
+
+	if m := weeksOutPattern.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return time.Time{}, false
+		}
+		return today.AddDate(0, 0, 7*n), true
+	}
+
+	if m := weekdayOffsetPattern.FindStringSubmatch(s); m != nil {
+		offset, ok := weekdayOffsets[m[1]]
+		if !ok {
+			return time.Time{}, false
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		// Monday of the current week, then shift by whole weeks
+		sinceMonday := (int(today.Weekday()) + 6) % 7
+		monday := today.AddDate(0, 0, -sinceMonday)
+		return monday.AddDate(0, 0, 7*n+offset), true
+	}
+
+	return time.Time{}, false
+}
+
+// RelativeOffset is a signed calendar offset such as +3d or -1w.
+type RelativeOffset struct {
+	N    int  // signed amount
+	Unit byte // 'd', 'w', 'm', or 'y'
+}
+
+// ParseRelativeOffset parses a signed offset (+3d, -1w, +2m, +1y). Unlike
+// ParseNaturalDate it does not resolve to a date; Apply shifts a given one.
+func ParseRelativeOffset(input string) (RelativeOffset, error) {
+	m := relativeOffsetPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(input)))
+	if m == nil {
+		return RelativeOffset{}, fmt.Errorf("invalid offset %q (use +Nd, -Nw, +Nm, or +Ny)", input)
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return RelativeOffset{}, fmt.Errorf("invalid offset %q: %w", input, err)
+	}
+	if m[1] == "-" {
+		n = -n
+	}
+	return RelativeOffset{N: n, Unit: m[3][0]}, nil
+}
+
+// Apply shifts t by the offset. Month and year offsets clamp to the end of
+// a shorter month (Jan 31 +1m is Feb 28) instead of overflowing.
+func (o RelativeOffset) Apply(t time.Time) time.Time {
+	switch o.Unit {
+	case 'd':
+		return t.AddDate(0, 0, o.N)
+	case 'w':
+		return t.AddDate(0, 0, 7*o.N)
+	case 'm', 'y':
+		months := o.N
+		if o.Unit == 'y' {
+			months *= 12
+		}
+		first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+		lastDay := first.AddDate(0, 1, -1).Day()
+		day := t.Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		return time.Date(first.Year(), first.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	}
+	return t
+}
+
+// SnapToWeekday moves t to the closest date on weekday wd in the direction
+// of forward (t itself if it already falls on wd).
+func SnapToWeekday(t time.Time, wd time.Weekday, forward bool) time.Time {
+	if forward {
+		return t.AddDate(0, 0, (int(wd)-int(t.Weekday())+7)%7)
+	}
+	return t.AddDate(0, 0, -((int(t.Weekday()) - int(wd) + 7) % 7))
+}
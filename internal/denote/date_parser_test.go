@@ -0,0 +1,97 @@
+package denote
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRelativeDate(t *testing.T) {
+	tests := []struct {
+		base  string
+		input string
+		want  string
+	}{
+		{"2026-03-10", "+3d", "2026-03-13"},
+		{"2026-03-10", "-1w", "2026-03-03"},
+		// Week offsets roll over month and year ends
+		{"2026-03-28", "+1w", "2026-04-04"},
+		{"2026-12-29", "+1w", "2027-01-05"},
+		{"2026-01-03", "-1w", "2025-12-27"},
+		// Month offsets clamp to shorter months
+		{"2026-01-31", "+1m", "2026-02-28"},
+		{"2026-11-15", "+2m", "2027-01-15"},
+		{"2026-03-31", "-1m", "2026-02-28"},
+		{"2024-02-29", "+1y", "2025-02-28"},
+		// Planning shortcuts anchor on base too
+		{"2026-03-10", "w+2", "2026-03-24"},
+		{"2026-03-10", "q-end", "2026-03-31"},
+	}
+	for _, tt := range tests {
+		base, err := time.Parse("2006-01-02", tt.base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ParseRelativeDate(base, tt.input)
+		if err != nil {
+			t.Errorf("ParseRelativeDate(%s, %q) error = %v", tt.base, tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRelativeDate(%s, %q) = %s, want %s", tt.base, tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := ParseRelativeDate(time.Now(), "+3x"); err == nil {
+		t.Error("ParseRelativeDate(+3x) succeeded, want error")
+	}
+}
+
+func TestRelativeOffsetApply(t *testing.T) {
+	tests := []struct {
+		date   string
+		offset RelativeOffset
+		want   string
+	}{
+		{"2026-01-31", RelativeOffset{1, 'm'}, "2026-02-28"},
+		{"2028-01-31", RelativeOffset{1, 'm'}, "2028-02-29"},
+		{"2026-01-31", RelativeOffset{3, 'm'}, "2026-04-30"},
+		{"2026-05-31", RelativeOffset{-3, 'm'}, "2026-02-28"},
+		{"2028-02-29", RelativeOffset{1, 'y'}, "2029-02-28"},
+		{"2026-01-31", RelativeOffset{2, 'w'}, "2026-02-14"},
+		{"2026-01-31", RelativeOffset{-31, 'd'}, "2025-12-31"},
+	}
+	for _, tt := range tests {
+		date, err := time.Parse("2006-01-02", tt.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tt.offset.Apply(date).Format("2006-01-02"); got != tt.want {
+			t.Errorf("%+d%c applied to %s = %s, want %s", tt.offset.N, tt.offset.Unit, tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestSnapToWeekday(t *testing.T) {
+	// 2026-03-12 is a Thursday
+	thu, err := time.Parse("2006-01-02", "2026-03-12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		wd      time.Weekday
+		forward bool
+		want    string
+	}{
+		{time.Monday, true, "2026-03-16"},
+		{time.Monday, false, "2026-03-09"},
+		{time.Thursday, true, "2026-03-12"},
+		{time.Thursday, false, "2026-03-12"},
+		{time.Friday, false, "2026-03-06"},
+		{time.Wednesday, true, "2026-03-18"},
+	}
+	for _, tt := range tests {
+		if got := SnapToWeekday(thu, tt.wd, tt.forward).Format("2006-01-02"); got != tt.want {
+			t.Errorf("SnapToWeekday(thu, %s, forward=%v) = %s, want %s", tt.wd, tt.forward, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/mph-llm-experiments/acore"
 )
@@ -19,8 +20,47 @@ func storeAndName(path string) (acore.Store, string) {
 var (
 	// Legacy Denote filename pattern for backward compatibility during migration
 	legacyDenotePattern = regexp.MustCompile(`^(\d{8}T\d{6})-{1,2}([^_]+)(?:__(.+))?\.md$`)
+
+	// Log entry line as written by AddLogEntry: [2026-03-05 Thu]: message
+	logEntryPattern = regexp.MustCompile(`^\[(\d{4}-\d{2}-\d{2}) ([A-Za-z]{3})\]: (.*)$`)
 )
 
+// LogEntry is one timestamped line from a task's log.
+type LogEntry struct {
+	Date    string `json:"date"`
+	Weekday string `json:"weekday"`
+	Message string `json:"message"`
+}
+
+// Line returns the entry as it appears in the file body.
+func (e LogEntry) Line() string {
+	return fmt.Sprintf("[%s %s]: %s", e.Date, e.Weekday, e.Message)
+}
+
+// ParseLogEntries extracts the log entries from a task body in file order.
+// Lines that are not log entries are skipped.
+func ParseLogEntries(content string) []LogEntry {
+	entries := []LogEntry{}
+	for _, line := range strings.Split(content, "\n") {
+		m := logEntryPattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		entries = append(entries, LogEntry{Date: m[1], Weekday: m[2], Message: m[3]})
+	}
+	return entries
+}
+
+// LogDates returns the YYYY-MM-DD date of each log entry in a task body,
+// in file order. A body with no log entries yields nil.
+func LogDates(content string) []string {
+	var dates []string
+	for _, e := range ParseLogEntries(content) {
+		dates = append(dates, e.Date)
+	}
+	return dates
+}
+
 // ParseTaskFile reads and parses a task file using acore.
 func ParseTaskFile(path string) (*Task, error) {
 	var task Task
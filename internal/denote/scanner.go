@@ -1,11 +1,16 @@
 package denote
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mph-llm-experiments/acore"
@@ -14,6 +19,109 @@ import (
 // Scanner finds and loads task/project files
 type Scanner struct {
 	BaseDir string
+
+	errors []ParseError
+}
+
+// ParseError records a file the scanner skipped because it failed to parse
+type ParseError struct {
+	Path string
+	Err  error
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("%s: %v", filepath.Base(e.Path), e.Err)
+}
+
+// verboseOut receives per-file scan progress and parse errors when set
+var verboseOut io.Writer
+
+// verboseMu serializes progress lines written by parallel parse workers
+var verboseMu sync.Mutex
+
+// scanWorkers bounds how many files parseEach parses at once
+var scanWorkers = runtime.NumCPU()
+
+// SetVerbose directs scan progress and parse errors to w (nil disables).
+// Without it, files that fail to parse are skipped silently.
+func SetVerbose(w io.Writer) {
+	verboseOut = w
+}
+
+// logScan writes a scan progress line when verbose output is enabled
+func logScan(format string, args ...interface{}) {
+	if verboseOut != nil {
+		verboseMu.Lock()
+		defer verboseMu.Unlock()
+		fmt.Fprintf(verboseOut, format+"\n", args...)
+	}
+}
+
+// parseEach parses every named file of the given type on up to scanWorkers
+// goroutines, reporting progress. Results come back sorted by path whatever
+// order the workers finish in. Files that fail to parse are skipped and
+// recorded on the scanner.
+func parseEach[T any](s *Scanner, dir, fileType string, names []string, parse func(string) (T, error)) []T {
+	logScan("Scanning %d %s file(s) in %s", len(names), fileType, dir)
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(dir, name)
+	}
+	sort.Strings(paths)
+
+	type result struct {
+		v   T
+		err error
+	}
+	results := make([]result, len(paths))
+	var done atomic.Int64
+	forEachParallel(len(paths), scanWorkers, func(i int) {
+		v, err := parse(paths[i])
+		results[i] = result{v, err}
+		logScan("  [%d/%d] %s", done.Add(1), len(paths), filepath.Base(paths[i]))
+	})
+
+	var parsed []T
+	failed := 0
+	for i, r := range results {
+		if r.err != nil {
+			failed++
+			s.errors = append(s.errors, ParseError{Path: paths[i], Err: r.err})
+			logScan("  skipped %s: %v", filepath.Base(paths[i]), r.err)
+			continue
+		}
+		parsed = append(parsed, r.v)
+	}
+	logScan("Scanned %d %s file(s), %d parse error(s)", len(names), fileType, failed)
+	return parsed
+}
+
+// forEachParallel calls fn for every index in [0, n) on at most workers
+// goroutines and waits for all of them.
+func forEachParallel(n, workers int, fn func(i int)) {
+	workers = min(max(workers, 1), n)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := range n {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// ParseErrors returns the files skipped so far by this scanner's Find
+// methods because they failed to parse.
+func (s *Scanner) ParseErrors() []ParseError {
+	return s.errors
 }
 
 // NewScanner creates a new scanner for the given directory
@@ -21,34 +129,23 @@ func NewScanner(dir string) *Scanner {
 	return &Scanner{BaseDir: dir}
 }
 
-// FindAllTaskAndProjectFiles finds all task and project files and returns File views.
+// FindAllTaskAndProjectFiles finds all task and project files and returns File
+// views, tasks then projects, each sorted by path.
 func (s *Scanner) FindAllTaskAndProjectFiles() ([]File, error) {
-	var allFiles []File
-	sc := &acore.Scanner{Store: acore.NewLocalStore(s.BaseDir)}
-
-	// Find task files
-	taskNames, err := sc.FindByType("task")
+	tasks, err := s.FindTasks()
 	if err != nil {
 		return nil, err
 	}
-	for _, name := range taskNames {
-		task, err := ParseTaskFile(filepath.Join(s.BaseDir, name))
-		if err != nil {
-			continue
-		}
-		allFiles = append(allFiles, FileFromTask(task))
-	}
-
-	// Find project files
-	projectNames, err := sc.FindByType("project")
+	projects, err := s.FindProjects()
 	if err != nil {
 		return nil, err
 	}
-	for _, name := range projectNames {
-		project, err := ParseProjectFile(filepath.Join(s.BaseDir, name))
-		if err != nil {
-			continue
-		}
+
+	var allFiles []File
+	for _, task := range tasks {
+		allFiles = append(allFiles, FileFromTask(task))
+	}
+	for _, project := range projects {
 		allFiles = append(allFiles, FileFromProject(project))
 	}
 
@@ -68,16 +165,27 @@ func (s *Scanner) FindTasks() ([]*Task, error) {
 		return nil, err
 	}
 
-	var tasks []*Task
-	for _, name := range names {
-		task, err := ParseTaskFile(filepath.Join(s.BaseDir, name))
-		if err != nil {
-			continue
-		}
-		tasks = append(tasks, task)
+	tasks := parseEach(s, s.BaseDir, "task", names, ParseTaskFile)
+	ResolveBlockers(tasks)
+	return tasks, nil
+}
+
+// FindArchivedTasks finds task files in the archive/ subdirectory, which
+// FindTasks does not look in.
+func (s *Scanner) FindArchivedTasks() ([]*Task, error) {
+	archiveDir := filepath.Join(s.BaseDir, "archive")
+
+	if _, err := os.Stat(archiveDir); os.IsNotExist(err) {
+		return nil, nil
 	}
 
-	return tasks, nil
+	sc := &acore.Scanner{Store: acore.NewLocalStore(archiveDir)}
+	names, err := sc.FindByType("task")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEach(s, archiveDir, "task", names, ParseTaskFile), nil
 }
 
 // FindProjects finds all project files in the directory
@@ -88,16 +196,7 @@ func (s *Scanner) FindProjects() ([]*Project, error) {
 		return nil, err
 	}
 
-	var projects []*Project
-	for _, name := range names {
-		project, err := ParseProjectFile(filepath.Join(s.BaseDir, name))
-		if err != nil {
-			continue
-		}
-		projects = append(projects, project)
-	}
-
-	return projects, nil
+	return parseEach(s, s.BaseDir, "project", names, ParseProjectFile), nil
 }
 
 // FindActions finds all action files in the queue/ subdirectory
@@ -115,16 +214,7 @@ func (s *Scanner) FindActions() ([]*Action, error) {
 		return nil, err
 	}
 
-	var actions []*Action
-	for _, name := range names {
-		action, err := ParseActionFile(filepath.Join(queueDir, name))
-		if err != nil {
-			continue
-		}
-		actions = append(actions, action)
-	}
-
-	return actions, nil
+	return parseEach(s, queueDir, "action", names, ParseActionFile), nil
 }
 
 // FindArchivedActions finds action files in the queue/archive/ subdirectory
@@ -141,16 +231,71 @@ func (s *Scanner) FindArchivedActions() ([]*Action, error) {
 		return nil, err
 	}
 
-	var actions []*Action
-	for _, name := range names {
-		action, err := ParseActionFile(filepath.Join(archiveDir, name))
-		if err != nil {
+	return parseEach(s, archiveDir, "action", names, ParseActionFile), nil
+}
+
+// LegacyFile is a task or project file still in the pre-acore Denote format
+type LegacyFile struct {
+	Path     string `json:"path"`
+	Type     string `json:"type"`
+	DenoteID string `json:"denote_id"`
+	Title    string `json:"title"`
+}
+
+// FindLegacyFiles finds task and project files with a legacy Denote filename
+// and no ULID in their frontmatter, i.e. files `migrate acore` would convert.
+// Legacy files whose frontmatter can't be read are reported in ParseErrors.
+func (s *Scanner) FindLegacyFiles() ([]LegacyFile, error) {
+	entries, err := os.ReadDir(s.BaseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var legacy []LegacyFile
+	for _, entry := range entries {
+		if entry.IsDir() {
 			continue
 		}
-		actions = append(actions, action)
+		m := legacyDenotePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+
+		fileType := ""
+		for _, tag := range strings.Split(m[3], "_") {
+			if tag == TypeTask || tag == TypeProject {
+				fileType = tag
+				break
+			}
+		}
+		if fileType == "" {
+			continue
+		}
+
+		var entity struct {
+			acore.Entity `yaml:",inline"`
+		}
+		if _, err := acore.ReadFile(acore.NewLocalStore(s.BaseDir), entry.Name(), &entity); err != nil {
+			s.errors = append(s.errors, ParseError{Path: filepath.Join(s.BaseDir, entry.Name()), Err: err})
+			continue
+		}
+		if entity.ID != "" {
+			continue
+		}
+
+		title := entity.Title
+		if title == "" {
+			title = strings.ReplaceAll(m[2], "-", " ")
+		}
+		legacy = append(legacy, LegacyFile{
+			Path:     filepath.Join(s.BaseDir, entry.Name()),
+			Type:     fileType,
+			DenoteID: m[1],
+			Title:    title,
+		})
 	}
 
-	return actions, nil
+	return legacy, nil
 }
 
 // SortTasks sorts tasks by various criteria
@@ -197,6 +342,11 @@ func SortTasks(tasks []*Task, sortBy string, reverse bool) {
 			return tasks[i].ID < tasks[j].ID
 		})
 
+	case "age":
+		sort.Slice(tasks, func(i, j int) bool {
+			return olderThan(tasks[i], tasks[j])
+		})
+
 	case "modified":
 		fallthrough
 	default:
@@ -225,8 +375,26 @@ func priorityValue(p string) int {
 	}
 }
 
+// olderThan orders tasks oldest first by creation time, with tasks whose
+// creation time is unknown last
+func olderThan(a, b *Task) bool {
+	ta, tb := a.CreationTime(), b.CreationTime()
+	if ta.Equal(tb) {
+		return a.IndexID < b.IndexID
+	}
+	if ta.IsZero() {
+		return false
+	}
+	if tb.IsZero() {
+		return true
+	}
+	return ta.Before(tb)
+}
+
 func statusValue(s string) int {
 	switch s {
+	case TaskStatusInProgress:
+		return 0
 	case TaskStatusOpen:
 		return 1
 	case TaskStatusPaused:
@@ -447,7 +615,7 @@ func FilterTasks(tasks []*Task, filterType string, filterValue string) []*Task {
 
 	case "open":
 		for _, task := range tasks {
-			if task.Status == TaskStatusOpen {
+			if task.Status == TaskStatusOpen || task.Status == TaskStatusInProgress {
 				filtered = append(filtered, task)
 			}
 		}
@@ -462,6 +630,7 @@ func FilterTasks(tasks []*Task, filterType string, filterValue string) []*Task {
 	case "active":
 		for _, task := range tasks {
 			if task.Status == TaskStatusOpen ||
+				task.Status == TaskStatusInProgress ||
 				task.Status == TaskStatusPaused ||
 				task.Status == TaskStatusDelegated {
 				filtered = append(filtered, task)
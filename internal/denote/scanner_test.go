@@ -0,0 +1,219 @@
+package denote
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/mph-llm-experiments/acore"
+)
+
+func TestFindTasksReportsParseErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	good := "---\nid: 01JGOOD0000000000000000000\ntitle: Good task\nindex_id: 1\ntype: task\nstatus: open\n---\n"
+	bad := "---\ntitle: [unterminated\n  status: : open\n---\n"
+
+	goodName := "01JGOOD0000000000000000000--good-task__task.md"
+	badName := "01JBAD00000000000000000000--bad-task__task.md"
+	if err := os.WriteFile(filepath.Join(dir, goodName), []byte(good), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, badName), []byte(bad), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner := NewScanner(dir)
+	tasks, err := scanner.FindTasks()
+	if err != nil {
+		t.Fatalf("FindTasks error = %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].Title != "Good task" {
+		t.Fatalf("FindTasks returned %d tasks, want only the good one", len(tasks))
+	}
+
+	errs := scanner.ParseErrors()
+	if len(errs) != 1 {
+		t.Fatalf("ParseErrors() returned %d errors, want 1", len(errs))
+	}
+	if filepath.Base(errs[0].Path) != badName {
+		t.Errorf("ParseErrors()[0].Path = %s, want %s", errs[0].Path, badName)
+	}
+}
+
+func TestFindLegacyFilesReportsUnreadableFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"20240101T120000--old-task__task.md":    "---\ntitle: Old task\n---\n",
+		"20240102T120000--broken-task__task.md": "---\ntitle: [unterminated\n  status: : open\n---\n",
+		"20240103T120000--moved-task__task.md":  "---\nid: 01JGOOD0000000000000000000\ntitle: Moved task\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanner := NewScanner(dir)
+	legacy, err := scanner.FindLegacyFiles()
+	if err != nil {
+		t.Fatalf("FindLegacyFiles error = %v", err)
+	}
+	if len(legacy) != 1 || legacy[0].Title != "Old task" {
+		t.Errorf("FindLegacyFiles = %+v, want only the readable unmigrated file", legacy)
+	}
+
+	errs := scanner.ParseErrors()
+	if len(errs) != 1 {
+		t.Fatalf("ParseErrors() returned %d errors, want 1", len(errs))
+	}
+	if got := filepath.Base(errs[0].Path); got != "20240102T120000--broken-task__task.md" {
+		t.Errorf("ParseErrors()[0].Path = %s, want the broken legacy file", got)
+	}
+}
+
+func TestFindTasksResolvesBlockers(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"01JOPEN0000000000000000000--open__task.md":   "---\nid: 01JOPEN0000000000000000000\ntitle: Open blocker\nindex_id: 1\ntype: task\nstatus: open\n---\n",
+		"01JDONE0000000000000000000--done__task.md":   "---\nid: 01JDONE0000000000000000000\ntitle: Done blocker\nindex_id: 2\ntype: task\nstatus: done\n---\n",
+		"01JWAIT0000000000000000000--wait__task.md":   "---\nid: 01JWAIT0000000000000000000\ntitle: Waiting\nindex_id: 3\ntype: task\nstatus: open\nblocked_by:\n  - 01JOPEN0000000000000000000\n  - 01JGONE0000000000000000000\n---\n",
+		"01JFREE0000000000000000000--free__task.md":   "---\nid: 01JFREE0000000000000000000\ntitle: Unblocked\nindex_id: 4\ntype: task\nstatus: open\nblocked_by:\n  - 01JDONE0000000000000000000\n---\n",
+		"01JORPH0000000000000000000--orphan__task.md": "---\nid: 01JORPH0000000000000000000\ntitle: Deleted blocker\nindex_id: 5\ntype: task\nstatus: open\nblocked_by:\n  - 01JGONE0000000000000000000\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tasks, err := NewScanner(dir).FindTasks()
+	if err != nil {
+		t.Fatalf("FindTasks error = %v", err)
+	}
+
+	// Only open blockers that still exist count
+	want := map[string]bool{"Open blocker": false, "Done blocker": false, "Waiting": true, "Unblocked": false, "Deleted blocker": false}
+	for _, task := range tasks {
+		if got := task.IsBlocked(); got != want[task.Title] {
+			t.Errorf("%s IsBlocked() = %v, want %v (open blockers %v)", task.Title, got, want[task.Title], task.OpenBlockers)
+		}
+	}
+}
+
+func TestSortTasksByAgeMixesDenoteAndULIDs(t *testing.T) {
+	tasks := []*Task{
+		{Entity: acore.Entity{ID: "01JWMCKG000000000000000000", Title: "ULID 2025"}},
+		{Entity: acore.Entity{ID: "not-an-id", Title: "Unknown"}},
+		{Entity: acore.Entity{ID: "20240101T090000", Title: "Denote 2024"}},
+		{Entity: acore.Entity{ID: "01H1T423000000000000000000", Title: "ULID 2023"}},
+	}
+
+	SortTasks(tasks, "age", false)
+
+	// Lexical ID order would put both ULIDs before the Denote ID
+	want := []string{"ULID 2023", "Denote 2024", "ULID 2025", "Unknown"}
+	for i, task := range tasks {
+		if task.Title != want[i] {
+			t.Errorf("position %d = %s, want %s", i, task.Title, want[i])
+		}
+	}
+}
+
+// writeGeneratedNotes writes n task files and n/10 project files to dir,
+// with every 25th task unparseable.
+func writeGeneratedNotes(tb testing.TB, dir string, n int) {
+	tb.Helper()
+	for i := 1; i <= n; i++ {
+		id := fmt.Sprintf("01JGEN%020d", i)
+		content := fmt.Sprintf("---\nid: %s\ntitle: Task %d\nindex_id: %d\ntype: task\nstatus: open\n---\nBody %d\n", id, i, i, i)
+		if i%25 == 0 {
+			content = "---\ntitle: [unterminated\n  status: : open\n---\n"
+		}
+		name := fmt.Sprintf("%s--task-%d__task.md", id, i)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	for i := 1; i <= n/10; i++ {
+		id := fmt.Sprintf("01JPRJ%020d", i)
+		content := fmt.Sprintf("---\nid: %s\ntitle: Project %d\nindex_id: %d\ntype: project\nstatus: active\n---\n", id, i, i)
+		name := fmt.Sprintf("%s--project-%d__project.md", id, i)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+}
+
+func TestParallelScanMatchesSequential(t *testing.T) {
+	dir := t.TempDir()
+	writeGeneratedNotes(t, dir, 300)
+
+	type scan struct {
+		tasks    []*Task
+		projects []*Project
+		files    []File
+		errors   []ParseError
+	}
+	scanWith := func(workers int) scan {
+		t.Helper()
+		saved := scanWorkers
+		scanWorkers = workers
+		defer func() { scanWorkers = saved }()
+
+		s := NewScanner(dir)
+		tasks, err := s.FindTasks()
+		if err != nil {
+			t.Fatal(err)
+		}
+		projects, err := s.FindProjects()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files, err := NewScanner(dir).FindAllTaskAndProjectFiles()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return scan{tasks, projects, files, s.ParseErrors()}
+	}
+
+	sequential := scanWith(1)
+	parallel := scanWith(8)
+
+	if len(sequential.tasks) != 288 || len(sequential.projects) != 30 || len(sequential.errors) != 12 {
+		t.Fatalf("sequential scan found %d tasks, %d projects, %d errors; want 288, 30, 12",
+			len(sequential.tasks), len(sequential.projects), len(sequential.errors))
+	}
+	if !reflect.DeepEqual(sequential, parallel) {
+		t.Error("parallel scan results differ from the sequential scan")
+	}
+	if !sort.SliceIsSorted(parallel.tasks, func(i, j int) bool {
+		return parallel.tasks[i].FilePath < parallel.tasks[j].FilePath
+	}) {
+		t.Error("parallel FindTasks results are not sorted by path")
+	}
+}
+
+func BenchmarkFindTasks(b *testing.B) {
+	dir := b.TempDir()
+	writeGeneratedNotes(b, dir, 2000)
+
+	for _, workers := range []int{1, scanWorkers} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			saved := scanWorkers
+			scanWorkers = workers
+			defer func() { scanWorkers = saved }()
+			for b.Loop() {
+				if _, err := NewScanner(dir).FindTasks(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/mph-llm-experiments/acore"
+	"github.com/oklog/ulid/v2"
 )
 
 // File represents a lightweight view of a task/project file for list display.
@@ -38,6 +39,29 @@ func (f *File) HasTag(tag string) bool {
 	return false
 }
 
+// TagMatches reports whether tag matches pattern. A pattern ending in "/*"
+// matches every tag under that namespace (e.g. "client/*" matches
+// "client/acme" but not "client"); any other pattern must match exactly.
+func TagMatches(tag, pattern string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(tag, prefix+"/")
+	}
+	return tag == pattern
+}
+
+// HasTagMatching checks if the task has a user tag matching pattern (see
+// TagMatches), ignoring case. The implicit "task" type tag never matches.
+func (t *Task) HasTagMatching(pattern string) bool {
+	pattern = strings.ToLower(pattern)
+	for _, tag := range t.Tags {
+		tag = strings.ToLower(tag)
+		if tag != TypeTask && TagMatches(tag, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // MatchesSearch checks if the file matches a search query using fuzzy matching
 func (f *File) MatchesSearch(query string) bool {
 	query = strings.ToLower(query)
@@ -90,16 +114,19 @@ func fuzzyMatch(text, pattern string) bool {
 // Common fields (ID, Title, IndexID, Type, Tags, Created, Modified,
 // RelatedPeople, RelatedTasks, RelatedIdeas) come from embedded acore.Entity.
 type TaskMetadata struct {
-	Status    string `yaml:"status,omitempty" json:"status,omitempty"`
-	Priority  string `yaml:"priority,omitempty" json:"priority,omitempty"`
-	DueDate   string `yaml:"due_date,omitempty" json:"due_date,omitempty"`
-	StartDate string `yaml:"start_date,omitempty" json:"start_date,omitempty"`
-	TodayDate string `yaml:"today_date,omitempty" json:"today_date,omitempty"`
-	Estimate  int    `yaml:"estimate,omitempty" json:"estimate,omitempty"`
-	ProjectID string `yaml:"project_id,omitempty" json:"project_id,omitempty"`
-	Area      string `yaml:"area,omitempty" json:"area,omitempty"`
-	Assignee  string `yaml:"assignee,omitempty" json:"assignee,omitempty"`
-	Recur     string `yaml:"recur,omitempty" json:"recur,omitempty"`
+	Status    string   `yaml:"status,omitempty" json:"status,omitempty"`
+	Priority  string   `yaml:"priority,omitempty" json:"priority,omitempty"`
+	DueDate   string   `yaml:"due_date,omitempty" json:"due_date,omitempty"`
+	StartDate string   `yaml:"start_date,omitempty" json:"start_date,omitempty"`
+	TodayDate string   `yaml:"today_date,omitempty" json:"today_date,omitempty"`
+	Estimate  int      `yaml:"estimate,omitempty" json:"estimate,omitempty"`
+	ProjectID string   `yaml:"project_id,omitempty" json:"project_id,omitempty"`
+	Area      string   `yaml:"area,omitempty" json:"area,omitempty"`
+	Assignee  string   `yaml:"assignee,omitempty" json:"assignee,omitempty"`
+	Recur     string   `yaml:"recur,omitempty" json:"recur,omitempty"`
+	BlockedBy []string `yaml:"blocked_by,omitempty" json:"blocked_by,omitempty"` // ULIDs of tasks that must finish first
+
+	CompletedDate string `yaml:"completed_date,omitempty" json:"completed_date,omitempty"`
 }
 
 // ProjectMetadata holds domain-specific project fields.
@@ -118,6 +145,10 @@ type Task struct {
 	TaskMetadata `yaml:",inline"`
 	ModTime      time.Time `yaml:"-" json:"-"`
 	Content      string    `yaml:"-" json:"-"`
+
+	// OpenBlockers lists the BlockedBy ULIDs that name an existing task
+	// that is not yet done or dropped. Set by ResolveBlockers.
+	OpenBlockers []string `yaml:"-" json:"-"`
 }
 
 // Project combines acore.Entity with project-specific metadata.
@@ -152,6 +183,67 @@ func FileFromProject(p *Project) File {
 	}
 }
 
+// CreationTime returns when the task was created: its created timestamp if
+// set, otherwise the time encoded in its ID (a ULID, or a legacy Denote
+// YYYYMMDDTHHMMSS identifier). It is zero when neither can be read.
+func (t *Task) CreationTime() time.Time {
+	if t.Created != "" {
+		if created, err := time.Parse(time.RFC3339, t.Created); err == nil {
+			return created
+		}
+	}
+	if denoteTime, err := time.ParseInLocation("20060102T150405", t.ID, time.Local); err == nil {
+		return denoteTime
+	}
+	return ulidTime(t.ID)
+}
+
+// ulidTime returns the timestamp encoded in a ULID, or zero if id is not one.
+func ulidTime(id string) time.Time {
+	u, err := ulid.ParseStrict(id)
+	if err != nil {
+		return time.Time{}
+	}
+	return u.Timestamp()
+}
+
+// SetStatus changes the task's status and keeps completed_date in step:
+// it is stamped with today's date when the task becomes done and cleared
+// when it moves to any other status.
+func (t *Task) SetStatus(status string) {
+	if status != TaskStatusDone {
+		t.TaskMetadata.CompletedDate = ""
+	} else if t.TaskMetadata.Status != TaskStatusDone || t.TaskMetadata.CompletedDate == "" {
+		t.TaskMetadata.CompletedDate = time.Now().Format("2006-01-02")
+	}
+	t.TaskMetadata.Status = status
+}
+
+// IsBlocked reports whether any of the task's blockers is still incomplete
+func (t *Task) IsBlocked() bool {
+	return len(t.OpenBlockers) > 0
+}
+
+// ResolveBlockers sets OpenBlockers on every task from the statuses of the
+// others. Blockers that no longer exist, or that are done or dropped, don't
+// block.
+func ResolveBlockers(tasks []*Task) {
+	incomplete := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		if t.TaskMetadata.Status != TaskStatusDone && t.TaskMetadata.Status != TaskStatusDropped {
+			incomplete[t.ID] = true
+		}
+	}
+	for _, t := range tasks {
+		t.OpenBlockers = nil
+		for _, id := range t.TaskMetadata.BlockedBy {
+			if incomplete[id] {
+				t.OpenBlockers = append(t.OpenBlockers, id)
+			}
+		}
+	}
+}
+
 // IsTaggedForToday checks if the task is tagged for today
 func (t *Task) IsTaggedForToday() bool {
 	if t.TaskMetadata.TodayDate == "" {
@@ -164,11 +256,12 @@ func (t *Task) IsTaggedForToday() bool {
 // Common status values
 const (
 	// Task statuses
-	TaskStatusOpen      = "open"
-	TaskStatusDone      = "done"
-	TaskStatusPaused    = "paused"
-	TaskStatusDelegated = "delegated"
-	TaskStatusDropped   = "dropped"
+	TaskStatusOpen       = "open"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+	TaskStatusPaused     = "paused"
+	TaskStatusDelegated  = "delegated"
+	TaskStatusDropped    = "dropped"
 
 	// Project statuses
 	ProjectStatusActive    = "active"
@@ -194,23 +287,32 @@ const (
 	ActionRejected = "rejected"
 
 	// Valid action types
-	ActionTypeTaskCreate   = "task_create"
-	ActionTypeTaskUpdate   = "task_update"
-	ActionTypeIdeaCreate   = "idea_create"
-	ActionTypeIdeaUpdate   = "idea_update"
-	ActionTypePeopleUpdate = "people_update"
-	ActionTypePeopleLog    = "people_log"
+	ActionTypeTaskCreate    = "task_create"
+	ActionTypeTaskUpdate    = "task_update"
+	ActionTypeTaskDone      = "task_done"
+	ActionTypeProjectCreate = "project_create"
+	ActionTypeProjectUpdate = "project_update"
+	ActionTypeIdeaCreate    = "idea_create"
+	ActionTypeIdeaUpdate    = "idea_update"
+	ActionTypePeopleUpdate  = "people_update"
+	ActionTypePeopleLog     = "people_log"
 )
 
 // IsValidTaskStatus checks if a status is valid for tasks
 func IsValidTaskStatus(status string) bool {
 	switch status {
-	case TaskStatusOpen, TaskStatusDone, TaskStatusPaused, TaskStatusDelegated, TaskStatusDropped:
+	case TaskStatusOpen, TaskStatusInProgress, TaskStatusDone, TaskStatusPaused, TaskStatusDelegated, TaskStatusDropped:
 		return true
 	}
 	return false
 }
 
+// IsOpenTaskStatus reports whether a task with this status belongs in the
+// default open-only views: open, in progress, or unset (treated as open)
+func IsOpenTaskStatus(status string) bool {
+	return status == TaskStatusOpen || status == TaskStatusInProgress || status == ""
+}
+
 // IsValidProjectStatus checks if a status is valid for projects
 func IsValidProjectStatus(status string) bool {
 	switch status {
@@ -284,6 +386,9 @@ func DaysUntilDue(dueDateStr string) int {
 
 // IsDueThisWeek checks if a task is due within the next 7 days
 func IsDueThisWeek(dueDateStr string) bool {
+	if dueDateStr == "" {
+		return false
+	}
 	days := DaysUntilDue(dueDateStr)
 	return days >= 0 && days <= 7
 }
@@ -382,7 +487,8 @@ type Action struct {
 // IsValidActionType checks if an action type is valid
 func IsValidActionType(actionType string) bool {
 	switch actionType {
-	case ActionTypeTaskCreate, ActionTypeTaskUpdate,
+	case ActionTypeTaskCreate, ActionTypeTaskUpdate, ActionTypeTaskDone,
+		ActionTypeProjectCreate, ActionTypeProjectUpdate,
 		ActionTypeIdeaCreate, ActionTypeIdeaUpdate,
 		ActionTypePeopleUpdate, ActionTypePeopleLog:
 		return true
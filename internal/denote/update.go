@@ -20,7 +20,7 @@ func UpdateTaskStatus(filepath string, newStatus string) error {
 		return fmt.Errorf("failed to parse task: %w", err)
 	}
 
-	task.Status = newStatus
+	task.SetStatus(newStatus)
 	task.Modified = acore.Now()
 
 	s, n := storeAndName(filepath)
@@ -150,8 +150,16 @@ func UpdateProjectFile(path string, project *Project) error {
 	return acore.UpdateFrontmatter(s, n, project)
 }
 
-// AddLogEntry adds a timestamped log entry to a task file.
+// AddLogEntry adds a log entry stamped with today's date to a task file.
 func AddLogEntry(filepath string, message string) error {
+	return AddLogEntryAt(filepath, message, time.Now())
+}
+
+// AddLogEntryAt adds a log entry stamped with the date of at. Entries are
+// kept newest first: an entry for today or later goes at the top of the
+// body, and a backdated one goes above the first existing entry on or
+// before its date, or after the last entry when all are newer.
+func AddLogEntryAt(filepath string, message string, at time.Time) error {
 	content, err := os.ReadFile(filepath)
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
@@ -176,27 +184,54 @@ func AddLogEntry(filepath string, message string) error {
 		return fmt.Errorf("no frontmatter found in file")
 	}
 
-	now := time.Now()
-	timestamp := now.Format("[2006-01-02 Mon]")
+	timestamp := at.Format("[2006-01-02 Mon]")
 	logEntry := fmt.Sprintf("%s: %s", timestamp, message)
 
 	var newLines []string
-	newLines = append(newLines, lines[:frontmatterEnd+1]...)
-
-	insertPos := frontmatterEnd + 1
-	for insertPos < len(lines) && lines[insertPos] == "" {
-		insertPos++
+	if date := at.Format("2006-01-02"); date < time.Now().Format("2006-01-02") {
+		// Backdated: find where the date falls among the existing entries
+		before, lastEntry := -1, -1
+		for i := frontmatterEnd + 1; i < len(lines); i++ {
+			m := logEntryPattern.FindStringSubmatch(strings.TrimRight(lines[i], "\r"))
+			if m == nil {
+				continue
+			}
+			if m[1] <= date {
+				before = i
+				break
+			}
+			lastEntry = i
+		}
+		switch {
+		case before != -1:
+			newLines = append(newLines, lines[:before]...)
+			newLines = append(newLines, logEntry, "")
+			newLines = append(newLines, lines[before:]...)
+		case lastEntry != -1:
+			newLines = append(newLines, lines[:lastEntry+1]...)
+			newLines = append(newLines, "", logEntry)
+			newLines = append(newLines, lines[lastEntry+1:]...)
+		}
 	}
 
-	if insertPos < len(lines) {
-		newLines = append(newLines, "")
-	}
+	if newLines == nil {
+		newLines = append(newLines, lines[:frontmatterEnd+1]...)
+
+		insertPos := frontmatterEnd + 1
+		for insertPos < len(lines) && lines[insertPos] == "" {
+			insertPos++
+		}
+
+		if insertPos < len(lines) {
+			newLines = append(newLines, "")
+		}
 
-	newLines = append(newLines, logEntry)
+		newLines = append(newLines, logEntry)
 
-	if insertPos < len(lines) {
-		newLines = append(newLines, "")
-		newLines = append(newLines, lines[insertPos:]...)
+		if insertPos < len(lines) {
+			newLines = append(newLines, "")
+			newLines = append(newLines, lines[insertPos:]...)
+		}
 	}
 
 	newContent := strings.Join(newLines, "\n")
@@ -246,3 +281,51 @@ func DeleteLogEntry(filepath string, line string) error {
 
 	return nil
 }
+
+// DeleteLogEntryByIndex removes the nth log entry (1-based, in file order,
+// so newest first) from a task or project file, counting entries the same
+// way as ParseLogEntries. Only the body after the frontmatter is searched,
+// and the rest of the body is left as it was apart from a blank line the
+// removal would otherwise double up.
+func DeleteLogEntryByIndex(filepath string, n int) error {
+	content, err := os.ReadFile(filepath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	frontmatterEnd := -1
+	if len(lines) > 0 && lines[0] == "---" {
+		for i := 1; i < len(lines); i++ {
+			if lines[i] == "---" {
+				frontmatterEnd = i
+				break
+			}
+		}
+	}
+	if frontmatterEnd == -1 {
+		return fmt.Errorf("no frontmatter found in file")
+	}
+
+	var entryLines []int
+	for i := frontmatterEnd + 1; i < len(lines); i++ {
+		if logEntryPattern.MatchString(strings.TrimRight(lines[i], "\r")) {
+			entryLines = append(entryLines, i)
+		}
+	}
+	if n < 1 || n > len(entryLines) {
+		return fmt.Errorf("log entry %d not found (%d entries)", n, len(entryLines))
+	}
+	found := entryLines[n-1]
+
+	newLines := append(lines[:found:found], lines[found+1:]...)
+	// Don't leave two blank lines where the entry was
+	if found < len(newLines) && newLines[found] == "" && found > frontmatterEnd+1 && newLines[found-1] == "" {
+		newLines = append(newLines[:found], newLines[found+1:]...)
+	}
+
+	if err := os.WriteFile(filepath, []byte(strings.Join(newLines, "\n")), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package denote
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeleteLogEntryByIndex(t *testing.T) {
+	const original = `---
+title: Fix fence
+type: task
+---
+
+[2026-03-03 Tue]: Dug holes
+
+[2026-03-02 Mon]: Called nieghbour
+
+[2026-03-01 Sun]: Bought posts
+
+Notes about the fence.
+`
+
+	tests := []struct {
+		name    string
+		n       int
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "first",
+			n:    1,
+			want: `---
+title: Fix fence
+type: task
+---
+
+[2026-03-02 Mon]: Called nieghbour
+
+[2026-03-01 Sun]: Bought posts
+
+Notes about the fence.
+`,
+		},
+		{
+			name: "middle",
+			n:    2,
+			want: `---
+title: Fix fence
+type: task
+---
+
+[2026-03-03 Tue]: Dug holes
+
+[2026-03-01 Sun]: Bought posts
+
+Notes about the fence.
+`,
+		},
+		{name: "past the last entry", n: 4, wantErr: true},
+		{name: "zero", n: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "task.md")
+			if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := DeleteLogEntryByIndex(path, tt.n)
+			got, readErr := os.ReadFile(path)
+			if readErr != nil {
+				t.Fatal(readErr)
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("DeleteLogEntryByIndex(%d) succeeded, want error", tt.n)
+				}
+				if string(got) != original {
+					t.Errorf("file changed on error:\n%s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeleteLogEntryByIndex(%d) error = %v", tt.n, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("after DeleteLogEntryByIndex(%d):\n%s\nwant:\n%s", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddLogEntryAtBackdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "task.md")
+	original := "---\ntitle: Fix fence\ntype: task\n---\n\n[2026-03-03 Tue]: Dug holes\n\n[2026-03-01 Sun]: Bought posts\n\nNotes about the fence.\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	add := func(message string, at time.Time) {
+		t.Helper()
+		if err := AddLogEntryAt(path, message, at); err != nil {
+			t.Fatalf("AddLogEntryAt(%q) error = %v", message, err)
+		}
+	}
+	add("Called neighbour", time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local))
+	add("Measured yard", time.Date(2026, 2, 20, 9, 0, 0, 0, time.Local))
+	now := time.Now()
+	add("Painted", now)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range ParseLogEntries(string(content)) {
+		got = append(got, e.Line())
+	}
+	want := []string{
+		now.Format("[2006-01-02 Mon]") + ": Painted",
+		"[2026-03-03 Tue]: Dug holes",
+		"[2026-03-02 Mon]: Called neighbour",
+		"[2026-03-01 Sun]: Bought posts",
+		"[2026-02-20 Fri]: Measured yard",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("entries =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !strings.Contains(string(content), "[2026-03-01 Sun]: Bought posts\n\n[2026-02-20 Fri]: Measured yard\n\nNotes about the fence.") {
+		t.Errorf("body structure not kept:\n%s", content)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mph-llm-experiments/atask/internal/config"
 	"github.com/mph-llm-experiments/atask/internal/denote"
@@ -23,6 +24,9 @@ type ComparisonNode struct {
 }
 
 func (n *ComparisonNode) String() string {
+	if n.Value == "" || strings.ContainsAny(n.Value, " \t()\":<>=!") {
+		return fmt.Sprintf("%s%s%q", n.Field, n.Operator, n.Value)
+	}
 	return fmt.Sprintf("%s%s%s", n.Field, n.Operator, n.Value)
 }
 
@@ -63,29 +67,27 @@ func (n *ComparisonNode) Evaluate(task *denote.Task, cfg *config.Config) bool {
 
 	case "due", "due_date":
 		// Special values
+		due := task.TaskMetadata.DueDate
 		switch value {
 		case "empty":
-			isEmpty := task.TaskMetadata.DueDate == ""
+			isEmpty := due == ""
 			return n.Operator == ":" && isEmpty
 		case "set":
-			isSet := task.TaskMetadata.DueDate != ""
+			isSet := due != ""
 			return n.Operator == ":" && isSet
-		case "overdue":
-			isOverdue := denote.IsOverdue(task.TaskMetadata.DueDate)
-			return n.Operator == ":" && isOverdue
+		case "overdue", "past":
+			return compareTemporal(denote.IsOverdue(due), n.Operator)
 		case "today":
-			daysUntil := denote.DaysUntilDue(task.TaskMetadata.DueDate)
-			isToday := daysUntil == 0
-			return n.Operator == ":" && isToday
+			return compareTemporal(due == time.Now().Format("2006-01-02"), n.Operator)
+		case "tomorrow":
+			return compareTemporal(due == time.Now().AddDate(0, 0, 1).Format("2006-01-02"), n.Operator)
 		case "week":
-			isThisWeek := denote.IsDueThisWeek(task.TaskMetadata.DueDate)
-			return n.Operator == ":" && isThisWeek
+			return compareTemporal(denote.IsDueThisWeek(due), n.Operator)
 		case "soon":
-			isSoon := denote.IsDueSoon(task.TaskMetadata.DueDate, cfg.SoonHorizon)
-			return n.Operator == ":" && isSoon
+			return compareTemporal(denote.IsDueSoon(due, cfg.SoonHorizon), n.Operator)
 		default:
 			// Compare as date string (YYYY-MM-DD)
-			return compareString(task.TaskMetadata.DueDate, n.Operator, value)
+			return compareString(due, n.Operator, value)
 		}
 
 	case "start", "start_date":
@@ -107,17 +109,41 @@ func (n *ComparisonNode) Evaluate(task *denote.Task, cfg *config.Config) bool {
 		// Otherwise compare as date string
 		return compareString(task.TaskMetadata.TodayDate, n.Operator, value)
 
+	case "planned", "planned_for":
+		// Same values as list --planned-for: any, today, or a date
+		switch value {
+		case "any":
+			return compareTemporal(task.PlannedFor != "", n.Operator)
+		case "today":
+			return compareTemporal(task.PlannedFor == time.Now().Format("2006-01-02"), n.Operator)
+		default:
+			return compareString(task.PlannedFor, n.Operator, value)
+		}
+
+	case "logged":
+		// A date or FROM..TO range (either end may be open); matches when
+		// any log entry in the body falls in it
+		from, to, ok := parseDateRange(value)
+		if !ok {
+			return false
+		}
+		matched := false
+		for _, date := range denote.LogDates(task.Content) {
+			if (from == "" || date >= from) && (to == "" || date <= to) {
+				matched = true
+				break
+			}
+		}
+		return compareTemporal(matched, n.Operator)
+
 	case "title":
 		return compareString(strings.ToLower(task.Title), n.Operator, value)
 
 	case "tag", "tags":
-		// Check if any tag matches
-		for _, tag := range task.Tags {
-			if compareString(strings.ToLower(tag), n.Operator, value) {
-				return true
-			}
-		}
-		return false
+		// Matches if any user tag equals the value, or with a namespace
+		// prefix (tag:client/*) falls under it; tag!=foo means no tag is foo.
+		matched := task.HasTagMatching(value)
+		return compareString(strconv.FormatBool(matched), n.Operator, "true")
 
 	case "recur":
 		if value == "empty" {
@@ -130,14 +156,22 @@ func (n *ComparisonNode) Evaluate(task *denote.Task, cfg *config.Config) bool {
 		}
 		return compareString(strings.ToLower(task.TaskMetadata.Recur), n.Operator, value)
 
-	case "content", "body", "text":
+	case "blocked":
+		// blocked:true matches tasks with an incomplete blocker
+		return compareString(strconv.FormatBool(task.IsBlocked()), n.Operator, value)
+
+	case "waiting":
+		// waiting:true matches tasks delegated to someone
+		waiting := task.TaskMetadata.Status == denote.TaskStatusDelegated && task.TaskMetadata.Assignee != ""
+		return compareString(strconv.FormatBool(waiting), n.Operator, value)
+
+	case "content", "body":
 		// Search in file content (case-insensitive substring match)
-		if n.Operator == ":" || n.Operator == "=" {
-			return strings.Contains(strings.ToLower(task.Content), value)
-		} else if n.Operator == "!=" {
-			return !strings.Contains(strings.ToLower(task.Content), value)
-		}
-		return false
+		return compareContains(task.Content, n.Operator, value)
+
+	case "text":
+		// Full-text search: the title or the body, log entries included
+		return compareContains(task.Title+"\n"+task.Content, n.Operator, value)
 
 	default:
 		// Unknown field always returns false
@@ -187,6 +221,54 @@ func compareString(actual, operator, expected string) bool {
 	}
 }
 
+// compareContains applies a case-insensitive substring match; value is
+// already lowercased.
+func compareContains(text, operator, value string) bool {
+	switch operator {
+	case ":", "=":
+		return strings.Contains(strings.ToLower(text), value)
+	case "!=":
+		return !strings.Contains(strings.ToLower(text), value)
+	default:
+		return false
+	}
+}
+
+// parseDateRange splits a YYYY-MM-DD or FROM..TO value into inclusive
+// bounds, "" for an open end. ok is false for anything else.
+func parseDateRange(value string) (from, to string, ok bool) {
+	from, to, isRange := strings.Cut(value, "..")
+	if !isRange {
+		to = from
+	}
+	for _, d := range []string{from, to} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return "", "", false
+		}
+	}
+	if from == "" && to == "" {
+		return "", "", false
+	}
+	return from, to, true
+}
+
+// compareTemporal applies an operator to a temporal predicate (overdue,
+// today, week, soon). An undated task never satisfies the predicate, so
+// it matches the negated forms: NOT due:past and due!=past both include it.
+func compareTemporal(matches bool, operator string) bool {
+	switch operator {
+	case ":", "=":
+		return matches
+	case "!=":
+		return !matches
+	default:
+		return false
+	}
+}
+
 func compareInt(actual int, operator, expectedStr string) bool {
 	expected, err := strconv.Atoi(expectedStr)
 	if err != nil {
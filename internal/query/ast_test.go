@@ -0,0 +1,252 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/config"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+func TestNegatedTemporalPredicates(t *testing.T) {
+	cfg := &config.Config{SoonHorizon: 3}
+	day := func(offset int) string {
+		return time.Now().AddDate(0, 0, offset).Format("2006-01-02")
+	}
+
+	tasks := map[string]string{
+		"undated":  "",
+		"past":     day(-2),
+		"today":    day(0),
+		"in2days":  day(2),
+		"in30days": day(30),
+	}
+
+	// Expected matches for the positive predicate; NOT and != must be the
+	// exact complement, so undated tasks match every negated form.
+	tests := []struct {
+		query string
+		want  map[string]bool
+	}{
+		{"due:past", map[string]bool{"past": true}},
+		{"due:overdue", map[string]bool{"past": true}},
+		{"due:today", map[string]bool{"today": true}},
+		{"due:week", map[string]bool{"today": true, "in2days": true}},
+		{"due:soon", map[string]bool{"today": true, "in2days": true}},
+	}
+
+	for _, tt := range tests {
+		positive, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.query, err)
+		}
+		negated, err := Parse("NOT " + tt.query)
+		if err != nil {
+			t.Fatalf("Parse(NOT %q) error = %v", tt.query, err)
+		}
+		notEqual := &ComparisonNode{
+			Field:    positive.(*ComparisonNode).Field,
+			Operator: "!=",
+			Value:    positive.(*ComparisonNode).Value,
+		}
+
+		for name, due := range tasks {
+			task := &denote.Task{}
+			task.TaskMetadata.DueDate = due
+
+			want := tt.want[name]
+			if got := positive.Evaluate(task, cfg); got != want {
+				t.Errorf("%s on %s task = %v, want %v", tt.query, name, got, want)
+			}
+			if got := negated.Evaluate(task, cfg); got != !want {
+				t.Errorf("NOT %s on %s task = %v, want %v", tt.query, name, got, !want)
+			}
+			if got := notEqual.Evaluate(task, cfg); got != !want {
+				t.Errorf("%s on %s task = %v, want %v", notEqual, name, got, !want)
+			}
+		}
+	}
+}
+
+func TestDueTodayAndTomorrow(t *testing.T) {
+	now := time.Now()
+	tasks := map[string]string{
+		"yesterday": now.AddDate(0, 0, -1).Format("2006-01-02"),
+		"today":     now.Format("2006-01-02"),
+		"tomorrow":  now.AddDate(0, 0, 1).Format("2006-01-02"),
+		"undated":   "",
+		"malformed": "someday",
+	}
+
+	for _, term := range []string{"today", "tomorrow"} {
+		ast, err := Parse("due:" + term)
+		if err != nil {
+			t.Fatalf("Parse(due:%s) error = %v", term, err)
+		}
+		for name, due := range tasks {
+			task := &denote.Task{}
+			task.TaskMetadata.DueDate = due
+			if got, want := ast.Evaluate(task, &config.Config{}), name == term; got != want {
+				t.Errorf("due:%s on %s task = %v, want %v", term, name, got, want)
+			}
+		}
+	}
+}
+
+func TestWaitingMatchesDelegatedWithAssignee(t *testing.T) {
+	tests := []struct {
+		status, assignee string
+		want             bool
+	}{
+		{denote.TaskStatusDelegated, "alice", true},
+		{denote.TaskStatusDelegated, "", false},
+		{denote.TaskStatusOpen, "alice", false},
+	}
+
+	ast, err := Parse("waiting:true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		task := &denote.Task{}
+		task.TaskMetadata.Status = tt.status
+		task.TaskMetadata.Assignee = tt.assignee
+		if got := ast.Evaluate(task, &config.Config{}); got != tt.want {
+			t.Errorf("waiting:true on %s task assigned to %q = %v, want %v", tt.status, tt.assignee, got, tt.want)
+		}
+	}
+}
+
+func TestTextSearchesTitleAndBody(t *testing.T) {
+	invoice := &denote.Task{}
+	invoice.Title = "Send Q3 invoice to Acme"
+	invoice.Content = "Net 30 terms.\n\n## Log\n\n[2026-01-05] Called the finance team\n"
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{`text:invoice`, true},                // title only
+		{`text:"q3 invoice"`, true},           // phrase in title
+		{`text:"finance team"`, true},         // phrase in a log entry
+		{`text:"NET 30"`, true},               // case-insensitive
+		{`text:"invoice net"`, false},         // words split across title and body
+		{`area:work AND text:invoice`, false}, // combined with other terms
+		{`NOT text:"finance team"`, false},
+		{`body:invoice`, false}, // body alone excludes the title
+	}
+
+	for _, tt := range tests {
+		ast, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.query, err)
+		}
+		if got := ast.Evaluate(invoice, &config.Config{}); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestTagMatchesAnyOfSeveralTags(t *testing.T) {
+	tagged := &denote.Task{}
+	tagged.Tags = []string{"task", "urgent", "client/acme", "Sprint-42"}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"tag:urgent", true},
+		{"tag:sprint-42", true},
+		{"tag:client/*", true},
+		{"tag:client", false},
+		{"tag:task", false}, // implicit type tag
+		{"tag:urgent AND tag:client/acme", true},
+		{"tag:urgent AND tag:someday", false},
+		{"tag:someday OR tag:urgent", true},
+		{"tag!=urgent", false},
+		{"tag!=someday", true},
+	}
+
+	for _, tt := range tests {
+		ast, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.query, err)
+		}
+		if got := ast.Evaluate(tagged, &config.Config{}); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestPlannedTerm(t *testing.T) {
+	cfg := &config.Config{}
+	today := time.Now().Format("2006-01-02")
+
+	tasks := map[string]string{
+		"unplanned": "",
+		"today":     today,
+		"later":     "2026-12-24",
+	}
+
+	tests := []struct {
+		query string
+		want  map[string]bool
+	}{
+		{"planned:any", map[string]bool{"today": true, "later": true}},
+		{"planned!=any", map[string]bool{"unplanned": true}},
+		{"planned:today", map[string]bool{"today": true}},
+		{"planned:2026-12-24", map[string]bool{"later": true}},
+		{"planned_for:2026-12-24", map[string]bool{"later": true}},
+		{"NOT planned:2026-12-24", map[string]bool{"unplanned": true, "today": true}},
+	}
+
+	for _, tt := range tests {
+		node, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.query, err)
+		}
+		for name, planned := range tasks {
+			task := &denote.Task{}
+			task.PlannedFor = planned
+			if got := node.Evaluate(task, cfg); got != tt.want[name] {
+				t.Errorf("%s on %s task = %v, want %v", tt.query, name, got, tt.want[name])
+			}
+		}
+	}
+}
+
+func TestLoggedTerm(t *testing.T) {
+	cfg := &config.Config{}
+
+	tasks := map[string]string{
+		"no log":    "Just notes, no entries.",
+		"last week": "[2026-10-08 Thu]: Sent draft\n\nNotes\n",
+		"older":     "[2026-09-01 Tue]: Kicked off\n",
+	}
+
+	tests := []struct {
+		query string
+		want  map[string]bool
+	}{
+		{"logged:2026-10-05..2026-10-11", map[string]bool{"last week": true}},
+		{"logged:2026-10-08", map[string]bool{"last week": true}},
+		{"logged:2026-10-09..2026-10-11", map[string]bool{}},
+		{"logged:..2026-09-30", map[string]bool{"older": true}},
+		{"logged:2026-09-01..", map[string]bool{"last week": true, "older": true}},
+		{"logged!=2026-10-05..2026-10-11", map[string]bool{"no log": true, "older": true}},
+		{"logged:last-week", map[string]bool{}},
+	}
+
+	for _, tt := range tests {
+		node, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.query, err)
+		}
+		for name, content := range tasks {
+			task := &denote.Task{Content: content}
+			if got := node.Evaluate(task, cfg); got != tt.want[name] {
+				t.Errorf("%s on %s task = %v, want %v", tt.query, name, got, tt.want[name])
+			}
+		}
+	}
+}
@@ -50,15 +50,15 @@ func (p *Parser) parseExpression() (Node, error) {
 	return node, nil
 }
 
-// parseTerm handles AND
-// term := factor (AND factor)*
+// parseTerm handles AND, explicit or implied by adjacent factors
+// term := factor ([AND] factor)*
 func (p *Parser) parseTerm() (Node, error) {
 	node, err := p.parseFactor()
 	if err != nil {
 		return nil, err
 	}
 
-	for p.match(TokenAND) {
+	for p.match(TokenAND) || p.startsFactor() {
 		right, err := p.parseFactor()
 		if err != nil {
 			return nil, err
@@ -130,6 +130,12 @@ func (p *Parser) parseComparison() (Node, error) {
 
 // Helper methods
 
+// startsFactor reports whether the current token can begin a factor, so
+// "status:open priority:p1" reads as "status:open AND priority:p1"
+func (p *Parser) startsFactor() bool {
+	return p.check(TokenField) || p.check(TokenNOT) || p.check(TokenLeftParen)
+}
+
 func (p *Parser) current() Token {
 	if p.pos >= len(p.tokens) {
 		return p.tokens[len(p.tokens)-1] // Return EOF
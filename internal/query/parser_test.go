@@ -0,0 +1,45 @@
+package query
+
+import "testing"
+
+func TestParseImplicitAnd(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"status:open priority:p1", "(status:open AND priority:p1)"},
+		{"status:open priority:p1 area:work", "((status:open AND priority:p1) AND area:work)"},
+		{"status:open AND priority:p1", "(status:open AND priority:p1)"},
+		{"status:open priority:p1 AND area:work", "((status:open AND priority:p1) AND area:work)"},
+		{"status:open priority:p1 OR area:work", "((status:open AND priority:p1) OR area:work)"},
+		{"area:work OR status:open priority:p1", "(area:work OR (status:open AND priority:p1))"},
+		{"status:open NOT due:overdue", "(status:open AND NOT due:overdue)"},
+		{"status:open (due:today OR due:overdue)", "(status:open AND (due:today OR due:overdue))"},
+		{"(area:work area:home) OR priority:p1", "((area:work AND area:home) OR priority:p1)"},
+		{`area:work text:"net 30 (late)"`, `(area:work AND text:"net 30 (late)")`},
+	}
+
+	for _, tt := range tests {
+		node, err := Parse(tt.query)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.query, err)
+		}
+		if got := node.String(); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseImplicitAndErrors(t *testing.T) {
+	for _, query := range []string{
+		"status:open priority",
+		"status:open AND",
+		"status:open OR OR priority:p1",
+		"status:open )",
+		`text:"unterminated`,
+	} {
+		if _, err := Parse(query); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", query)
+		}
+	}
+}
@@ -111,6 +111,15 @@ func Tokenize(query string) ([]Token, error) {
 					pos += 2
 					continue
 				}
+			case '"':
+				// Quoted value, which may contain spaces and operators
+				end := strings.IndexByte(query[pos+1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated quote at position %d", pos)
+				}
+				tokens = append(tokens, Token{Type: TokenValue, Value: query[pos+1 : pos+1+end], Pos: pos})
+				pos += end + 2
+				continue
 			}
 		}
 
@@ -25,6 +25,32 @@ var weekdayNames = map[string]time.Weekday{
 	"sat":       time.Saturday,
 }
 
+// Ordinals accepted in "every 2nd tuesday" patterns, mapped to the
+// occurrence within the month; -1 means the last one
+var ordinalNames = map[string]int{
+	"1st": 1, "first": 1,
+	"2nd": 2, "second": 2,
+	"3rd": 3, "third": 3,
+	"4th": 4, "fourth": 4,
+	"5th": 5, "fifth": 5,
+	"last": -1,
+}
+
+// parseOrdinalWeekday parses an "<ordinal> <weekday>" spec such as
+// "2nd tuesday" or "last fri". ok is false if spec is not of that form.
+func parseOrdinalWeekday(spec string) (n int, wd time.Weekday, ok bool) {
+	fields := strings.Fields(spec)
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	n, ok = ordinalNames[fields[0]]
+	if !ok {
+		return 0, 0, false
+	}
+	wd, ok = weekdayNames[fields[1]]
+	return n, wd, ok
+}
+
 // ParsePattern validates and normalizes a recurrence pattern string.
 // Returns the normalized pattern or an error if invalid.
 //
@@ -32,6 +58,9 @@ var weekdayNames = map[string]time.Weekday{
 //   - daily, weekly, monthly, yearly
 //   - every <N>d, every <N>w, every <N>m, every <N>y
 //   - every monday, every mon,wed,fri
+//   - every 2nd tuesday, every last friday (1st-5th or last weekday of the month)
+//   - after:<N>d, after:<N>w, after:<N>m, after:<N>y (relative to completion)
+//   - every +<N>d, every +<N>w, every +<N>m, every +<N>y (same as after:)
 func ParsePattern(pattern string) (string, error) {
 	pattern = strings.TrimSpace(strings.ToLower(pattern))
 	if pattern == "" {
@@ -43,6 +72,14 @@ func ParsePattern(pattern string) (string, error) {
 		return pattern, nil
 	}
 
+	if strings.HasPrefix(pattern, "after:") {
+		n, unit, err := parseInterval(strings.TrimSpace(pattern[6:]))
+		if err != nil {
+			return "", fmt.Errorf("invalid recurrence pattern: %q (%v)", pattern, err)
+		}
+		return fmt.Sprintf("after:%d%c", n, unit), nil
+	}
+
 	if !strings.HasPrefix(pattern, "every ") {
 		return "", fmt.Errorf("invalid recurrence pattern: %q (expected daily, weekly, monthly, yearly, or every ...)", pattern)
 	}
@@ -52,6 +89,15 @@ func ParsePattern(pattern string) (string, error) {
 		return "", fmt.Errorf("invalid recurrence pattern: %q (missing interval after 'every')", pattern)
 	}
 
+	// Try after-completion interval: every +<N>d/w/m/y
+	if strings.HasPrefix(spec, "+") {
+		n, unit, err := parseInterval(strings.TrimSpace(spec[1:]))
+		if err != nil {
+			return "", fmt.Errorf("invalid recurrence pattern: %q (%v)", pattern, err)
+		}
+		return fmt.Sprintf("every +%d%c", n, unit), nil
+	}
+
 	// Try interval+unit pattern: every <N>d/w/m/y
 	if len(spec) >= 2 {
 		unit := spec[len(spec)-1]
@@ -67,6 +113,11 @@ func ParsePattern(pattern string) (string, error) {
 		}
 	}
 
+	// Try ordinal weekday pattern: every 2nd tuesday
+	if _, _, ok := parseOrdinalWeekday(spec); ok {
+		return "every " + strings.Join(strings.Fields(spec), " "), nil
+	}
+
 	// Try day-of-week pattern: every mon,wed,fri
 	parts := strings.Split(spec, ",")
 	var days []string
@@ -81,8 +132,48 @@ func ParsePattern(pattern string) (string, error) {
 	return "every " + strings.Join(days, ","), nil
 }
 
+// IsAfterCompletion reports whether a pattern schedules the next occurrence
+// relative to when the task is completed rather than to its due date.
+// Such patterns don't need a due date until the first completion.
+func IsAfterCompletion(pattern string) bool {
+	_, ok := afterCompletionInterval(strings.TrimSpace(strings.ToLower(pattern)))
+	return ok
+}
+
+// afterCompletionInterval returns the <N><unit> interval of a normalized
+// after-completion pattern, written either as after:3d or every +3d.
+func afterCompletionInterval(pattern string) (string, bool) {
+	if strings.HasPrefix(pattern, "after:") {
+		return pattern[len("after:"):], true
+	}
+	if strings.HasPrefix(pattern, "every +") {
+		return pattern[len("every +"):], true
+	}
+	return "", false
+}
+
+// parseInterval parses an <N><unit> interval such as "3d" or "2w".
+func parseInterval(spec string) (int, byte, error) {
+	if len(spec) < 2 {
+		return 0, 0, fmt.Errorf("expected <N>d, <N>w, <N>m, or <N>y")
+	}
+	unit := spec[len(spec)-1]
+	if unit != 'd' && unit != 'w' && unit != 'm' && unit != 'y' {
+		return 0, 0, fmt.Errorf("unknown interval unit %q", string(unit))
+	}
+	n, err := strconv.Atoi(spec[:len(spec)-1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("expected <N>d, <N>w, <N>m, or <N>y")
+	}
+	if n <= 0 {
+		return 0, 0, fmt.Errorf("interval must be positive")
+	}
+	return n, unit, nil
+}
+
 // NextDueDate computes the next due date based on a recurrence pattern and the current due date.
 // It always advances past today so late completions still get a future date.
+// After-completion patterns ignore currentDue and count from today.
 func NextDueDate(pattern string, currentDue time.Time) (time.Time, error) {
 	pattern = strings.TrimSpace(strings.ToLower(pattern))
 	today := time.Now()
@@ -101,7 +192,13 @@ func NextDueDate(pattern string, currentDue time.Time) (time.Time, error) {
 	case "yearly":
 		next = advanceByInterval(currentDue, 1, 'y', today)
 	default:
-		if strings.HasPrefix(pattern, "every ") {
+		if interval, ok := afterCompletionInterval(pattern); ok {
+			n, unit, err := parseInterval(strings.TrimSpace(interval))
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid recurrence pattern: %q (%v)", pattern, err)
+			}
+			next = advanceByInterval(today, n, unit, today)
+		} else if strings.HasPrefix(pattern, "every ") {
 			spec := strings.TrimSpace(pattern[6:])
 			next, err = parseEverySpec(spec, currentDue, today)
 			if err != nil {
@@ -128,6 +225,10 @@ func parseEverySpec(spec string, currentDue, today time.Time) (time.Time, error)
 		}
 	}
 
+	if n, wd, ok := parseOrdinalWeekday(spec); ok {
+		return nextOrdinalWeekday(currentDue, n, wd, today), nil
+	}
+
 	// Day-of-week pattern
 	parts := strings.Split(spec, ",")
 	var weekdays []time.Weekday
@@ -149,24 +250,46 @@ func parseEverySpec(spec string, currentDue, today time.Time) (time.Time, error)
 
 // advanceByInterval advances from currentDue by the given interval,
 // repeating until the result is strictly after today.
+//
+// Dates are treated as calendar days: each candidate is rebuilt at midnight
+// from year/month/day, so DST transitions can't shift it to a neighbouring
+// day. Month and year steps are counted from the original day of month and
+// clamped to the end of shorter months, so Jan 31 monthly gives Feb 28 (or
+// 29), then Mar 31, rather than overflowing into March or drifting to the 28th.
 func advanceByInterval(currentDue time.Time, n int, unit byte, today time.Time) time.Time {
-	next := currentDue
-	for {
+	y, m, d := currentDue.Date()
+	loc := currentDue.Location()
+
+	for k := 1; ; k++ {
+		var next time.Time
 		switch unit {
 		case 'd':
-			next = next.AddDate(0, 0, n)
+			next = time.Date(y, m, d+k*n, 0, 0, 0, 0, loc)
 		case 'w':
-			next = next.AddDate(0, 0, n*7)
+			next = time.Date(y, m, d+k*n*7, 0, 0, 0, 0, loc)
 		case 'm':
-			next = next.AddDate(0, n, 0)
+			next = addMonthsClamped(y, m, d, k*n, loc)
 		case 'y':
-			next = next.AddDate(n, 0, 0)
+			next = addMonthsClamped(y, m, d, k*n*12, loc)
+		default:
+			return currentDue
 		}
 		if !next.Before(today) {
-			break
+			return next
 		}
 	}
-	return next
+}
+
+// addMonthsClamped returns the date months after y-m-d, using the last day
+// of the target month when day d doesn't exist in it.
+func addMonthsClamped(y int, m time.Month, d, months int, loc *time.Location) time.Time {
+	first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, loc)
+	// Day 0 of the following month is the last day of this one
+	lastDay := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, loc).Day()
+	if d > lastDay {
+		d = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, loc)
 }
 
 // nextMatchingWeekday finds the next date after currentDue that falls on one of the given weekdays,
@@ -196,3 +319,46 @@ func nextMatchingWeekday(currentDue time.Time, weekdays []time.Weekday, today ti
 	// Should never reach here if weekdays is non-empty
 	return candidate
 }
+
+// nextOrdinalWeekday finds the first nth weekday of a month (n of -1 is the
+// last) that falls after currentDue and not before today. Months without
+// that occurrence, such as a month with only four Fridays for "5th friday",
+// are skipped.
+func nextOrdinalWeekday(currentDue time.Time, n int, wd time.Weekday, today time.Time) time.Time {
+	// Search from whichever is later, so a due date years in the past does
+	// not use up the bound before reaching today
+	start := currentDue
+	if today.After(start) {
+		start = today
+	}
+	y, m, _ := start.Date()
+	loc := currentDue.Location()
+
+	// A fifth weekday occurs at least every few months, so this always
+	// finds a match well within the bound
+	for k := 0; k < 24; k++ {
+		candidate, ok := nthWeekdayOfMonth(y, m+time.Month(k), n, wd, loc)
+		if ok && candidate.After(currentDue) && !candidate.Before(today) {
+			return candidate
+		}
+	}
+	return currentDue
+}
+
+// nthWeekdayOfMonth returns the nth wd in the given month (n of -1 for the
+// last one), or false if the month has fewer than n of them.
+func nthWeekdayOfMonth(y int, m time.Month, n int, wd time.Weekday, loc *time.Location) (time.Time, bool) {
+	first := time.Date(y, m, 1, 0, 0, 0, 0, loc)
+	if n < 0 {
+		// Day 0 of the following month is the last day of this one
+		last := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, loc)
+		offset := (int(last.Weekday()) - int(wd) + 7) % 7
+		return time.Date(last.Year(), last.Month(), last.Day()-offset, 0, 0, 0, 0, loc), true
+	}
+	offset := (int(wd) - int(first.Weekday()) + 7) % 7
+	candidate := time.Date(first.Year(), first.Month(), 1+offset+(n-1)*7, 0, 0, 0, 0, loc)
+	if candidate.Month() != first.Month() {
+		return time.Time{}, false
+	}
+	return candidate, true
+}
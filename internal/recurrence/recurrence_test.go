@@ -32,6 +32,18 @@ func TestParsePattern(t *testing.T) {
 		{"every mon,wed,fri", "every mon,wed,fri", false},
 		{"every tuesday", "every tuesday", false},
 
+		// Ordinal weekday patterns
+		{"every 2nd tuesday", "every 2nd tuesday", false},
+		{"Every Last  Friday", "every last friday", false},
+		{"every first mon", "every first mon", false},
+
+		// After-completion patterns
+		{"after:3d", "after:3d", false},
+		{"After:2W", "after:2w", false},
+		{"after: 1m", "after:1m", false},
+		{"every +3d", "every +3d", false},
+		{"Every +2W", "every +2w", false},
+
 		// Invalid patterns
 		{"", "", true},
 		{"biweekly", "", true},
@@ -40,6 +52,15 @@ func TestParsePattern(t *testing.T) {
 		{"every -1w", "", true},
 		{"every funday", "", true},
 		{"every 2x", "", true},
+		{"every 6th friday", "", true},
+		{"every last funday", "", true},
+		{"after:", "", true},
+		{"after:0d", "", true},
+		{"after:3x", "", true},
+		{"after:mon", "", true},
+		{"every +", "", true},
+		{"every +0d", "", true},
+		{"every +mon", "", true},
 	}
 
 	for _, tt := range tests {
@@ -157,3 +178,195 @@ func TestNextDueDateInvalidPattern(t *testing.T) {
 		t.Error("expected error for invalid pattern")
 	}
 }
+
+func TestNextDueDateAfterCompletion(t *testing.T) {
+	today := time.Now()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+
+	// The old due date is irrelevant for after-completion patterns
+	for _, due := range []time.Time{{}, time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local), time.Date(2099, 1, 1, 0, 0, 0, 0, time.Local)} {
+		got, err := NextDueDate("after:3d", due)
+		if err != nil {
+			t.Fatalf("NextDueDate error = %v", err)
+		}
+		want := today.AddDate(0, 0, 3)
+		if !got.Equal(want) {
+			t.Errorf("NextDueDate(after:3d, %v) = %v, want %v", due, got, want)
+		}
+	}
+}
+
+func TestIsAfterCompletion(t *testing.T) {
+	for _, p := range []string{"after:3d", "every +3d"} {
+		if !IsAfterCompletion(p) {
+			t.Errorf("expected %q to be after-completion", p)
+		}
+	}
+	for _, p := range []string{"daily", "every 3d", "every mon"} {
+		if IsAfterCompletion(p) {
+			t.Errorf("expected %q not to be after-completion", p)
+		}
+	}
+}
+
+func TestNextDueDateMonthEnd(t *testing.T) {
+	date := func(y, m, d int) time.Time {
+		return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name       string
+		pattern    string
+		currentDue time.Time
+		want       time.Time
+	}{
+		{"jan 31 monthly", "monthly", date(2099, 1, 31), date(2099, 2, 28)},
+		{"jan 31 monthly in leap year", "monthly", date(2096, 1, 31), date(2096, 2, 29)},
+		{"mar 31 monthly", "monthly", date(2099, 3, 31), date(2099, 4, 30)},
+		{"aug 31 every 6m", "every 6m", date(2099, 8, 31), date(2100, 2, 28)},
+		{"feb 29 yearly", "yearly", date(2096, 2, 29), date(2097, 2, 28)},
+		{"feb 29 every 4y", "every 4y", date(2096, 2, 29), date(2100, 2, 28)},
+		{"feb 29 every 4y to leap year", "every 4y", date(2092, 2, 29), date(2096, 2, 29)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextDueDate(tt.pattern, tt.currentDue)
+			if err != nil {
+				t.Fatalf("NextDueDate error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextDueDate(%q, %s) = %s, want %s", tt.pattern, tt.currentDue.Format("2006-01-02"),
+					got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestAdvanceByIntervalKeepsDayOfMonth(t *testing.T) {
+	// Jan 31 monthly, advanced past a late "today": the clamp to Feb 28
+	// must not carry over, so March lands on the 31st again
+	jan31 := time.Date(2099, 1, 31, 0, 0, 0, 0, time.Local)
+	today := time.Date(2099, 3, 1, 0, 0, 0, 0, time.Local)
+
+	got := advanceByInterval(jan31, 1, 'm', today)
+	want := time.Date(2099, 3, 31, 0, 0, 0, 0, time.Local)
+	if !got.Equal(want) {
+		t.Errorf("advanceByInterval = %s, want %s", got.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+}
+
+func TestAdvanceByIntervalAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// US DST starts 2099-03-08 and ends 2099-11-01
+	for _, start := range []time.Time{
+		time.Date(2099, 3, 7, 0, 0, 0, 0, loc),
+		time.Date(2099, 10, 31, 0, 0, 0, 0, loc),
+	} {
+		got := advanceByInterval(start, 1, 'd', start)
+		want := start.AddDate(0, 0, 1)
+		if got.Hour() != 0 || got.Day() != want.Day() {
+			t.Errorf("daily from %s = %s, want midnight on %s", start.Format("2006-01-02"), got, want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestNextDueDateOrdinalWeekday(t *testing.T) {
+	date := func(y, m, d int) time.Time {
+		return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name       string
+		pattern    string
+		currentDue time.Time
+		want       time.Time
+	}{
+		{"2nd tuesday across year end", "every 2nd tuesday", date(2099, 12, 8), date(2100, 1, 12)},
+		{"last friday across year end", "every last friday", date(2099, 12, 25), date(2100, 1, 29)},
+		{"1st monday from mid-month", "every 1st mon", date(2099, 1, 15), date(2099, 2, 2)},
+		{"5th friday skips months with four", "every 5th friday", date(2099, 1, 30), date(2099, 5, 29)},
+		{"5th tuesday across year end", "every 5th tuesday", date(2099, 12, 29), date(2100, 3, 30)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextDueDate(tt.pattern, tt.currentDue)
+			if err != nil {
+				t.Fatalf("NextDueDate error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextDueDate(%q, %s) = %s, want %s", tt.pattern, tt.currentDue.Format("2006-01-02"),
+					got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestNextOrdinalWeekdayFromStaleDueDate(t *testing.T) {
+	date := func(y, m, d int) time.Time {
+		return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.Local)
+	}
+	today := date(2026, 10, 17)
+
+	tests := []struct {
+		name       string
+		n          int
+		wd         time.Weekday
+		currentDue time.Time
+		want       time.Time
+	}{
+		{"2nd tuesday already past this month", 2, time.Tuesday, date(2023, 3, 14), date(2026, 11, 10)},
+		{"5th friday later this month", 5, time.Friday, date(2023, 6, 30), date(2026, 10, 30)},
+		{"last monday", -1, time.Monday, date(2023, 7, 31), date(2026, 10, 26)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextOrdinalWeekday(tt.currentDue, tt.n, tt.wd, today)
+			if !got.Equal(tt.want) {
+				t.Errorf("nextOrdinalWeekday(%s) = %s, want %s", tt.currentDue.Format("2006-01-02"),
+					got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+
+	// Through NextDueDate, a due date three years old still lands on or after today
+	now := time.Now()
+	now = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	got, err := NextDueDate("every 2nd tuesday", now.AddDate(-3, 0, 0))
+	if err != nil {
+		t.Fatalf("NextDueDate error = %v", err)
+	}
+	if got.Before(now) || got.Weekday() != time.Tuesday || (got.Day()-1)/7 != 1 {
+		t.Errorf("NextDueDate(every 2nd tuesday, three years ago) = %s, want a 2nd Tuesday on or after %s",
+			got.Format("2006-01-02"), now.Format("2006-01-02"))
+	}
+}
+
+func TestNextDueDateAnchoring(t *testing.T) {
+	today := time.Now()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	due := time.Date(2099, 1, 1, 0, 0, 0, 0, time.Local)
+
+	// Fixed schedule counts from the old due date
+	got, err := NextDueDate("every 3d", due)
+	if err != nil {
+		t.Fatalf("NextDueDate error = %v", err)
+	}
+	if want := due.AddDate(0, 0, 3); !got.Equal(want) {
+		t.Errorf("NextDueDate(every 3d) = %s, want %s", got.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+
+	// After-completion counts from today, whatever the due date was
+	got, err = NextDueDate("every +3d", due)
+	if err != nil {
+		t.Fatalf("NextDueDate error = %v", err)
+	}
+	if want := today.AddDate(0, 0, 3); !got.Equal(want) {
+		t.Errorf("NextDueDate(every +3d) = %s, want %s", got.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,108 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+// MergeTask folds source into target: the source body is appended to the
+// target body, tags and relations are unioned, and the target's metadata is
+// otherwise kept. The target file is rewritten; the source file is left
+// untouched so the caller can delete or drop it.
+func MergeTask(source, target *denote.Task) error {
+	MergeMetadata(source, target)
+
+	body := strings.TrimRight(extractBody(target.Content), "\n")
+	if sourceBody := strings.TrimSpace(extractBody(source.Content)); sourceBody != "" {
+		if strings.TrimSpace(body) != "" {
+			body += "\n\n"
+		}
+		body += fmt.Sprintf("Merged from task #%d (%s):\n\n%s", source.IndexID, source.Title, sourceBody)
+	}
+	body = strings.TrimLeft(body, "\n")
+	if body != "" {
+		body += "\n"
+	}
+
+	if err := SafeWriteTaskFile(target.FilePath, target, body); err != nil {
+		return fmt.Errorf("failed to write merged task: %w", err)
+	}
+	target.Content = body
+	return nil
+}
+
+// MergeMetadata unions source's tags and relations into target without
+// writing anything, so a dry run can show the merged result.
+func MergeMetadata(source, target *denote.Task) {
+	target.Tags = unionStrings(target.Tags, source.Tags)
+	target.RelatedPeople = unionStrings(target.RelatedPeople, source.RelatedPeople)
+	target.RelatedIdeas = unionStrings(target.RelatedIdeas, source.RelatedIdeas)
+
+	// Don't let the merged task relate to itself or to the task being removed
+	target.RelatedTasks = withoutIDs(unionStrings(target.RelatedTasks, source.RelatedTasks), target.ID, source.ID)
+	target.BlockedBy = withoutIDs(unionStrings(target.BlockedBy, source.BlockedBy), target.ID, source.ID)
+	target.EnsureSlices()
+}
+
+// RedirectReferences points the related_tasks and blocked_by entries that
+// name source at target instead, and returns the tasks it changed. Nothing
+// is written; the caller saves the returned tasks.
+func RedirectReferences(tasks []*denote.Task, source, target *denote.Task) []*denote.Task {
+	var changed []*denote.Task
+	for _, t := range tasks {
+		if t.ID == source.ID || t.ID == target.ID {
+			continue
+		}
+		related, relChanged := redirectID(t.RelatedTasks, source.ID, target.ID)
+		blockedBy, blockChanged := redirectID(t.BlockedBy, source.ID, target.ID)
+		if !relChanged && !blockChanged {
+			continue
+		}
+		t.RelatedTasks = related
+		t.BlockedBy = blockedBy
+		changed = append(changed, t)
+	}
+	return changed
+}
+
+// redirectID replaces from with to in ids, dropping the duplicate if ids
+// already held to.
+func redirectID(ids []string, from, to string) ([]string, bool) {
+	if !contains(ids, from) {
+		return ids, false
+	}
+	var result []string
+	for _, id := range ids {
+		if id == from {
+			id = to
+		}
+		if !contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result, true
+}
+
+// withoutIDs returns ids minus the given ones.
+func withoutIDs(ids []string, drop ...string) []string {
+	var result []string
+	for _, id := range ids {
+		if !contains(drop, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// unionStrings returns a followed by the items of b not already in a.
+func unionStrings(a, b []string) []string {
+	result := append([]string{}, a...)
+	for _, item := range b {
+		if !contains(result, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+// NextIndexID reserves the next index_id from the "atask" counter in dir,
+// which tasks and projects share.
+func NextIndexID(dir string) (int, error) {
+	return nextFromCounter(dir, "atask")
+}
+
+// NextActionIndexID reserves the next index_id from the action queue counter.
+func NextActionIndexID(dir string) (int, error) {
+	queueDir := filepath.Join(dir, "queue")
+	if err := os.MkdirAll(queueDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create queue directory: %w", err)
+	}
+	return nextFromCounter(queueDir, "atask-action")
+}
+
+func nextFromCounter(dir, app string) (int, error) {
+	counter, err := acore.NewIndexCounter(acore.NewLocalStore(dir), app)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ID counter: %w", err)
+	}
+	indexID, err := counter.Next()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next index ID: %w", err)
+	}
+	return indexID, nil
+}
+
+// ReseedCounters advances the task/project and action counters past the
+// highest index_id on disk, archived tasks included, so files written with
+// preserved IDs are never handed out again.
+func ReseedCounters(dir string) error {
+	archived, err := denote.NewScanner(dir).FindArchivedTasks()
+	if err != nil {
+		return fmt.Errorf("failed to scan archived tasks: %w", err)
+	}
+	floor := 0
+	for _, t := range archived {
+		floor = max(floor, t.IndexID)
+	}
+	if err := reseedCounter(dir, "atask", floor, "task", "project"); err != nil {
+		return err
+	}
+	queueDir := filepath.Join(dir, "queue")
+	if _, err := os.Stat(queueDir); err != nil {
+		return nil
+	}
+	return reseedCounter(queueDir, "atask-action", 0, "action")
+}
+
+// reseedCounter initializes app's counter from the files of types in dir,
+// then, since the counter only sees files in its own store, draws numbers
+// until it has reached floor.
+func reseedCounter(dir, app string, floor int, types ...string) error {
+	store := acore.NewLocalStore(dir)
+	counter, err := acore.NewIndexCounter(store, app)
+	if err != nil {
+		return fmt.Errorf("failed to create counter: %w", err)
+	}
+	readIndexID := func(name string) (int, error) {
+		var entity struct {
+			acore.Entity `yaml:",inline"`
+		}
+		if _, err := acore.ReadFile(store, name, &entity); err != nil {
+			return 0, err
+		}
+		return entity.IndexID, nil
+	}
+	for _, t := range types {
+		if err := counter.InitFromFiles(t, readIndexID); err != nil {
+			return fmt.Errorf("counter init from %ss: %w", t, err)
+		}
+	}
+	if floor == 0 {
+		return nil
+	}
+	for {
+		n, err := counter.Next()
+		if err != nil {
+			return fmt.Errorf("failed to advance counter: %w", err)
+		}
+		if n >= floor {
+			return nil
+		}
+	}
+}
+
+// RestoreTask writes a task read from a backup to a new file in dir, keeping
+// its ID, index_id, and timestamps. content may include frontmatter, which
+// is replaced by the task's own metadata.
+func RestoreTask(dir string, t *denote.Task, content string) error {
+	filename := acore.BuildFilename(t.ID, slugTitle(t.Title), "task")
+	if err := acore.WriteFile(acore.NewLocalStore(dir), filename, t, extractBody(content)); err != nil {
+		return fmt.Errorf("failed to write task file: %w", err)
+	}
+	return nil
+}
+
+// RestoreProject writes a project read from a backup to a new file in dir.
+func RestoreProject(dir string, p *denote.Project, content string) error {
+	filename := acore.BuildFilename(p.ID, slugTitle(p.Title), "project")
+	if err := acore.WriteFile(acore.NewLocalStore(dir), filename, p, extractBody(content)); err != nil {
+		return fmt.Errorf("failed to write project file: %w", err)
+	}
+	return nil
+}
+
+// RestoreAction writes an action read from a backup into the queue/
+// subdirectory of dir.
+func RestoreAction(dir string, a *denote.Action, content string) error {
+	queueDir := filepath.Join(dir, "queue")
+	if err := os.MkdirAll(queueDir, 0755); err != nil {
+		return fmt.Errorf("failed to create queue directory: %w", err)
+	}
+	filename := acore.BuildFilename(a.ID, slugTitle(a.Title), "action")
+	if err := acore.WriteFile(acore.NewLocalStore(queueDir), filename, a, extractBody(content)); err != nil {
+		return fmt.Errorf("failed to write action file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+func TestRestoreTaskKeepsIDsAndBody(t *testing.T) {
+	dir := t.TempDir()
+	orig := &denote.Task{}
+	orig.ID, orig.IndexID, orig.Title, orig.Type = "01JKR7S0000000000000000001", 42, "Renew passport", denote.TypeTask
+	orig.TaskMetadata.Status = denote.TaskStatusOpen
+
+	if err := RestoreTask(dir, orig, "---\nindex_id: 1\n---\n\nBring photos\n"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FindTaskByID(dir, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != orig.ID || got.Title != orig.Title {
+		t.Errorf("restored task = (%s, %q), want (%s, %q)", got.ID, got.Title, orig.ID, orig.Title)
+	}
+	if !strings.Contains(got.Content, "Bring photos") || strings.Contains(got.Content, "index_id: 1\n") {
+		t.Errorf("restored content = %q, want the body under the task's own frontmatter", got.Content)
+	}
+}
+
+func TestReseedCountersSkipsArchivedIndexIDs(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := CreateTask(dir, "Water plants", "", nil, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// An archived task restored with a high index_id the counter never issued
+	old := &denote.Task{}
+	old.ID, old.IndexID, old.Title, old.Type = "01JKR7S0000000000000000002", 30, "Old chore", denote.TypeTask
+	archiveDir := filepath.Join(dir, "archive")
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := RestoreTask(archiveDir, old, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReseedCounters(dir); err != nil {
+		t.Fatal(err)
+	}
+	next, err := NextIndexID(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next <= old.IndexID {
+		t.Errorf("next index_id = %d, want past the archived %d", next, old.IndexID)
+	}
+}
@@ -0,0 +1,86 @@
+package task
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SlugOptions controls how titles are turned into filename slugs.
+// The zero value leaves titles untouched.
+type SlugOptions struct {
+	MaxLength     int  // Maximum slug length in characters; 0 means unlimited
+	Transliterate bool // Replace accented Latin letters with ASCII equivalents
+}
+
+var slugOptions SlugOptions
+
+// SetSlugOptions sets the options used when building filenames for new
+// tasks, projects, and actions.
+func SetSlugOptions(opts SlugOptions) {
+	slugOptions = opts
+}
+
+// transliterations maps common accented Latin letters to ASCII.
+var transliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a",
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Ā': "A",
+	'æ': "ae", 'Æ': "AE", 'ç': "c", 'Ç': "C", 'č': "c", 'Č': "C",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ę': "e", 'ě': "e",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ē': "E", 'Ę': "E", 'Ě': "E",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I", 'Ī': "I",
+	'ł': "l", 'Ł': "L", 'ñ': "n", 'Ñ': "N", 'ń': "n", 'Ń': "N",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O", 'Ō': "O",
+	'œ': "oe", 'Œ': "OE", 'ř': "r", 'Ř': "R", 'š': "s", 'Š': "S", 'ś': "s", 'Ś': "S", 'ß': "ss",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ū': "U", 'Ů': "U",
+	'ý': "y", 'ÿ': "y", 'Ý': "Y", 'ž': "z", 'Ž': "Z", 'ź': "z", 'ż': "z", 'Ź': "Z", 'Ż': "Z",
+}
+
+// slugTitle returns the slug to hand to acore.BuildFilename for title,
+// using the options set with SetSlugOptions. The task's stored title is not
+// affected.
+func slugTitle(title string) string {
+	return makeSlug(title, slugOptions)
+}
+
+// makeSlug lowercases title and joins its runs of letters and digits with
+// hyphens, after transliterating if asked. MaxLength caps the finished
+// slug, so the punctuation and spaces the slug drops don't count against it.
+// With no options the title is returned as is for acore to slug.
+func makeSlug(title string, opts SlugOptions) string {
+	if opts == (SlugOptions{}) {
+		return title
+	}
+
+	var b strings.Builder
+	gap := false
+	for _, r := range title {
+		word := string(unicode.ToLower(r))
+		if repl, ok := transliterations[r]; ok && opts.Transliterate {
+			word = strings.ToLower(repl)
+		} else if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			gap = true
+			continue
+		}
+		if gap && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(word)
+		gap = false
+	}
+	slug := b.String()
+
+	if max := opts.MaxLength; max > 0 && utf8.RuneCountInString(slug) > max {
+		cut := string([]rune(slug)[:max])
+		// Prefer cutting at a word boundary if one is reasonably close
+		if idx := strings.LastIndex(cut, "-"); idx > len(cut)/2 {
+			cut = cut[:idx]
+		}
+		slug = strings.TrimSuffix(cut, "-")
+	}
+
+	return slug
+}
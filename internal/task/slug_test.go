@@ -0,0 +1,51 @@
+package task
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMakeSlug(t *testing.T) {
+	for _, tc := range []struct {
+		title string
+		opts  SlugOptions
+		want  string
+	}{
+		{"Call Zoë about the café", SlugOptions{}, "Call Zoë about the café"},
+		{"Call Zoë about the café", SlugOptions{Transliterate: true}, "call-zoe-about-the-cafe"},
+		{"Call Zoë about the café", SlugOptions{MaxLength: 100}, "call-zoë-about-the-café"},
+		{"Straße & Æther", SlugOptions{Transliterate: true}, "strasse-aether"},
+		{"  Fix -- the   bike!  ", SlugOptions{MaxLength: 100}, "fix-the-bike"},
+		// Cut at the last hyphen when it is past the halfway point
+		{"Renew passport before summer", SlugOptions{MaxLength: 20}, "renew-passport"},
+		// Punctuation dropped by slugging doesn't count against the cap
+		{"A!!!!!!!!!!!!!!!!!!!!b", SlugOptions{MaxLength: 3}, "a-b"},
+		// No hyphen close enough, so the word itself is cut
+		{"Supercalifragilistic", SlugOptions{MaxLength: 5}, "super"},
+	} {
+		got := makeSlug(tc.title, tc.opts)
+		if got != tc.want {
+			t.Errorf("makeSlug(%q, %+v) = %q, want %q", tc.title, tc.opts, got, tc.want)
+		}
+		if max := tc.opts.MaxLength; max > 0 && utf8.RuneCountInString(got) > max {
+			t.Errorf("makeSlug(%q, %+v) = %q, longer than %d", tc.title, tc.opts, got, max)
+		}
+	}
+}
+
+func TestCreateTaskCapsFilenameSlug(t *testing.T) {
+	SetSlugOptions(SlugOptions{MaxLength: 10, Transliterate: true})
+	t.Cleanup(func() { SetSlugOptions(SlugOptions{}) })
+
+	created, err := CreateTask(t.TempDir(), "Réserver la salle pour la réunion", "", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Title != "Réserver la salle pour la réunion" {
+		t.Errorf("title = %q, want it stored unchanged", created.Title)
+	}
+	if want := "--reserver__task.md"; !strings.HasSuffix(created.FilePath, want) {
+		t.Errorf("file = %s, want the slug capped at 10 characters (%s)", created.FilePath, want)
+	}
+}
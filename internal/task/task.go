@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/mph-llm-experiments/acore"
@@ -50,7 +52,7 @@ func CreateTask(dir, title, content string, tags []string, area string) (*denote
 	task.Area = area
 
 	// Build filename and path
-	filename := acore.BuildFilename(id, title, "task")
+	filename := acore.BuildFilename(id, slugTitle(title), "task")
 	filepath := dir + "/" + filename
 	task.FilePath = filepath
 
@@ -93,7 +95,7 @@ func CreateProject(dir, title, content string, tags []string) (*denote.Project,
 	project.Modified = now
 	project.Status = denote.ProjectStatusActive
 
-	filename := acore.BuildFilename(id, title, "project")
+	filename := acore.BuildFilename(id, slugTitle(title), "project")
 	filepath := dir + "/" + filename
 	project.FilePath = filepath
 
@@ -112,13 +114,21 @@ func FindTaskByID(dir string, id int) (*denote.Task, error) {
 		return nil, err
 	}
 
+	var matches []*denote.Task
+	var paths []string
 	for _, task := range tasks {
 		if task.IndexID == id {
-			return task, nil
+			matches = append(matches, task)
+			paths = append(paths, task.FilePath)
 		}
 	}
-
-	return nil, fmt.Errorf("task %d not found", id)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("task %d not found", id)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, AmbiguousIDError("task", id, paths)
 }
 
 // FindProjectByID finds a project by its sequential ID
@@ -129,13 +139,28 @@ func FindProjectByID(dir string, id int) (*denote.Project, error) {
 		return nil, err
 	}
 
+	var matches []*denote.Project
+	var paths []string
 	for _, project := range projects {
 		if project.IndexID == id {
-			return project, nil
+			matches = append(matches, project)
+			paths = append(paths, project.FilePath)
 		}
 	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("project %d not found", id)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, AmbiguousIDError("project", id, paths)
+}
 
-	return nil, fmt.Errorf("project %d not found", id)
+// AmbiguousIDError reports an index_id shared by several files. Lookups
+// refuse to pick one, so an update can never land on the wrong file.
+func AmbiguousIDError(kind string, id int, paths []string) error {
+	return fmt.Errorf("%s %d is ambiguous: index_id %d is used by %d files (%s); run 'atask doctor'",
+		kind, id, id, len(paths), strings.Join(paths, ", "))
 }
 
 // FindTaskByEntityID finds a task by its ULID (or legacy Denote ID)
@@ -155,6 +180,32 @@ func FindTaskByEntityID(dir string, entityID string) (*denote.Task, error) {
 	return nil, fmt.Errorf("task with ID %s not found", entityID)
 }
 
+// FindTasksReferencing returns the tasks whose related_tasks list the given
+// ULID, ordered by index_id. Relations are stored on one side only, so this
+// is the way to find a task's inbound links.
+func FindTasksReferencing(dir string, entityID string) ([]*denote.Task, error) {
+	scanner := denote.NewScanner(dir)
+	tasks, err := scanner.FindTasks()
+	if err != nil {
+		return nil, err
+	}
+	return TasksReferencing(tasks, entityID), nil
+}
+
+// TasksReferencing is FindTasksReferencing over tasks already scanned.
+func TasksReferencing(tasks []*denote.Task, entityID string) []*denote.Task {
+	var referencing []*denote.Task
+	for _, task := range tasks {
+		if task.ID != entityID && contains(task.RelatedTasks, entityID) {
+			referencing = append(referencing, task)
+		}
+	}
+	sort.Slice(referencing, func(i, j int) bool {
+		return referencing[i].IndexID < referencing[j].IndexID
+	})
+	return referencing
+}
+
 // FindProjectByEntityID finds a project by its ULID (or legacy Denote ID)
 func FindProjectByEntityID(dir string, entityID string) (*denote.Project, error) {
 	scanner := denote.NewScanner(dir)
@@ -208,7 +259,7 @@ func CloneTaskForRecurrence(dir string, original *denote.Task, newDueDate string
 	task.Recur = original.TaskMetadata.Recur
 	// StartDate and TodayDate intentionally left empty
 
-	filename := acore.BuildFilename(id, original.Title, "task")
+	filename := acore.BuildFilename(id, slugTitle(original.Title), "task")
 	filepath := dir + "/" + filename
 	task.FilePath = filepath
 
@@ -270,7 +321,7 @@ func CreateAction(dir, title, actionType, proposedBy, body string, fields map[st
 	action.ProposedBy = proposedBy
 	action.Fields = fields
 
-	filename := acore.BuildFilename(id, title, "action")
+	filename := acore.BuildFilename(id, slugTitle(title), "action")
 	fp := filepath.Join(queueDir, filename)
 	action.FilePath = fp
 
@@ -289,13 +340,21 @@ func FindActionByID(dir string, id int) (*denote.Action, error) {
 		return nil, err
 	}
 
+	var matches []*denote.Action
+	var paths []string
 	for _, action := range actions {
 		if action.IndexID == id {
-			return action, nil
+			matches = append(matches, action)
+			paths = append(paths, action.FilePath)
 		}
 	}
-
-	return nil, fmt.Errorf("action %d not found", id)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("action %d not found", id)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, AmbiguousIDError("action", id, paths)
 }
 
 // FindActionByEntityID finds an action by its ULID.
@@ -330,6 +389,55 @@ func ArchiveAction(dir string, action *denote.Action) error {
 	return nil
 }
 
+// UnarchiveAction moves an archived action file from queue/archive/ back to
+// queue/, the reverse of ArchiveAction, and updates action.FilePath.
+func UnarchiveAction(dir string, action *denote.Action) error {
+	newPath := filepath.Join(dir, "queue", filepath.Base(action.FilePath))
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("action file already exists in queue: %s", filepath.Base(newPath))
+	}
+	if err := os.Rename(action.FilePath, newPath); err != nil {
+		return fmt.Errorf("failed to unarchive action: %w", err)
+	}
+	action.FilePath = newPath
+	return nil
+}
+
+// FindArchivedAction finds an archived action by integer index_id or ULID.
+func FindArchivedAction(dir string, identifier string) (*denote.Action, error) {
+	scanner := denote.NewScanner(dir)
+	actions, err := scanner.FindArchivedActions()
+	if err != nil {
+		return nil, err
+	}
+
+	num, numErr := strconv.Atoi(identifier)
+	for _, action := range actions {
+		if (numErr == nil && action.IndexID == num) || action.ID == identifier {
+			return action, nil
+		}
+	}
+
+	return nil, fmt.Errorf("archived action %s not found", identifier)
+}
+
+// ArchiveTask moves a task file to the archive/ subdirectory, where the
+// scanner no longer finds it by default.
+func ArchiveTask(dir string, t *denote.Task) error {
+	archiveDir := filepath.Join(dir, "archive")
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	newPath := filepath.Join(archiveDir, filepath.Base(t.FilePath))
+	if err := os.Rename(t.FilePath, newPath); err != nil {
+		return fmt.Errorf("failed to archive task: %w", err)
+	}
+	t.FilePath = newPath
+
+	return nil
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,91 @@
+package task
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mph-llm-experiments/acore"
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+func TestFindByIDRejectsDuplicateIndexIDs(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := CreateTask(dir, "Pay invoice", "", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := CreateTask(dir, "Pay invoice again", "", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second.IndexID = first.IndexID
+	if err := UpdateTaskFile(second.FilePath, second); err != nil {
+		t.Fatal(err)
+	}
+
+	p1, err := CreateProject(dir, "Office move", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2, err := CreateProject(dir, "Office move copy", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2.IndexID = p1.IndexID
+	if err := denote.UpdateProjectFile(p2.FilePath, p2); err != nil {
+		t.Fatal(err)
+	}
+
+	a1, err := CreateAction(dir, "Create task", denote.ActionTypeTaskCreate, "agent", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2, err := CreateAction(dir, "Create task twice", denote.ActionTypeTaskCreate, "agent", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2.IndexID = a1.IndexID
+	if err := acore.UpdateFrontmatter(acore.NewLocalStore(filepath.Dir(a2.FilePath)), filepath.Base(a2.FilePath), a2); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		find  func() error
+		paths []string
+	}{
+		{"task", func() error { _, err := FindTaskByID(dir, first.IndexID); return err },
+			[]string{first.FilePath, second.FilePath}},
+		{"project", func() error { _, err := FindProjectByID(dir, p1.IndexID); return err },
+			[]string{p1.FilePath, p2.FilePath}},
+		{"action", func() error { _, err := FindActionByID(dir, a1.IndexID); return err },
+			[]string{a1.FilePath, a2.FilePath}},
+	}
+	for _, tt := range tests {
+		err := tt.find()
+		if err == nil {
+			t.Errorf("%s lookup of a duplicate index_id succeeded, want ambiguity error", tt.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), "ambiguous") {
+			t.Errorf("%s error = %v, want it to mention the ambiguity", tt.name, err)
+		}
+		for _, p := range tt.paths {
+			if !strings.Contains(err.Error(), p) {
+				t.Errorf("%s error = %v, want it to list %s", tt.name, err, p)
+			}
+		}
+	}
+
+	// Unique IDs still resolve
+	other, err := CreateTask(dir, "File receipt", "", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := FindTaskByID(dir, other.IndexID)
+	if err != nil || got.FilePath != other.FilePath {
+		t.Errorf("FindTaskByID(%d) = %v, %v; want %s", other.IndexID, got, err, other.FilePath)
+	}
+}
@@ -0,0 +1,160 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mph-llm-experiments/atask/internal/denote"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateTask describes one task a template creates. Due is a relative
+// offset such as +3d, counted from the day the template is applied; Body
+// becomes the task file's content.
+type TemplateTask struct {
+	Title    string   `yaml:"title"`
+	Priority string   `yaml:"priority,omitempty"`
+	Estimate int      `yaml:"estimate,omitempty"`
+	Due      string   `yaml:"due,omitempty"`
+	Area     string   `yaml:"area,omitempty"`
+	Tags     []string `yaml:"tags,omitempty"`
+	Body     string   `yaml:"body,omitempty"`
+}
+
+// TaskTemplate is a reusable skeleton for a single task, applied by
+// task new --template. Its title is optional.
+type TaskTemplate struct {
+	Name         string `yaml:"-"`
+	TemplateTask `yaml:",inline"`
+}
+
+// ProjectTemplate lists the starter tasks for a new project.
+type ProjectTemplate struct {
+	Name  string         `yaml:"-"`
+	Tasks []TemplateTask `yaml:"tasks"`
+}
+
+// templatePath returns the file for a named template in dir, accepting
+// .yaml or .yml.
+func templatePath(dir, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid template name %q", name)
+	}
+	for _, ext := range []string{".yaml", ".yml"} {
+		path := filepath.Join(dir, name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("template %q not found (looked for %s)", name, filepath.Join(dir, name+".yaml"))
+}
+
+// readTemplate finds the named template in dir and decodes it into v.
+func readTemplate(dir, name string, v interface{}) (string, error) {
+	path, err := templatePath(dir, name)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return "", fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// LoadProjectTemplate reads and validates the named template from dir, so
+// nothing is created from a template with a bad entry.
+func LoadProjectTemplate(dir, name string) (*ProjectTemplate, error) {
+	tmpl := &ProjectTemplate{Name: name}
+	path, err := readTemplate(dir, name, tmpl)
+	if err != nil {
+		return nil, err
+	}
+	if len(tmpl.Tasks) == 0 {
+		return nil, fmt.Errorf("template %s has no tasks", path)
+	}
+	for i, tt := range tmpl.Tasks {
+		if strings.TrimSpace(tt.Title) == "" {
+			return nil, fmt.Errorf("template %s task %d: title required", path, i+1)
+		}
+		if err := tt.validate(); err != nil {
+			return nil, fmt.Errorf("template %s task %d: %w", path, i+1, err)
+		}
+	}
+	return tmpl, nil
+}
+
+// LoadTaskTemplate reads and validates the named single-task template
+// from dir.
+func LoadTaskTemplate(dir, name string) (*TaskTemplate, error) {
+	tmpl := &TaskTemplate{Name: name}
+	path, err := readTemplate(dir, name, tmpl)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.validate(); err != nil {
+		return nil, fmt.Errorf("template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+func (tt TemplateTask) validate() error {
+	if tt.Priority != "" && !denote.IsValidPriority(tt.Priority) {
+		return fmt.Errorf("invalid priority: %s", tt.Priority)
+	}
+	if tt.Estimate != 0 && !denote.IsValidEstimate(tt.Estimate) {
+		return fmt.Errorf("invalid estimate: %d (must be 0, 1, 2, 3, 5, 8, or 13)", tt.Estimate)
+	}
+	if tt.Due != "" {
+		if _, err := denote.ParseRelativeOffset(tt.Due); err != nil {
+			return fmt.Errorf("invalid due: %w", err)
+		}
+	}
+	return nil
+}
+
+// DueDate resolves the task's relative due offset against from, returning
+// "" when the template sets no due date.
+func (tt TemplateTask) DueDate(from time.Time) string {
+	if tt.Due == "" {
+		return ""
+	}
+	offset, err := denote.ParseRelativeOffset(tt.Due)
+	if err != nil {
+		return ""
+	}
+	return offset.Apply(from).Format("2006-01-02")
+}
+
+// CreateTemplateTasks creates the template's tasks in dir, linked to
+// project and sharing its area unless a task sets its own, with due dates
+// counted from from.
+func CreateTemplateTasks(dir string, tmpl *ProjectTemplate, project *denote.Project, from time.Time) ([]*denote.Task, error) {
+	var created []*denote.Task
+	for _, tt := range tmpl.Tasks {
+		area := tt.Area
+		if area == "" {
+			area = project.ProjectMetadata.Area
+		}
+		t, err := CreateTask(dir, tt.Title, tt.Body, append([]string(nil), tt.Tags...), area)
+		if err != nil {
+			return created, fmt.Errorf("failed to create task %q: %w", tt.Title, err)
+		}
+		t.TaskMetadata.ProjectID = strconv.Itoa(project.IndexID)
+		t.TaskMetadata.Priority = tt.Priority
+		t.TaskMetadata.Estimate = tt.Estimate
+		t.TaskMetadata.DueDate = tt.DueDate(from)
+		if err := UpdateTaskFile(t.FilePath, t); err != nil {
+			return created, fmt.Errorf("failed to update task %q: %w", tt.Title, err)
+		}
+		created = append(created, t)
+	}
+	return created, nil
+}
@@ -1,13 +1,111 @@
 package task
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/mph-llm-experiments/acore"
 	"github.com/mph-llm-experiments/atask/internal/denote"
 )
 
+// renameFile and parseWritten are os.Rename and denote.ParseTaskFile,
+// swapped out by tests to make the last steps of SafeUpdateTaskFile fail.
+var (
+	renameFile   = os.Rename
+	parseWritten = denote.ParseTaskFile
+)
+
 // UpdateTaskFile updates the task metadata in a file using acore.
+// Writes go through SafeUpdateTaskFile.
 func UpdateTaskFile(path string, task *denote.Task) error {
+	return SafeUpdateTaskFile(path, task)
+}
+
+// SafeUpdateTaskFile updates the task metadata without ever leaving a
+// half-written task behind. The update is applied to a temporary copy in
+// the same directory, the copy must re-parse as the same task and is
+// synced to disk, and only then is it renamed over the original. On any
+// failure the original file is left untouched.
+func SafeUpdateTaskFile(path string, task *denote.Task) error {
+	return safeWriteTask(path, task, func(store acore.Store, name string) error {
+		return acore.UpdateFrontmatter(store, name, task)
+	})
+}
+
+// SafeWriteTaskFile is SafeUpdateTaskFile for changes to the body as well:
+// the file is rewritten with task's frontmatter followed by body.
+func SafeWriteTaskFile(path string, task *denote.Task, body string) error {
+	return safeWriteTask(path, task, func(store acore.Store, name string) error {
+		return acore.WriteFile(store, name, task, body)
+	})
+}
+
+// safeWriteTask applies write to a temporary copy of path and swaps the
+// copy in once it re-parses as the same task.
+func safeWriteTask(path string, task *denote.Task, write func(acore.Store, string) error) error {
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read task file: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat task file: %w", err)
+	}
+
+	dir, name := filepath.Split(path)
+	tmp, err := os.CreateTemp(dir, "."+name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once renamed
+
+	if _, err := tmp.Write(original); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+
 	task.Modified = acore.Now()
-	store, name := storeAndName(path)
-	return acore.UpdateFrontmatter(store, name, task)
+	store, tmpName := storeAndName(tmpPath)
+	if err := write(store, tmpName); err != nil {
+		return err
+	}
+
+	written, err := parseWritten(tmpPath)
+	if err != nil {
+		return fmt.Errorf("updated task did not re-parse, original kept: %w", err)
+	}
+	// Legacy files carry their ID in the filename, which the temp copy lacks
+	if (written.ID != "" && written.ID != task.ID) || written.IndexID != task.IndexID {
+		return fmt.Errorf("updated task re-parsed as %s (#%d), original kept", written.ID, written.IndexID)
+	}
+
+	if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+	if err := syncFile(tmpPath); err != nil {
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := renameFile(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace task file: %w", err)
+	}
+	// Persist the rename itself; a directory that can't be synced is not
+	// worth failing an already completed update over
+	syncFile(dir)
+	return nil
+}
+
+// syncFile flushes a file or directory to disk.
+func syncFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
 }
@@ -0,0 +1,103 @@
+package task
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mph-llm-experiments/atask/internal/denote"
+)
+
+// assertUntouched fails unless path still holds want and no temp copy is
+// left next to it.
+func assertUntouched(t *testing.T, path string, want []byte) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("task file changed after a failed update:\n%s\nwant:\n%s", got, want)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) == ".tmp" {
+			t.Errorf("temp file %s left behind", e.Name())
+		}
+	}
+}
+
+func TestSafeUpdateTaskFileWritesUpdate(t *testing.T) {
+	created, err := CreateTask(t.TempDir(), "Sweep porch", "", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created.TaskMetadata.Priority = denote.PriorityP1
+	if err := SafeUpdateTaskFile(created.FilePath, created); err != nil {
+		t.Fatal(err)
+	}
+	got, err := denote.ParseTaskFile(created.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TaskMetadata.Priority != denote.PriorityP1 {
+		t.Errorf("priority after update = %q, want p1", got.TaskMetadata.Priority)
+	}
+}
+
+func TestSafeUpdateTaskFileKeepsOriginalWhenParseBackFails(t *testing.T) {
+	created, err := CreateTask(t.TempDir(), "Sweep porch", "", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(created.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parseWritten = func(string) (*denote.Task, error) { return nil, errors.New("broken frontmatter") }
+	t.Cleanup(func() { parseWritten = denote.ParseTaskFile })
+
+	created.TaskMetadata.Priority = denote.PriorityP1
+	if err := SafeUpdateTaskFile(created.FilePath, created); err == nil {
+		t.Error("update whose copy does not re-parse succeeded, want error")
+	}
+	assertUntouched(t, created.FilePath, before)
+
+	// A copy that re-parses as a different task is refused too
+	parseWritten = func(path string) (*denote.Task, error) {
+		tk, err := denote.ParseTaskFile(path)
+		if err == nil {
+			tk.IndexID++
+		}
+		return tk, err
+	}
+	if err := SafeUpdateTaskFile(created.FilePath, created); err == nil {
+		t.Error("update whose copy re-parses with another index_id succeeded, want error")
+	}
+	assertUntouched(t, created.FilePath, before)
+}
+
+func TestSafeUpdateTaskFileKeepsOriginalWhenRenameFails(t *testing.T) {
+	created, err := CreateTask(t.TempDir(), "Sweep porch", "", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(created.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renameFile = func(string, string) error { return errors.New("disk full") }
+	t.Cleanup(func() { renameFile = os.Rename })
+
+	created.TaskMetadata.Priority = denote.PriorityP1
+	if err := SafeUpdateTaskFile(created.FilePath, created); err == nil {
+		t.Error("update with a failing rename succeeded, want error")
+	}
+	assertUntouched(t, created.FilePath, before)
+}
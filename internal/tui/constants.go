@@ -10,12 +10,13 @@ const (
 
 // Status Symbols
 const (
-	StatusSymbolOpen      = "○"
-	StatusSymbolDone      = "✓"
-	StatusSymbolPaused    = "⏸"
-	StatusSymbolDelegated = "→"
-	StatusSymbolDropped   = "⨯"
-	StatusSymbolActive    = "●"
+	StatusSymbolOpen       = "○"
+	StatusSymbolInProgress = "◐"
+	StatusSymbolDone       = "✓"
+	StatusSymbolPaused     = "⏸"
+	StatusSymbolDelegated  = "→"
+	StatusSymbolDropped    = "⨯"
+	StatusSymbolActive     = "●"
 )
 
 // Priority Levels
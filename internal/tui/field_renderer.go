@@ -99,6 +99,9 @@ func (fr *FieldRenderer) RenderStatusWithCursor(status string, isEditing bool, e
 	case denote.TaskStatusDone:
 		symbol = StatusSymbolDone
 		style = lipgloss.NewStyle().Foreground(lipgloss.Color("70"))
+	case denote.TaskStatusInProgress:
+		symbol = StatusSymbolInProgress
+		style = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
 	case denote.TaskStatusPaused:
 		symbol = StatusSymbolPaused
 		style = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
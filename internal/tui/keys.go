@@ -1759,7 +1759,7 @@ func (m Model) handleEstimateEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			
 			// Update the task
 			if file.IsTask() {
-				if err := denote.UpdateTaskEstimate(file.Path, estimate); err != nil {
+				if err := updateTaskEstimate(file.Path, estimate); err != nil {
 					m.statusMsg = fmt.Sprintf("Failed to update estimate: %v", err)
 				} else {
 					if estimate == 0 {
@@ -313,8 +313,9 @@ func (m *Model) applyFilters() {
 						continue
 					}
 				} else if m.stateFilter == "active" {
-					// Active: open/delegated tasks, active projects
+					// Active: open/in-progress/delegated tasks, active projects
 					if taskMeta != nil && taskMeta.Status != denote.TaskStatusOpen &&
+						taskMeta.Status != denote.TaskStatusInProgress &&
 						taskMeta.Status != denote.TaskStatusDelegated {
 						continue
 					}
@@ -676,7 +677,10 @@ func (m *Model) updateTaskPriority(priority string) error {
 	file := m.filtered[m.cursor]
 
 	if file.IsTask() {
-		if err := denote.UpdateTaskPriority(file.Path, priority); err != nil {
+		if priority != "" && !denote.IsValidPriority(priority) {
+			return fmt.Errorf("invalid priority: %s", priority)
+		}
+		if err := updateTaskFile(file.Path, func(t *denote.Task) { t.TaskMetadata.Priority = priority }); err != nil {
 			return err
 		}
 		if priority == "" {
@@ -785,7 +789,7 @@ func (m *Model) updateTaskField(field, value string) error {
 	case "priority":
 		task.TaskMetadata.Priority = value
 	case "status":
-		task.TaskMetadata.Status = value
+		task.SetStatus(value)
 	case "due_date":
 		if value != "" {
 			parsed, err := denote.ParseNaturalDate(value)
@@ -1028,7 +1032,7 @@ func (m *Model) updateCurrentTaskStatus(newStatus string) error {
 	}
 	
 	// Update the task status
-	err := denote.UpdateTaskStatus(file.Path, newStatus)
+	err := updateTaskStatus(file.Path, newStatus)
 	if err != nil {
 		return err
 	}
@@ -1056,15 +1060,22 @@ func (m *Model) adjustScrollOffset() {
 // Returns a status message about the new task, or empty string if not recurring.
 func (m *Model) handleTaskRecurrence(filePath string) string {
 	t, err := denote.ParseTaskFile(filePath)
-	if err != nil || t.TaskMetadata.Recur == "" || t.TaskMetadata.DueDate == "" {
+	if err != nil || t.TaskMetadata.Recur == "" {
 		return ""
 	}
-
-	currentDue, err := time.ParseInLocation("2006-01-02", t.TaskMetadata.DueDate, time.Now().Location())
-	if err != nil {
+	afterCompletion := recurrence.IsAfterCompletion(t.TaskMetadata.Recur)
+	if t.TaskMetadata.DueDate == "" && !afterCompletion {
 		return ""
 	}
 
+	var currentDue time.Time
+	if t.TaskMetadata.DueDate != "" {
+		currentDue, err = time.ParseInLocation("2006-01-02", t.TaskMetadata.DueDate, time.Now().Location())
+		if err != nil && !afterCompletion {
+			return ""
+		}
+	}
+
 	nextDue, err := recurrence.NextDueDate(t.TaskMetadata.Recur, currentDue)
 	if err != nil {
 		return ""
@@ -1136,12 +1147,39 @@ func (m *Model) findTasksAffectedByProjectDeletion() {
 
 // clearProjectFromTask removes the project_id from a task
 func (m *Model) clearProjectFromTask(taskPath string) error {
-	if err := denote.UpdateTaskProjectID(taskPath, ""); err != nil {
+	if err := updateTaskFile(taskPath, func(t *denote.Task) { t.TaskMetadata.ProjectID = "" }); err != nil {
 		return err
 	}
 	return nil
 }
 
+// updateTaskFile applies change to the task at path and writes it back
+// through task.SafeUpdateTaskFile, so a failed write keeps the original.
+func updateTaskFile(path string, change func(*denote.Task)) error {
+	t, err := denote.ParseTaskFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to parse task: %w", err)
+	}
+	change(t)
+	return task.SafeUpdateTaskFile(path, t)
+}
+
+// updateTaskStatus sets the status of the task at path.
+func updateTaskStatus(path, status string) error {
+	if !denote.IsValidTaskStatus(status) {
+		return fmt.Errorf("invalid status: %s", status)
+	}
+	return updateTaskFile(path, func(t *denote.Task) { t.SetStatus(status) })
+}
+
+// updateTaskEstimate sets the estimate of the task at path; 0 clears it.
+func updateTaskEstimate(path string, estimate int) error {
+	if estimate != 0 && !denote.IsValidEstimate(estimate) {
+		return fmt.Errorf("invalid estimate: %d (must be 0, 1, 2, 3, 5, 8, or 13)", estimate)
+	}
+	return updateTaskFile(path, func(t *denote.Task) { t.TaskMetadata.Estimate = estimate })
+}
+
 // updateProjectTaskStatus updates the status of the currently selected task in project view
 func (m *Model) updateProjectTaskStatus(newStatus string) error {
 	if m.projectTasksCursor >= len(m.projectTasks) {
@@ -1151,13 +1189,13 @@ func (m *Model) updateProjectTaskStatus(newStatus string) error {
 	task := &m.projectTasks[m.projectTasksCursor]
 	
 	// Update the task status
-	err := denote.UpdateTaskStatus(task.FilePath, newStatus)
+	err := updateTaskStatus(task.FilePath, newStatus)
 	if err != nil {
 		return err
 	}
 	
 	// Update the in-memory task (but no cache to update)
-	task.TaskMetadata.Status = newStatus
+	task.SetStatus(newStatus)
 	
 	return nil
 }
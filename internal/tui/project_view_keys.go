@@ -350,8 +350,11 @@ func (m Model) handleProjectViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateTaskPriorityFromProject updates a task priority from the project view
 func (m *Model) updateTaskPriorityFromProject(task *denote.Task, priority string) error {
+	if priority != "" && !denote.IsValidPriority(priority) {
+		return fmt.Errorf("invalid priority: %s", priority)
+	}
 	task.TaskMetadata.Priority = priority
-	if err := denote.UpdateTaskPriority(task.FilePath, priority); err != nil {
+	if err := updateTaskFile(task.FilePath, func(t *denote.Task) { t.TaskMetadata.Priority = priority }); err != nil {
 		return err
 	}
 	return nil
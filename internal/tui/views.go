@@ -242,6 +242,8 @@ func (m Model) renderTaskLine(index int, file denote.File, task *denote.Task) st
 	status := StatusSymbolOpen // open
 	if task.TaskMetadata.Status == denote.TaskStatusDone {
 		status = StatusSymbolDone
+	} else if task.TaskMetadata.Status == denote.TaskStatusInProgress {
+		status = StatusSymbolInProgress
 	} else if task.TaskMetadata.Status == denote.TaskStatusPaused {
 		status = StatusSymbolPaused
 	} else if task.TaskMetadata.Status == denote.TaskStatusDelegated {